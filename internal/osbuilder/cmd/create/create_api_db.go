@@ -0,0 +1,52 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/onexstack/osbuilder/internal/osbuilder/helper"
+	"github.com/onexstack/osbuilder/internal/osbuilder/introspect"
+	"github.com/onexstack/osbuilder/internal/osbuilder/types"
+)
+
+// inspectTimeout bounds the introspection of the --from-db database.
+const inspectTimeout = 30 * time.Second
+
+// inspectDB reads the tables of the --from-db database and sets the kinds to generate, with the
+// field spec of each kind derived from the columns of its table.
+func (o *APIOptions) inspectDB(ws *types.WebServer) error {
+	if len(o.Kinds) > 0 {
+		return fmt.Errorf("--kinds cannot be used with --from-db; select tables with --tables")
+	}
+	if err := o.validateSpecSource("--from-db", "the table columns"); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), inspectTimeout)
+	defer cancel()
+	tables, err := introspect.Inspect(ctx, o.FromDB, o.Tables...)
+	if err != nil {
+		return fmt.Errorf("inspect database: %w", err)
+	}
+	if len(tables) == 0 {
+		return fmt.Errorf("no tables found in the database")
+	}
+
+	kinds, notes := introspect.Kinds(tables, helper.ExtractProjectPrefix()(ws.BinaryName))
+	for _, note := range notes {
+		fmt.Fprintln(o.ErrOut, color.YellowString("Warning! %s", note))
+	}
+
+	for _, k := range kinds {
+		if other, ok := o.specs[k.Name]; ok {
+			return fmt.Errorf("tables %q and %q both map to kind %q; select one of them with --tables", other.Table, k.Table, k.Name)
+		}
+		if err := o.addSpec(ws, k, "--from-db"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
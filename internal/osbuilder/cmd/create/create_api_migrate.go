@@ -0,0 +1,136 @@
+package create
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fatih/color"
+
+	"github.com/onexstack/osbuilder/internal/osbuilder/file"
+	"github.com/onexstack/osbuilder/internal/osbuilder/migration"
+	"github.com/onexstack/osbuilder/internal/osbuilder/types"
+)
+
+// snapshotKinds returns copies of the recorded specs of the kinds to generate, so that the
+// columns they had before this run can be compared with the columns they have after it.
+func (o *APIOptions) snapshotKinds(ws *types.WebServer) map[string]*types.Kind {
+	before := map[string]*types.Kind{}
+	for _, kind := range o.Kinds {
+		k, ok := ws.FindKind(kind)
+		if !ok {
+			continue
+		}
+		copied := *k
+		copied.Fields = make([]*types.Field, 0, len(k.Fields))
+		for _, f := range k.Fields {
+			field := *f
+			copied.Fields = append(copied.Fields, &field)
+		}
+		before[kind] = &copied
+	}
+	return before
+}
+
+// GenerateMigrations writes the migrations that create the tables of new kinds and alter the
+// tables of kinds whose columns changed, for every SQL dialect, and returns their number. The
+// migrations of the kinds stored in a named datasource are written to the migrations of that
+// datasource. Kinds whose model is still registered for GORM auto-migration are skipped, since
+// GORM changes their tables itself.
+func (o *APIOptions) GenerateMigrations(fm *file.FileManager, ws *types.WebServer, before map[string]*types.Kind) (int, error) {
+	// versions holds the next version of each migrations directory.
+	versions := map[string]int{}
+	nextVersion := func(dir string) (int, error) {
+		if v, ok := versions[dir]; ok {
+			return v, nil
+		}
+		return migration.NextVersion(dir)
+	}
+
+	count := 0
+	dir := filepath.Join(o.RootDir, ws.Migrations())
+	if ws.Outbox != "" && !migration.HasCreate(dir, ws.OutboxTable()) {
+		version, err := nextVersion(dir)
+		if err != nil {
+			return count, err
+		}
+		if err := GenerateCreateMigration(fm, dir, version, ws.OutboxTable(), migration.OutboxColumns()); err != nil {
+			return count, err
+		}
+		versions[dir] = version + 1
+		count++
+	}
+	for _, kind := range o.Kinds {
+		k, _ := ws.FindKind(kind)
+		dir := filepath.Join(o.RootDir, ws.KindMigrations(k))
+		hook := filepath.Join(o.RootDir, ws.Model(), "hook_"+types.NewREST(kind, o.APIVersion).FileName)
+		if src, err := os.ReadFile(hook); err == nil && bytes.Contains(src, []byte("registry.Register(")) {
+			fmt.Fprintln(o.ErrOut, color.YellowString("Warning! kind %q is migrated by GORM (registry.Register in %s); "+
+				"remove the registration to manage its table with migrations", kind, filepath.Base(hook)))
+			continue
+		}
+
+		table := migration.Table(ws.BinaryName, k)
+		scripts := map[migration.Dialect]*migration.Script{}
+		var name string
+		old, recorded := before[kind]
+		switch {
+		case !migration.HasCreate(dir, table):
+			name = "create_" + table
+			for _, d := range migration.Dialects {
+				scripts[d] = migration.Create(d, table, migration.Columns(k))
+			}
+		case recorded:
+			name = "alter_" + table
+			for _, d := range migration.Dialects {
+				if script, ok := migration.Alter(d, table, migration.Columns(old), migration.Columns(k)); ok {
+					scripts[d] = script
+				}
+			}
+		}
+		if len(scripts) == 0 {
+			continue
+		}
+
+		version, err := nextVersion(dir)
+		if err != nil {
+			return count, err
+		}
+		up, down := migration.FileNames(version, name)
+		for _, d := range migration.Dialects {
+			// Dialects whose columns did not change get an empty migration, so that the
+			// versions of all dialects stay the same.
+			script, ok := scripts[d]
+			if !ok {
+				script = &migration.Script{Up: "-- No changes for this dialect.\n", Down: "-- No changes for this dialect.\n"}
+			}
+			if err := fm.WriteFile(filepath.Join(dir, string(d), up), []byte(script.Up)); err != nil {
+				return count, err
+			}
+			if err := fm.WriteFile(filepath.Join(dir, string(d), down), []byte(script.Down)); err != nil {
+				return count, err
+			}
+		}
+		versions[dir] = version + 1
+		count++
+	}
+	return count, nil
+}
+
+// GenerateCreateMigration writes the migration of the given version that creates a table with the
+// given columns into the migrations directory dir, for every SQL dialect. It writes the migrations
+// of the tables that are not the table of a kind, such as the outbox and the built-in user tables.
+func GenerateCreateMigration(fm *file.FileManager, dir string, version int, table string, cols []*migration.Column) error {
+	up, down := migration.FileNames(version, "create_"+table)
+	for _, d := range migration.Dialects {
+		script := migration.Create(d, table, cols)
+		if err := fm.WriteFile(filepath.Join(dir, string(d), up), []byte(script.Up)); err != nil {
+			return err
+		}
+		if err := fm.WriteFile(filepath.Join(dir, string(d), down), []byte(script.Down)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
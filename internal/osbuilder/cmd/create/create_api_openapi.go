@@ -0,0 +1,38 @@
+package create
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+
+	"github.com/onexstack/osbuilder/internal/osbuilder/openapi"
+	"github.com/onexstack/osbuilder/internal/osbuilder/types"
+)
+
+// loadOpenAPI reads the --from-openapi document and sets the kinds to generate, with the field
+// spec and custom methods of each kind derived from its paths and schemas.
+func (o *APIOptions) loadOpenAPI(ws *types.WebServer) error {
+	if err := o.validateSpecSource("--from-openapi", "the schemas"); err != nil {
+		return err
+	}
+
+	doc, err := openapi.Load(o.FromOpenAPI)
+	if err != nil {
+		return err
+	}
+
+	kinds, notes := openapi.Kinds(doc)
+	for _, note := range notes {
+		fmt.Fprintln(o.ErrOut, color.YellowString("Warning! %s", note))
+	}
+	if len(kinds) == 0 {
+		return fmt.Errorf("no resources found in %s", o.FromOpenAPI)
+	}
+
+	for _, k := range kinds {
+		if err := o.addSpec(ws, k, "--from-openapi"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
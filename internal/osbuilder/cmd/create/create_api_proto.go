@@ -0,0 +1,111 @@
+package create
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/onexstack/osbuilder/internal/osbuilder/file"
+	"github.com/onexstack/osbuilder/internal/osbuilder/helper"
+	"github.com/onexstack/osbuilder/internal/osbuilder/protofile"
+	"github.com/onexstack/osbuilder/internal/osbuilder/types"
+)
+
+// protoTemplate is the template of the messages of a kind.
+const protoTemplate = "/project/pkg/api/apiserver/v1/post.proto"
+
+// loadProto reads the resources of the --from-proto file and sets the kinds to generate, with
+// the field spec and custom methods of each kind derived from its messages.
+func (o *APIOptions) loadProto(ws *types.WebServer) error {
+	if err := o.validateSpecSource("--from-proto", "the resource messages"); err != nil {
+		return err
+	}
+
+	name := types.GetComponentName(ws.BinaryName)
+	apiDir, err := filepath.Abs(filepath.Join(o.RootDir, "pkg/api", name, o.APIVersion))
+	if err != nil {
+		return err
+	}
+	path, err := filepath.Abs(o.FromProto)
+	if err != nil {
+		return err
+	}
+	if filepath.Dir(path) != apiDir {
+		return fmt.Errorf("--from-proto must be a proto file of %s", apiDir)
+	}
+	if filepath.Base(path) == name+".proto" {
+		return fmt.Errorf("--from-proto cannot be the service proto of %s; declare the resources in another file", ws.BinaryName)
+	}
+
+	f, err := protofile.Load(path)
+	if err != nil {
+		return err
+	}
+
+	kinds, notes := protofile.Kinds(f)
+	for _, note := range notes {
+		fmt.Fprintln(o.ErrOut, color.YellowString("Warning! %s", note))
+	}
+	if len(kinds) == 0 {
+		return fmt.Errorf("no resources found in %s: a resource is a message <Kind> with a Create<Kind> method", o.FromProto)
+	}
+
+	for _, k := range kinds {
+		k.Proto = filepath.Base(path)
+		if err := o.addSpec(ws, k, "--from-proto"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkProtos checks that the proto files of the kinds generated from an existing proto file
+// declare the request and response messages of the methods the generated layers implement,
+// with the fields the layers read and set. The fields of the resource message are copied by
+// name, so only the ones the conversion sets explicitly are required.
+func (o *APIOptions) checkProtos(ws *types.WebServer) error {
+	var problems []string
+	for _, kind := range o.Kinds {
+		ws.PrepareRESTMetadata(kind)
+		if !ws.R.IsProtoFirst() {
+			continue
+		}
+
+		path := ws.Proj.Join(ws.API(), ws.R.ProtoFile())
+		got, err := protofile.Load(path)
+		if err != nil {
+			return err
+		}
+
+		rendered, err := helper.ExecuteTemplate(protoTemplate, helper.GetTemplateFuncMap(), &types.TemplateData{Project: o.Project, Web: ws})
+		if err != nil {
+			return err
+		}
+		src, _, err := file.AddVerbMessages(string(rendered), ws.R)
+		if err != nil {
+			return err
+		}
+		want, err := protofile.Parse(bytes.NewBufferString(src))
+		if err != nil {
+			return fmt.Errorf("parse the expected messages of kind %q: %w", kind, err)
+		}
+		if m, ok := want.Message(ws.R.SingularName); ok {
+			m.Fields = slices.DeleteFunc(m.Fields, func(f *protofile.Field) bool {
+				return f.Name != "deletedAt"
+			})
+		}
+
+		for _, problem := range protofile.Check(want, got, file.KindRPCs(ws.R)) {
+			problems = append(problems, fmt.Sprintf("%s: %s", ws.R.ProtoFile(), problem))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("the proto messages lack what the generated layers use:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
@@ -0,0 +1,330 @@
+package create
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+
+	"github.com/onexstack/osbuilder/internal/osbuilder/file"
+	"github.com/onexstack/osbuilder/internal/osbuilder/helper"
+	"github.com/onexstack/osbuilder/internal/osbuilder/known"
+	"github.com/onexstack/osbuilder/internal/osbuilder/types"
+)
+
+func TestRegisterKind(t *testing.T) {
+	tests := []struct {
+		name string
+		ws   *types.WebServer
+		// verbs are the custom methods of the kind.
+		verbs []string
+		// tpl is the template of the file that registers the kind, which is rendered before
+		// `create api` runs when the kind is registered by patching the server proto.
+		tpl string
+		// path is the file that registers the kind.
+		path string
+		// want lists the lines that register the kind.
+		want []string
+	}{
+		{
+			name: "hertz handlers register their routes",
+			ws:   &types.WebServer{WebFramework: known.WebFrameworkHertz},
+			tpl:  "/project/internal/apiserver/handler/hertz/post.go",
+			path: "internal/apiserver/handler/post.go",
+			want: []string{
+				"Register(func(v1 *route.RouterGroup, handler *Handler, mws ...app.HandlerFunc) {",
+				`rg.POST("", handler.CreatePost)`,
+				`rg.GET(":postID", handler.GetPost)`,
+			},
+		},
+		{
+			name: "nethttp handlers register their routes",
+			ws:   &types.WebServer{WebFramework: known.WebFrameworkNetHTTP},
+			tpl:  "/project/internal/apiserver/handler/nethttp/post.go",
+			path: "internal/apiserver/handler/post.go",
+			want: []string{
+				"Register(func(v1 *RouterGroup, handler *Handler, mws ...Middleware) {",
+				`rg := v1.Group("/posts", mws...)`,
+				`rg.GET("/{postID}", handler.GetPost)`,
+			},
+		},
+		{
+			name: "kitex services declare the kind methods",
+			ws:   &types.WebServer{WebFramework: known.WebFrameworkKitex},
+			tpl:  "/project/pkg/api/apiserver/v1/apiserver.proto",
+			path: "pkg/api/apiserver/v1/apiserver.proto",
+			want: []string{
+				`import "apiserver/v1/post.proto";`,
+				"service Apiserver {",
+				"rpc CreatePost(CreatePostRequest) returns (CreatePostResponse);",
+				"rpc DeletePosts(DeletePostsRequest) returns (DeletePostsResponse);",
+				"rpc ListPost(ListPostRequest) returns (ListPostResponse);",
+			},
+		},
+		{
+			name:  "connect services declare the kind methods and verbs",
+			ws:    &types.WebServer{WebFramework: known.WebFrameworkConnect, GRPCServiceName: "BlogService"},
+			verbs: []string{"publish"},
+			tpl:   "/project/pkg/api/apiserver/v1/apiserver.proto",
+			path:  "pkg/api/apiserver/v1/apiserver.proto",
+			want: []string{
+				`import "apiserver/v1/post.proto";`,
+				"service BlogService {",
+				"rpc GetPost(GetPostRequest) returns (GetPostResponse);",
+				"rpc PublishPost(PublishPostRequest) returns (PublishPostResponse);",
+			},
+		},
+		{
+			name: "connect handlers mount the kind procedures",
+			ws:   &types.WebServer{WebFramework: known.WebFrameworkConnect, GRPCServiceName: "BlogService"},
+			tpl:  "/project/internal/apiserver/handler/connect/post.go",
+			path: "internal/apiserver/handler/post.go",
+			want: []string{
+				"Register(func(mux *http.ServeMux, handler *Handler, opts ...connect.HandlerOption) {",
+				"handle(mux, v1.BlogService_CreatePost_FullMethodName, handler.CreatePost, opts...)",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proj := &types.Project{
+				Metadata:   &types.Metadata{},
+				D:          (&types.GeneratedData{WorkDir: t.TempDir(), APIVersion: "v1", ModuleName: "github.com/acme/blog"}).Complete(),
+				WebServers: []*types.WebServer{tt.ws},
+			}
+			ws := tt.ws
+			ws.BinaryName, ws.WithHealthz = "mb-apiserver", true
+			ws.Complete(proj)
+			ws.UpsertKind(&types.Kind{Name: "post", Verbs: tt.verbs})
+			ws.PrepareRESTMetadata("post")
+
+			path := proj.Join(tt.path)
+			out, err := helper.ExecuteTemplate(tt.tpl, helper.GetTemplateFuncMap(), &types.TemplateData{Project: proj, Web: ws})
+			require.NoError(t, err)
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+			require.NoError(t, os.WriteFile(path, out, 0o644))
+
+			if ws.ServesGRPC() && filepath.Ext(path) == ".proto" {
+				fm := file.NewFileManager(proj.D.WorkDir, true)
+				require.NoError(t, fm.AddNewGRPCMethod(ws))
+				patched, err := os.ReadFile(path)
+				require.NoError(t, err)
+
+				// The original file is kept, and patching the file again changes nothing.
+				assert.FileExists(t, path+".bak")
+				require.NoError(t, fm.AddNewGRPCMethod(ws))
+				again, err := os.ReadFile(path)
+				require.NoError(t, err)
+				assert.Equal(t, string(patched), string(again))
+				out = patched
+			}
+			for _, line := range tt.want {
+				assert.Contains(t, string(out), line)
+			}
+		})
+	}
+}
+
+func TestEnsureParentExists(t *testing.T) {
+	tests := []struct {
+		name string
+		// nested is whether the parent user/post is itself served under users.
+		nested bool
+		want   []string
+	}{
+		{
+			// A comment of /users/A/posts/<post of B>/comments must not find the post of B.
+			name:   "nested parent is scoped to its own parents",
+			nested: true,
+			want: []string{
+				"if err := b.ensureParentExists(ctx, rq.UserID, rq.PostID); err != nil {",
+				"ensureParentExists(ctx context.Context, userID, postID string) error {",
+				`whr := store.UserPostScope(userID).F("post_id", postID)`,
+				"if _, err := b.store.UserPost().Get(ctx, whr); err != nil {",
+			},
+		},
+		{
+			name: "top-level parent",
+			want: []string{
+				"if err := b.ensureParentExists(ctx, rq.PostID); err != nil {",
+				"ensureParentExists(ctx context.Context, postID string) error {",
+				`whr := where.F("post_id", postID)`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proj := &types.Project{
+				Metadata: &types.Metadata{},
+				D:        (&types.GeneratedData{WorkDir: t.TempDir(), APIVersion: "v1", ModuleName: "github.com/acme/blog"}).Complete(),
+			}
+			ws := &types.WebServer{BinaryName: "mb-apiserver", WebFramework: known.WebFrameworkGin, WithHealthz: true}
+			proj.WebServers = []*types.WebServer{ws}
+			ws.Complete(proj)
+			ws.UpsertKind(&types.Kind{Name: "user"})
+			ws.UpsertKind(&types.Kind{Name: "user/post", Nested: tt.nested})
+			ws.UpsertKind(&types.Kind{Name: "user/post/comment", Nested: true})
+			ws.PrepareRESTMetadata("user/post/comment")
+
+			out, err := helper.ExecuteTemplate("/project/internal/apiserver/biz/v1/post/post.go", helper.GetTemplateFuncMap(), &types.TemplateData{Project: proj, Web: ws})
+			require.NoError(t, err)
+			for _, line := range tt.want {
+				assert.Contains(t, string(out), line)
+			}
+		})
+	}
+}
+
+func TestValidateResourceVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		versioned bool
+		// recorded is whether the kind is recorded as versioned in PROJECT.
+		recorded bool
+		// generated is whether the kind has been generated before `create api` runs.
+		generated bool
+		force     bool
+		err       string
+	}{
+		{name: "not versioned", generated: true},
+		{name: "new kind", versioned: true},
+		{name: "recorded as versioned", versioned: true, recorded: true, generated: true},
+		{
+			name:      "existing kind",
+			versioned: true,
+			generated: true,
+			err:       `kind "post" already exists; rerun with --force to regenerate it with --resource-version`,
+		},
+		{name: "existing kind regenerated", versioned: true, generated: true, force: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &APIOptions{RootDir: t.TempDir(), APIVersion: "v1", Versioned: tt.versioned, Force: tt.force}
+			if tt.generated {
+				path := filepath.Join(o.RootDir, "internal/apiserver/store/post.go")
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+				require.NoError(t, os.WriteFile(path, []byte("package store"), 0o644))
+			}
+			ws := &types.WebServer{BinaryName: "mb-apiserver"}
+			if tt.recorded {
+				ws.Kinds = []*types.Kind{{Name: "post", ResourceVersion: true}}
+			}
+
+			err := o.validateResourceVersion(ws, "post")
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.err)
+			}
+		})
+	}
+}
+
+func TestValidateDatasource(t *testing.T) {
+	tests := []struct {
+		name       string
+		datasource string
+		// files are the files of the web server that exist before `create api` runs.
+		files map[string]string
+		err   string
+	}{
+		{name: "no datasource"},
+		{name: "declared datasource", datasource: "archive"},
+		{
+			name:       "undeclared datasource",
+			datasource: "analytics",
+			err:        `web server "mb-apiserver" has no datasource "analytics"`,
+		},
+		{
+			name:       "generated by a version with datasources",
+			datasource: "archive",
+			files: map[string]string{
+				"db.go":                    "store.DatasourceModels()",
+				"migrations/migrations.go": "NewDatasourceMigrator",
+			},
+		},
+		{
+			name:       "generated without datasources",
+			datasource: "archive",
+			files:      map[string]string{"db.go": "useResolver"},
+			err:        "db.go was generated without datasources",
+		},
+		{
+			name:       "generated without the migrations of datasources",
+			datasource: "archive",
+			files: map[string]string{
+				"db.go":                    "store.DatasourceModels()",
+				"migrations/migrations.go": "NewMigrator",
+			},
+			err: "migrations.go was generated without the migrations of datasources",
+		},
+		{
+			name:       "existing kind",
+			datasource: "archive",
+			files:      map[string]string{"store/post.go": "package store"},
+			err:        `kind "post" already exists; rerun with --force to regenerate it with --datasource`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := &APIOptions{RootDir: t.TempDir(), APIVersion: "v1", Datasource: tt.datasource}
+			for name, content := range tt.files {
+				path := filepath.Join(o.RootDir, "internal/apiserver", name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+			}
+			ws := &types.WebServer{BinaryName: "mb-apiserver", Datasources: []string{"archive"}}
+
+			err := o.validateDatasource(ws, "post")
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.err)
+			}
+		})
+	}
+}
+
+func TestGenerateMigrations_Datasources(t *testing.T) {
+	o := &APIOptions{
+		RootDir:    t.TempDir(),
+		Kinds:      []string{"post", "event", "metric"},
+		APIVersion: "v1",
+		IOStreams:  genericiooptions.IOStreams{Out: io.Discard, ErrOut: io.Discard},
+	}
+	proj := &types.Project{
+		Metadata:   &types.Metadata{},
+		D:          (&types.GeneratedData{WorkDir: o.RootDir, APIVersion: "v1"}).Complete(),
+		WebServers: []*types.WebServer{{BinaryName: "mb-apiserver", WebFramework: known.WebFrameworkGin, Datasources: []string{"analytics"}}},
+	}
+	ws := proj.WebServers[0].Complete(proj)
+	fields := []*types.Field{{Name: "name", Type: "string"}}
+	ws.UpsertKind(&types.Kind{Name: "post", Fields: fields})
+	ws.UpsertKind(&types.Kind{Name: "event", Fields: fields, Datasource: "analytics"})
+	ws.UpsertKind(&types.Kind{Name: "metric", Fields: fields, Datasource: "analytics"})
+
+	count, err := o.GenerateMigrations(file.NewFileManager(o.RootDir, true), ws, map[string]*types.Kind{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	// The tables of the kinds of a datasource are created in the datasource only, and each
+	// datasource numbers its migrations itself.
+	migrations := filepath.Join(o.RootDir, "internal/apiserver/migrations")
+	for _, dialect := range []string{"mysql", "postgres", "sqlite"} {
+		assert.FileExists(t, filepath.Join(migrations, dialect, "000001_create_mb_post.up.sql"))
+		assert.FileExists(t, filepath.Join(migrations, "datasources/analytics", dialect, "000001_create_mb_event.up.sql"))
+		assert.FileExists(t, filepath.Join(migrations, "datasources/analytics", dialect, "000002_create_mb_metric.down.sql"))
+
+		matches, err := filepath.Glob(filepath.Join(migrations, dialect, "*_create_mb_event.up.sql"))
+		require.NoError(t, err)
+		assert.Empty(t, matches)
+	}
+}
@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/enescakir/emoji"
@@ -18,6 +21,7 @@ import (
 	"github.com/onexstack/osbuilder/internal/osbuilder/file"
 	"github.com/onexstack/osbuilder/internal/osbuilder/helper"
 	"github.com/onexstack/osbuilder/internal/osbuilder/known"
+	"github.com/onexstack/osbuilder/internal/osbuilder/migration"
 	"github.com/onexstack/osbuilder/internal/osbuilder/types"
 	"github.com/onexstack/osbuilder/internal/osbuilder/validation"
 )
@@ -137,6 +141,10 @@ func (o *ProjectOptions) Complete(_ cmdutil.Factory, _ *cobra.Command, args []st
 	for i, ws := range o.Project.WebServers {
 		o.Project.WebServers[i] = ws.Complete(o.Project)
 	}
+	// MQ servers default to the broker of the web servers, so they are completed after them.
+	for i, ms := range o.Project.MQServers {
+		o.Project.MQServers[i] = ms.Complete(o.Project)
+	}
 	return nil
 }
 
@@ -191,11 +199,188 @@ func (o *ProjectOptions) Validate(_ *cobra.Command, _ []string) error {
 				ws.Name, st, strings.Join(known.AvailableServiceRegistry.UnsortedList(), ", "),
 			)
 		}
+
+		// Cache backend
+		if c := strings.TrimSpace(ws.Cache); c != "" && !known.AvailableCaches.Has(c) {
+			return fmt.Errorf(
+				"web server %q: unsupported cache %q; supported: %s",
+				ws.Name, c, strings.Join(known.AvailableCaches.UnsortedList(), ", "),
+			)
+		}
+
+		// Message broker and outbox broker
+		if b := strings.TrimSpace(ws.Broker); b != "" && !known.AvailableBrokers.Has(b) {
+			return fmt.Errorf(
+				"web server %q: unsupported broker %q; supported: %s",
+				ws.Name, b, strings.Join(known.AvailableBrokers.UnsortedList(), ", "),
+			)
+		}
+		if ob := strings.TrimSpace(ws.Outbox); ob != "" && !known.AvailableBrokers.Has(ob) {
+			return fmt.Errorf(
+				"web server %q: unsupported outbox %q; supported: %s",
+				ws.Name, ob, strings.Join(known.AvailableBrokers.UnsortedList(), ", "),
+			)
+		}
+		if ws.Outbox != "" && ws.Broker != "" && ws.Outbox != ws.Broker {
+			return fmt.Errorf("web server %q: outbox %q must be the broker %q of the server", ws.Name, ws.Outbox, ws.Broker)
+		}
+
+		// Read replicas and named datasources
+		if err := validateDatasources(ws); err != nil {
+			return err
+		}
+
+		// Typed clients
+		if err := validateClients(o.Project, ws); err != nil {
+			return err
+		}
+
+		// Features of the web framework
+		if err := validateFrameworkFeatures(ws); err != nil {
+			return err
+		}
+	}
+
+	// Message brokers of the MQ servers
+	if err := validateBrokers(o.Project); err != nil {
+		return err
 	}
 
 	return nil
 }
 
+// validateBrokers checks that the MQ servers consume from a supported broker, and that the
+// servers of the project agree on the broker, since they share the mq package in internal/pkg/mq.
+func validateBrokers(proj *types.Project) error {
+	broker, owner := "", ""
+	use := func(kind, name, b string) error {
+		switch {
+		case b == "":
+		case broker == "":
+			broker, owner = b, fmt.Sprintf("%s %q", kind, name)
+		case b != broker:
+			return fmt.Errorf("%s %q: broker %q differs from the broker %q of %s; the servers of a project share "+
+				"the mq package in internal/pkg/mq", kind, name, b, broker, owner)
+		}
+		return nil
+	}
+
+	for _, ws := range proj.WebServers {
+		if err := use("web server", ws.Name, ws.Broker); err != nil {
+			return err
+		}
+	}
+	for _, ms := range proj.MQServers {
+		if b := strings.TrimSpace(ms.Broker); b != "" && !known.AvailableBrokers.Has(b) {
+			return fmt.Errorf(
+				"mq server %q: unsupported broker %q; supported: %s",
+				ms.Name, b, strings.Join(known.AvailableBrokers.UnsortedList(), ", "),
+			)
+		}
+		if err := use("mq server", ms.Name, ms.Broker); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// datasourceNameRe matches the names of datasources, which are keys of the configuration file.
+var datasourceNameRe = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// validateDatasources checks that read replicas and named datasources are only declared by web
+// servers backed by a SQL database, and that the datasource names are unique.
+func validateDatasources(ws *types.WebServer) error {
+	if !ws.IsResolved() {
+		return nil
+	}
+	if !stringsutil.StringIn(ws.StorageType, []string{known.StorageTypeMariaDB, known.StorageTypePostgreSQL, known.StorageTypeSQLite}) {
+		return fmt.Errorf("web server %q: read replicas and datasources require storageType %s, %s or %s",
+			ws.Name, known.StorageTypeMariaDB, known.StorageTypePostgreSQL, known.StorageTypeSQLite)
+	}
+
+	seen := map[string]bool{}
+	for _, name := range ws.Datasources {
+		if !datasourceNameRe.MatchString(name) {
+			return fmt.Errorf("web server %q: invalid datasource name %q; use lowercase letters, digits and underscores", ws.Name, name)
+		}
+		if seen[name] {
+			return fmt.Errorf("web server %q: duplicate datasource %q", ws.Name, name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// validateClients checks the typed clients of the web server, including the clients of the web
+// servers it depends on, and that the gRPC clients call a web server serving a gRPC API.
+func validateClients(proj *types.Project, ws *types.WebServer) error {
+	for _, binaryName := range ws.DependsOn {
+		if server := proj.FindWebServer(binaryName); server == nil || server == ws {
+			return fmt.Errorf("web server %q: dependsOn: %q is not another web server of the project", ws.Name, binaryName)
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, c := range ws.AllClients() {
+		if err := c.Validate(); err != nil {
+			return fmt.Errorf("web server %q: %w", ws.Name, err)
+		}
+		if seen[c.PackageName()] {
+			return fmt.Errorf("web server %q: duplicate client %q", ws.Name, c.Name)
+		}
+		seen[c.PackageName()] = true
+
+		if !c.IsGRPC() {
+			continue
+		}
+		server := proj.FindWebServer(c.Param("server"))
+		if server == nil || server == ws {
+			return fmt.Errorf("web server %q: client %q: server %q is not another web server of the project", ws.Name, c.Name, c.Param("server"))
+		}
+		if !server.ServesGRPC() {
+			return fmt.Errorf("web server %q: client %q: server %q does not serve a gRPC API (webFramework %s)",
+				ws.Name, c.Name, server.BinaryName, server.WebFramework)
+		}
+	}
+	return nil
+}
+
+// validateFrameworkFeatures checks that the web server only enables the features that the
+// templates of its web framework implement.
+func validateFrameworkFeatures(ws *types.WebServer) error {
+	if ws.WithGraphQL && !stringsutil.StringIn(ws.WebFramework, []string{known.WebFrameworkGin, known.WebFrameworkNetHTTP}) {
+		return fmt.Errorf("web server %q: withGraphQL requires webFramework %s or %s",
+			ws.Name, known.WebFrameworkGin, known.WebFrameworkNetHTTP)
+	}
+	if ws.WebFramework != known.WebFrameworkGoZero {
+		return nil
+	}
+
+	var unsupported []string
+	for feature, enabled := range map[string]bool{
+		"withUser":        ws.WithUser,
+		"withOTel":        ws.WithOTel,
+		"withPreloader":   ws.WithPreloader,
+		"clients":         len(ws.AllClients()) > 0,
+		"serviceRegistry": ws.ServiceRegistry != known.ServiceRegistryNone,
+		"cache":           ws.Cache != "",
+		"broker":          ws.Broker != "",
+		"outbox":          ws.Outbox != "",
+		"readReplicas":    ws.ReadReplicas,
+		"datasources":     len(ws.Datasources) > 0,
+	} {
+		if enabled {
+			unsupported = append(unsupported, feature)
+		}
+	}
+	if len(unsupported) > 0 {
+		sort.Strings(unsupported)
+		return fmt.Errorf("web server %q: webFramework %s does not support %s",
+			ws.Name, ws.WebFramework, strings.Join(unsupported, ", "))
+	}
+	return nil
+}
+
 // Run generates the project files and prints next steps.
 func (o *ProjectOptions) Run(f cmdutil.Factory, ioStreams genericiooptions.IOStreams, _ []string) (err error) {
 	defer func() { helper.RecordOSBuilderUsage("project", err) }()
@@ -284,13 +469,13 @@ func (o *ProjectOptions) PrintGettingStarted() {
 				color.WhiteString("$ _output/platforms/%s/%s/%s", runtime.GOOS, runtime.GOARCH, ws.BinaryName),
 				color.CyanString("# run the compiled server"),
 			)
-			if ws.WebFramework == known.WebFrameworkGin && ws.WithHealthz {
+			if stringsutil.StringIn(ws.WebFramework, []string{known.WebFrameworkGin, known.WebFrameworkHertz, known.WebFrameworkNetHTTP, known.WebFrameworkGoZero}) && ws.WithHealthz {
 				fmt.Println(
 					color.WhiteString("$ curl http://127.0.0.1:5555/healthz"),
 					color.CyanString("# test with the health endpoint"),
 				)
 			}
-			if ws.WebFramework == known.WebFrameworkGRPC {
+			if stringsutil.StringIn(ws.WebFramework, []string{known.WebFrameworkGRPC, known.WebFrameworkKitex, known.WebFrameworkConnect}) {
 				if ws.WithUser {
 					fmt.Println(
 						color.WhiteString("$ go run examples/client/user/main.go"),
@@ -347,6 +532,11 @@ func (o *ProjectOptions) Generate(f cmdutil.Factory, fm *file.FileManager) error
 		filepath.Join("scripts/coverage.awk"): "/project/scripts/coverage.awk",
 	}
 
+	// The query object generator of the components, which is regenerated by `create api`.
+	if len(o.Project.WebServers) > 0 {
+		projectFiles[filepath.Join("cmd/gen-gorm-model/gen_gorm_model.go")] = "/project/cmd/gen-gorm-model/gen_gorm_model.go"
+	}
+
 	// Deployment-specific files
 	switch o.Project.Metadata.DeploymentMethod {
 	case known.DeploymentModeSystemd:
@@ -382,19 +572,43 @@ func (o *ProjectOptions) Generate(f cmdutil.Factory, fm *file.FileManager) error
 			return err
 		}
 
-		// 生成 client 类型的 fake 文件
-		for _, kind := range ws.Clients {
-			ws.TypedClientName = helper.ToLower(kind)
-			tplFile := "/project/internal/apiserver/pkg/clientset/typed/fake/fake.go"
-			pairs := map[string]string{
-				filepath.Join(ws.Pkg(), fmt.Sprintf("clientset/typed/%s/%s.go", ws.TypedClientName, ws.TypedClientName)): tplFile,
+		// 生成 clientset 中每个 typed client 的客户端、配置项和 fake 文件
+		for _, c := range ws.AllClients() {
+			ws.TypedClient = c
+			if err := helper.RenderTemplate(fm, ws.ClientPairs(c), funcs, &data); err != nil {
+				return err
+			}
+		}
+
+		// 生成内置 user 表和 outbox 表的迁移文件，outbox relay 启动后即轮询该表
+		version := 1
+		if ws.WithUser {
+			table := migration.Table(ws.BinaryName, &types.Kind{Name: "user"})
+			if err := GenerateCreateMigration(fm, o.Project.Join(ws.Migrations()), version, table, migration.UserColumns()); err != nil {
+				return err
 			}
-			if err := helper.RenderTemplate(fm, pairs, nil, &data); err != nil {
+			version++
+		}
+		if ws.Outbox != "" {
+			if err := GenerateCreateMigration(fm, o.Project.Join(ws.Migrations()), version, ws.OutboxTable(), migration.OutboxColumns()); err != nil {
 				return err
 			}
 		}
 	}
 
+	// Generate per-MQ-server files: the mq package of the broker the server consumes from, unless a
+	// web server has generated it already
+	webBroker := slices.ContainsFunc(o.Project.WebServers, func(ws *types.WebServer) bool { return ws.Broker != "" })
+	for _, ms := range o.Project.MQServers {
+		if ms.Broker == "" || webBroker {
+			continue
+		}
+		data := types.TemplateData{Project: o.Project, Web: ms.WebServer()}
+		if err := helper.RenderTemplate(fm, ms.BrokerPairs(), funcs, &data); err != nil {
+			return err
+		}
+	}
+
 	// TODO: Add jobs/CLI apps generation when templates are ready.
 	return nil
 }
@@ -430,7 +644,7 @@ func correctProjectConfig(proj *types.Project) *types.Project {
 		if ws.StorageType == known.StorageTypeMySQL {
 			ws.StorageType = known.StorageTypeMariaDB
 		}
-		if ws.WebFramework != known.WebFrameworkGRPC && ws.WebFramework != known.WebFrameworkGRPCGateway {
+		if !stringsutil.StringIn(ws.WebFramework, []string{known.WebFrameworkGRPC, known.WebFrameworkGRPCGateway, known.WebFrameworkGoZero, known.WebFrameworkKitex, known.WebFrameworkConnect}) {
 			ws.GRPCServiceName = ""
 		}
 		if ws.ServiceRegistry == "" {
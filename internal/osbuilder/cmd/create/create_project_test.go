@@ -0,0 +1,119 @@
+package create
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onexstack/osbuilder/internal/osbuilder/known"
+	"github.com/onexstack/osbuilder/internal/osbuilder/types"
+)
+
+func TestValidateFrameworkFeatures(t *testing.T) {
+	tests := []struct {
+		name string
+		ws   *types.WebServer
+		err  string
+	}{
+		{
+			name: "gin supports every feature",
+			ws: &types.WebServer{WebFramework: known.WebFrameworkGin, WithUser: true, WithGraphQL: true,
+				Cache: "redis", Broker: "kafka", Outbox: "kafka", ReadReplicas: true, Datasources: []string{"archive"}},
+		},
+		{
+			name: "graphql requires gin or nethttp",
+			ws:   &types.WebServer{Name: "apiserver", WebFramework: known.WebFrameworkHertz, WithGraphQL: true},
+			err:  `web server "apiserver": withGraphQL requires webFramework gin or nethttp`,
+		},
+		{
+			name: "go-zero without features",
+			ws:   &types.WebServer{WebFramework: known.WebFrameworkGoZero, ServiceRegistry: known.ServiceRegistryNone, WithHealthz: true},
+		},
+		{name: "go-zero withUser", ws: goZero(func(ws *types.WebServer) { ws.WithUser = true }), err: "withUser"},
+		{name: "go-zero withOTel", ws: goZero(func(ws *types.WebServer) { ws.WithOTel = true }), err: "withOTel"},
+		{name: "go-zero withPreloader", ws: goZero(func(ws *types.WebServer) { ws.WithPreloader = true }), err: "withPreloader"},
+		{name: "go-zero clients", ws: goZero(func(ws *types.WebServer) { ws.Clients = []*types.Client{{Name: "billing"}} }), err: "clients"},
+		{name: "go-zero dependencies", ws: goZero(func(ws *types.WebServer) { ws.Dependencies = []*types.Client{{Name: "billing"}} }), err: "clients"},
+		{name: "go-zero serviceRegistry", ws: goZero(func(ws *types.WebServer) { ws.ServiceRegistry = known.ServiceRegistryPolaris }), err: "serviceRegistry"},
+		{name: "go-zero cache", ws: goZero(func(ws *types.WebServer) { ws.Cache = "redis" }), err: "cache"},
+		{name: "go-zero broker", ws: goZero(func(ws *types.WebServer) { ws.Broker = "nats" }), err: "broker"},
+		{name: "go-zero outbox", ws: goZero(func(ws *types.WebServer) { ws.Outbox = "kafka" }), err: "outbox"},
+		{name: "go-zero readReplicas", ws: goZero(func(ws *types.WebServer) { ws.ReadReplicas = true }), err: "readReplicas"},
+		{name: "go-zero datasources", ws: goZero(func(ws *types.WebServer) { ws.Datasources = []string{"archive"} }), err: "datasources"},
+		{
+			name: "go-zero lists every unsupported feature",
+			ws: goZero(func(ws *types.WebServer) {
+				ws.WithUser, ws.Outbox, ws.Broker = true, "kafka", "kafka"
+			}),
+			err: "broker, outbox, withUser",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFrameworkFeatures(tt.ws)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.err)
+			}
+		})
+	}
+}
+
+// goZero returns a go-zero web server without features, modified by set.
+func goZero(set func(ws *types.WebServer)) *types.WebServer {
+	ws := &types.WebServer{Name: "apiserver", WebFramework: known.WebFrameworkGoZero, ServiceRegistry: known.ServiceRegistryNone}
+	set(ws)
+	return ws
+}
+
+func TestValidateBrokers(t *testing.T) {
+	tests := []struct {
+		name string
+		proj *types.Project
+		err  string
+	}{
+		{name: "no brokers", proj: &types.Project{MQServers: []*types.MQServer{{Name: "consumer"}}}},
+		{
+			name: "same broker",
+			proj: &types.Project{
+				WebServers: []*types.WebServer{{Name: "apiserver", Broker: "kafka"}, {Name: "billing"}},
+				MQServers:  []*types.MQServer{{Name: "consumer", Broker: "kafka"}},
+			},
+		},
+		{
+			name: "unsupported broker",
+			proj: &types.Project{MQServers: []*types.MQServer{{Name: "consumer", Broker: "pulsar"}}},
+			err:  `mq server "consumer": unsupported broker "pulsar"`,
+		},
+		{
+			name: "mq server with another broker",
+			proj: &types.Project{
+				WebServers: []*types.WebServer{{Name: "apiserver", Broker: "kafka"}},
+				MQServers:  []*types.MQServer{{Name: "consumer", Broker: "nats"}},
+			},
+			err: `mq server "consumer": broker "nats" differs from the broker "kafka" of web server "apiserver"`,
+		},
+		{
+			name: "web servers with different brokers",
+			proj: &types.Project{
+				WebServers: []*types.WebServer{{Name: "apiserver", Broker: "redis"}, {Name: "billing", Broker: "rabbitmq"}},
+			},
+			err: `web server "billing": broker "rabbitmq" differs from the broker "redis" of web server "apiserver"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBrokers(tt.proj)
+			if tt.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.err)
+			}
+		})
+	}
+}
@@ -42,9 +42,12 @@ type QuickstartOptions struct {
 	WithOtel        bool     // Enable OpenTelemetry
 	WithWS          bool     // Enable websocket
 	WithPreloader   bool     // Enable data pre-load
+	WithGraphQL     bool     // Enable GraphQL endpoint
 	Clients         []string
 	ServiceRegistry string // Service registry type
 
+	clients []*types.Client // Clients parsed by Validate
+
 	genericiooptions.IOStreams
 }
 
@@ -104,6 +107,7 @@ func NewQuickstartOptions(io genericiooptions.IOStreams) *QuickstartOptions {
 		WithOtel:        true,
 		WithWS:          true,
 		WithPreloader:   true,
+		WithGraphQL:     false,
 		Clients:         []string{},
 		ServiceRegistry: "none",
 		IOStreams:       io,
@@ -146,7 +150,8 @@ func NewCmdQuickstart(factory cmdutil.Factory, ioStreams genericiooptions.IOStre
 	cmd.Flags().BoolVar(&o.WithOtel, "with-otel", o.WithOtel, "Enable OpenTelemetry support")
 	cmd.Flags().BoolVar(&o.WithWS, "with-ws", o.WithWS, "Enable websocket support")
 	cmd.Flags().BoolVar(&o.WithPreloader, "with-preloader", o.WithPreloader, "Enable data preload feature.")
-	cmd.Flags().StringSliceVar(&o.Clients, "clients", o.Clients, "Define clientset.")
+	cmd.Flags().BoolVar(&o.WithGraphQL, "with-graphql", o.WithGraphQL, "Enable GraphQL endpoint (gin, nethttp).")
+	cmd.Flags().StringSliceVar(&o.Clients, "clients", o.Clients, "Typed clients of the clientset as name[:type] items, where type is one of: rest (default), s3, smtp.")
 	cmd.Flags().StringVar(&o.ServiceRegistry, "service-registry", o.ServiceRegistry, "Service registry type (none, etcd, consul)")
 
 	return cmd
@@ -173,6 +178,16 @@ func (o *QuickstartOptions) Complete(factory cmdutil.Factory, cmd *cobra.Command
 
 // Validate checks required inputs and validates configuration options.
 func (o *QuickstartOptions) Validate(cmd *cobra.Command, args []string) error {
+	for _, spec := range o.Clients {
+		c, err := types.ParseClient(spec)
+		if err != nil {
+			return err
+		}
+		if err := c.Validate(); err != nil {
+			return err
+		}
+		o.clients = append(o.clients, c)
+	}
 	return nil
 }
 
@@ -269,7 +284,8 @@ func (o *QuickstartOptions) applyQuickstartOptions(project *types.Project) *type
 		project.WebServers[i].WithOTel = o.WithOtel
 		project.WebServers[i].WithWS = o.WithWS
 		project.WebServers[i].WithPreloader = o.WithPreloader
-		project.WebServers[i].Clients = o.Clients
+		project.WebServers[i].WithGraphQL = o.WithGraphQL
+		project.WebServers[i].Clients = o.clients
 		if o.ServiceRegistry != "" {
 			project.WebServers[i].ServiceRegistry = o.ServiceRegistry
 		}
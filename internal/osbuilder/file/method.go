@@ -20,14 +20,20 @@ func (fm *FileManager) AddNewGRPCMethod(ws *types.WebServer) error {
 	kind, grpcServiceName := ws.R.SingularName, ws.GRPCServiceName
 
 	filePath := ws.Proj.Join(ws.API(), ws.Name+".proto")
-	importPath := filepath.Join(ws.Name, ws.Proj.D.APIVersion, ws.R.SingularLower+".proto")
+	importPath := filepath.Join(ws.Name, ws.Proj.D.APIVersion, ws.R.ProtoFile())
 
 	b, err := os.ReadFile(filePath)
 	if err != nil {
 		return err
 	}
 
-	updated, changed, err := applyUpdates(string(b), kind, grpcServiceName, importPath)
+	// Nested kinds are exposed through grpc-gateway under their parent resources.
+	var rule *httpRule
+	if ws.R.IsNested() {
+		rule = &httpRule{collection: ws.R.HTTPRulePath(ws.Proj.D.APIVersion), idParam: ws.R.IDJSON()}
+	}
+
+	updated, changed, err := applyUpdates(string(b), kind, grpcServiceName, importPath, rule, ws.R.CustomMethods())
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,27 @@
+package file
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// reRootCommandReturn matches the return statement of the command constructor of a binary.
+var reRootCommandReturn = regexp.MustCompile(`(?s)func New\w*Command\(\) \*cobra\.Command \{.*?\n(\treturn cmd\n\})`)
+
+// AddMigrateCommand registers the migrate command in the command constructor of a binary that
+// was generated before binaries had one. The file is left untouched if the command is registered.
+func (fm *FileManager) AddMigrateCommand(path string) error {
+	return fm.updateFile(path, func(src string) (string, bool, error) {
+		if strings.Contains(src, "newMigrateCommand(") {
+			return src, false, nil
+		}
+		loc := reRootCommandReturn.FindStringSubmatchIndex(src)
+		if loc == nil {
+			return "", false, fmt.Errorf("command constructor not found; register newMigrateCommand(opts) by hand")
+		}
+		register := "\t// Add the migrate command, which applies and reverts the database schema migrations.\n" +
+			"\tcmd.AddCommand(newMigrateCommand(opts))\n\n"
+		return src[:loc[2]] + register + src[loc[2]:], true, nil
+	})
+}
@@ -11,9 +11,12 @@ import (
 
 	"github.com/duke-git/lancet/v2/strutil"
 	"github.com/gobuffalo/flect"
+
+	"github.com/onexstack/osbuilder/internal/osbuilder/protofile"
+	"github.com/onexstack/osbuilder/internal/osbuilder/types"
 )
 
-func applyUpdates(src string, kind string, grpcServiceName string, importPath string) (string, bool, error) {
+func applyUpdates(src string, kind string, grpcServiceName string, importPath string, rule *httpRule, verbs []*types.Verb) (string, bool, error) {
 	changedAny := false
 
 	// 1) Ensure the import exists
@@ -23,8 +26,18 @@ func applyUpdates(src string, kind string, grpcServiceName string, importPath st
 	}
 	changedAny = changedAny || changed1
 
+	// HTTP rules need the google.api.http annotation.
+	if rule != nil {
+		var changed bool
+		withImport, changed, err = addImportProto(withImport, "google/api/annotations.proto")
+		if err != nil {
+			return "", false, err
+		}
+		changedAny = changedAny || changed
+	}
+
 	// 2) Ensure the RPC methods exist
-	withRPCs, changed2, err := addRPCsToAPIServer(withImport, kind, grpcServiceName)
+	withRPCs, changed2, err := addRPCsToAPIServer(withImport, kind, grpcServiceName, rule, verbs)
 	if err != nil {
 		return "", false, err
 	}
@@ -88,18 +101,85 @@ func addImportProto(src string, importPath string) (string, bool, error) {
 	return out, true, nil
 }
 
-func addRPCsToAPIServer(src string, kind string, grpcServiceName string) (string, bool, error) {
+// rpcMethod describes an RPC to add to the service block, with an optional google.api.http rule.
+type rpcMethod struct {
+	name     string
+	request  string
+	response string
+	// httpMethod and httpPath describe the google.api.http rule; empty httpPath means no rule.
+	httpMethod string
+	httpPath   string
+	body       bool
+}
+
+// httpRule holds the google.api.http paths of a kind's standard methods.
+type httpRule struct {
+	// collection is the path of the resource collection (e.g., "/v1/tickets/{ticketID}/cronjobs").
+	collection string
+	// idParam is the path parameter of a single resource (e.g., "cronJobID").
+	idParam string
+}
+
+// crudMethods returns the standard methods of a kind, annotated with HTTP rules when rule is not nil.
+func crudMethods(kind string, pluralKind string, rule *httpRule) []rpcMethod {
+	methods := []rpcMethod{
+		{name: "Create" + kind, httpMethod: "post", body: true},
+		{name: "Update" + kind, httpMethod: "put", body: true},
+		{name: "Delete" + kind, httpMethod: "delete"},
+		{name: "Delete" + pluralKind, httpMethod: "delete", body: true},
+		{name: "Get" + kind, httpMethod: "get"},
+		{name: "List" + kind, httpMethod: "get"},
+	}
+	for i := range methods {
+		m := &methods[i]
+		m.request, m.response = m.name+"Request", m.name+"Response"
+		if rule == nil {
+			continue
+		}
+		m.httpPath = rule.collection
+		if m.name == "Update"+kind || m.name == "Delete"+kind || m.name == "Get"+kind {
+			m.httpPath += "/{" + rule.idParam + "}"
+		}
+	}
+	return methods
+}
+
+// verbMethods returns the custom methods of a kind. With an HTTP rule, a verb is served as
+// POST <collection>/{id}:<verb> following the custom method convention of google.api.http.
+func verbMethods(kind string, verbs []*types.Verb, rule *httpRule) []rpcMethod {
+	methods := make([]rpcMethod, 0, len(verbs))
+	for _, v := range verbs {
+		m := rpcMethod{name: v.GoName() + kind, httpMethod: "post", body: true}
+		m.request, m.response = m.name+"Request", m.name+"Response"
+		if rule != nil {
+			m.httpPath = rule.collection + "/{" + rule.idParam + "}:" + v.PathName()
+		}
+		methods = append(methods, m)
+	}
+	return methods
+}
+
+// KindRPCs returns the methods of a kind that the generated layers implement, with their
+// request and response messages.
+func KindRPCs(r *types.REST) []*protofile.RPC {
+	kind := r.SingularName
 	pluralKind := flect.Pluralize(strutil.UpperFirst(strutil.CamelCase(kind)))
+	var rpcs []*protofile.RPC
+	for _, m := range append(crudMethods(kind, pluralKind, nil), verbMethods(kind, r.CustomMethods(), nil)...) {
+		rpcs = append(rpcs, &protofile.RPC{Name: m.name, Request: m.request, Response: m.response})
+	}
+	return rpcs
+}
 
-	var (
-		reServiceOpen      = regexp.MustCompile(fmt.Sprintf(`(?m)^[ \t]*service[ \t]+%s[ \t]*\{`, grpcServiceName))
-		reCreate           = regexp.MustCompile(fmt.Sprintf(`(?m)^[ \t]*rpc[ \t]+Create%s[ \t]*\(`, kind))
-		reUpdate           = regexp.MustCompile(fmt.Sprintf(`(?m)^[ \t]*rpc[ \t]+Update%s[ \t]*\(`, kind))
-		reDelete           = regexp.MustCompile(fmt.Sprintf(`(?m)^[ \t]*rpc[ \t]+Delete%s[ \t]*\(`, kind))
-		reDeleteCollection = regexp.MustCompile(fmt.Sprintf(`(?m)^[ \t]*rpc[ \t]+Delete%s[ \t]*\(`, pluralKind))
-		reGet              = regexp.MustCompile(fmt.Sprintf(`(?m)^[ \t]*rpc[ \t]+Get%s[ \t]*\(`, kind))
-		reList             = regexp.MustCompile(fmt.Sprintf(`(?m)^[ \t]*rpc[ \t]+List%s[ \t]*\(`, kind))
-	)
+func addRPCsToAPIServer(src string, kind string, grpcServiceName string, rule *httpRule, verbs []*types.Verb) (string, bool, error) {
+	pluralKind := flect.Pluralize(strutil.UpperFirst(strutil.CamelCase(kind)))
+	methods := append(crudMethods(kind, pluralKind, rule), verbMethods(kind, verbs, rule)...)
+	return addRPCMethods(src, grpcServiceName, methods)
+}
+
+// addRPCMethods appends the missing methods to the service block of grpcServiceName.
+func addRPCMethods(src string, grpcServiceName string, methods []rpcMethod) (string, bool, error) {
+	reServiceOpen := regexp.MustCompile(fmt.Sprintf(`(?m)^[ \t]*service[ \t]+%s[ \t]*\{`, grpcServiceName))
 
 	loc := reServiceOpen.FindStringIndex(src)
 	if loc == nil {
@@ -123,14 +203,6 @@ func addRPCsToAPIServer(src string, kind string, grpcServiceName string) (string
 	body := src[openIdx+1 : closeIdx]
 	tail := src[closeIdx:] // includes the closing '}' and after
 
-	// Check existing
-	hasCreate := reCreate.FindStringIndex(body) != nil
-	hasUpdate := reUpdate.FindStringIndex(body) != nil
-	hasDelete := reDelete.FindStringIndex(body) != nil
-	hasDeleteCollection := reDeleteCollection.FindStringIndex(body) != nil
-	hasGet := reGet.FindStringIndex(body) != nil
-	hasList := reList.FindStringIndex(body) != nil
-
 	insIndent := inferIndent(body)
 
 	var b strings.Builder
@@ -139,41 +211,28 @@ func addRPCsToAPIServer(src string, kind string, grpcServiceName string) (string
 	bodyTrimRight := strings.TrimRight(body, " \t")
 	b.WriteString(bodyTrimRight)
 
-	needsTrailingNewline := !strings.HasSuffix(bodyTrimRight, "\n")
-	if needsTrailingNewline {
-		// b.WriteString("\n")
-	}
-
 	// Prepare the lines to insert, only missing ones
 	needUpdate := false
-	// Prepare the lines to insert, only missing ones
-	if !hasCreate {
-		b.WriteString(insIndent)
-		b.WriteString(fmt.Sprintf("rpc Create%s(Create%sRequest) returns (Create%sResponse);\n", kind, kind, kind))
-		needUpdate = true
-	}
-	if !hasUpdate {
-		b.WriteString(insIndent)
-		b.WriteString(fmt.Sprintf("rpc Update%s(Update%sRequest) returns (Update%sResponse);\n", kind, kind, kind))
-	}
-	if !hasDelete {
-		b.WriteString(insIndent)
-		b.WriteString(fmt.Sprintf("rpc Delete%s(Delete%sRequest) returns (Delete%sResponse);\n", kind, kind, kind))
-		needUpdate = true
-	}
-	if !hasDeleteCollection {
-		b.WriteString(insIndent)
-		b.WriteString(fmt.Sprintf("rpc Delete%s(Delete%sRequest) returns (Delete%sResponse);\n", pluralKind, pluralKind, pluralKind))
-		needUpdate = true
-	}
-	if !hasGet {
-		b.WriteString(insIndent)
-		b.WriteString(fmt.Sprintf("rpc Get%s(Get%sRequest) returns (Get%sResponse);\n", kind, kind, kind))
-		needUpdate = true
-	}
-	if !hasList {
+	for _, m := range methods {
+		reExisting := regexp.MustCompile(fmt.Sprintf(`(?m)^[ \t]*rpc[ \t]+%s[ \t]*\(`, m.name))
+		if reExisting.FindStringIndex(body) != nil {
+			continue
+		}
+
 		b.WriteString(insIndent)
-		b.WriteString(fmt.Sprintf("rpc List%s(List%sRequest) returns (List%sResponse);\n", kind, kind, kind))
+		if m.httpPath == "" {
+			b.WriteString(fmt.Sprintf("rpc %s(%s) returns (%s);\n", m.name, m.request, m.response))
+		} else {
+			in2, in3 := strings.Repeat(insIndent, 2), strings.Repeat(insIndent, 3)
+			b.WriteString(fmt.Sprintf("rpc %s(%s) returns (%s) {\n", m.name, m.request, m.response))
+			b.WriteString(in2 + "option (google.api.http) = {\n")
+			b.WriteString(fmt.Sprintf("%s%s: \"%s\"\n", in3, m.httpMethod, m.httpPath))
+			if m.body {
+				b.WriteString(in3 + "body: \"*\"\n")
+			}
+			b.WriteString(in2 + "};\n")
+			b.WriteString(insIndent + "}\n")
+		}
 		needUpdate = true
 	}
 
@@ -0,0 +1,137 @@
+package file
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"mvdan.cc/gofumpt/format"
+
+	"github.com/onexstack/osbuilder/internal/osbuilder/types"
+)
+
+// AddNewVerbs adds the custom methods of the current kind to its proto messages and to the
+// <Kind>Expansion interface of its biz package. Verbs that already exist are left untouched,
+// so the method can be run again after new verbs are recorded for an existing kind.
+func (fm *FileManager) AddNewVerbs(ws *types.WebServer) error {
+	if len(ws.R.Verbs) == 0 {
+		return nil
+	}
+
+	// The messages of kinds generated from an existing proto file are declared by their users.
+	if !ws.R.IsProtoFirst() {
+		protoFile := ws.Proj.Join(ws.API(), ws.R.ProtoFile())
+		if err := fm.updateFile(protoFile, func(src string) (string, bool, error) {
+			return AddVerbMessages(src, ws.R)
+		}); err != nil {
+			return err
+		}
+	}
+
+	bizFile := ws.Proj.Join(ws.RESTBiz())
+	return fm.updateFile(bizFile, func(src string) (string, bool, error) {
+		out, changed, err := addVerbsToExpansion(src, ws.R, ws.Proj.D.APIAlias)
+		if err != nil || !changed {
+			return out, changed, err
+		}
+		formatted, err := format.Source([]byte(out), format.Options{})
+		if err != nil {
+			return "", false, err
+		}
+		return string(formatted), true, nil
+	})
+}
+
+// updateFile rewrites path with the result of update when it reports a change.
+func (fm *FileManager) updateFile(path string, update func(src string) (string, bool, error)) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	updated, changed, err := update(string(b))
+	if err != nil {
+		return fmt.Errorf("update %s: %w", path, err)
+	}
+	if !changed {
+		return nil
+	}
+
+	if err := os.WriteFile(path, []byte(updated), 0o644); err != nil {
+		return err
+	}
+	fm.Print(Updated, path)
+	return nil
+}
+
+// AddVerbMessages appends the request and response messages of the missing verbs to the proto of a kind.
+func AddVerbMessages(src string, r *types.REST) (string, bool, error) {
+	var b strings.Builder
+	for _, v := range r.Verbs {
+		name := v.GoName() + r.SingularName
+		reExisting := regexp.MustCompile(fmt.Sprintf(`(?m)^[ \t]*message[ \t]+%sRequest[ \t]*\{`, name))
+		if reExisting.FindStringIndex(src) != nil {
+			continue
+		}
+
+		fmt.Fprintf(&b, "\n// %sRequest represents the request message for the %s custom method of a %s.\n", name, v.Label(), r.SingularLower)
+		fmt.Fprintf(&b, "message %sRequest {\n", name)
+		fmt.Fprintf(&b, "    // %s is the unique identifier of the %s to %s.\n", r.IDField(), r.SingularLower, v.Label())
+		fmt.Fprintf(&b, "    // @gotags: uri:\"%s\"\n", r.IDJSON())
+		fmt.Fprintf(&b, "    string %s = 1;\n", r.IDJSON())
+		for i, p := range r.Parents {
+			fmt.Fprintf(&b, "    // %s is the identifier of the parent %s.\n", p.IDField(), p.SingularLower)
+			fmt.Fprintf(&b, "    // @gotags: uri:\"%s\"\n", p.IDJSON())
+			fmt.Fprintf(&b, "    string %s = %d [(google.api.field_behavior) = REQUIRED];\n", p.IDJSON(), i+2)
+		}
+		b.WriteString("    // TODO: Add additional fields if needed.\n")
+		b.WriteString("}\n")
+		fmt.Fprintf(&b, "\n// %sResponse represents the response message for a successful %s of a %s.\n", name, v.Label(), r.SingularLower)
+		fmt.Fprintf(&b, "message %sResponse {\n", name)
+		b.WriteString("    // TODO: Add additional fields to return if needed.\n")
+		b.WriteString("}\n")
+	}
+
+	if b.Len() == 0 {
+		return src, false, nil
+	}
+	return normalizeFileEnding(normalizeFileEnding(src) + b.String()), true, nil
+}
+
+// addVerbsToExpansion adds the missing verbs to the <Kind>Expansion interface of a biz file.
+func addVerbsToExpansion(src string, r *types.REST, apiAlias string) (string, bool, error) {
+	iface := r.SingularName + "Expansion"
+	reIface := regexp.MustCompile(fmt.Sprintf(`(?m)^type[ \t]+%s[ \t]+interface[ \t]*\{`, iface))
+	loc := reIface.FindStringIndex(src)
+	if loc == nil {
+		return "", false, fmt.Errorf("could not find 'type %s interface {'", iface)
+	}
+
+	openIdx := loc[1] - 1
+	closeIdx, err := findMatchingCloseBrace(src, openIdx)
+	if err != nil {
+		return "", false, err
+	}
+	body := src[openIdx+1 : closeIdx]
+
+	var b strings.Builder
+	for _, v := range r.Verbs {
+		reExisting := regexp.MustCompile(fmt.Sprintf(`(?m)^[ \t]*%s[ \t]*\(`, v.GoName()))
+		if reExisting.FindStringIndex(body) != nil {
+			continue
+		}
+
+		name := v.GoName() + r.SingularName
+		fmt.Fprintf(&b, "\t// %s performs the %s custom method on a %s.\n", v.GoName(), v.Label(), r.SingularLower)
+		fmt.Fprintf(&b, "\t%s(ctx context.Context, rq *%s.%sRequest) (*%s.%sResponse, error)\n",
+			v.GoName(), apiAlias, name, apiAlias, name)
+	}
+
+	if b.Len() == 0 {
+		return src, false, nil
+	}
+
+	newBody := strings.TrimRight(body, " \t\n") + "\n" + b.String()
+	return src[:openIdx+1] + newBody + src[closeIdx:], true, nil
+}
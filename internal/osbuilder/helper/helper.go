@@ -300,6 +300,50 @@ func HasGin() func([]*types.WebServer) bool {
 	}
 }
 
+func HasGoZero() func([]*types.WebServer) bool {
+	return func(servers []*types.WebServer) bool {
+		for _, server := range servers {
+			if server.WebFramework == "go-zero" {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func HasHertz() func([]*types.WebServer) bool {
+	return func(servers []*types.WebServer) bool {
+		for _, server := range servers {
+			if server.WebFramework == "hertz" {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func HasKitex() func([]*types.WebServer) bool {
+	return func(servers []*types.WebServer) bool {
+		for _, server := range servers {
+			if server.WebFramework == "kitex" {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func HasConnect() func([]*types.WebServer) bool {
+	return func(servers []*types.WebServer) bool {
+		for _, server := range servers {
+			if server.WebFramework == "connect" {
+				return true
+			}
+		}
+		return false
+	}
+}
+
 func HasOTel() func([]*types.WebServer) bool {
 	return func(servers []*types.WebServer) bool {
 		for _, server := range servers {
@@ -322,17 +366,19 @@ func HasMemoryStorageType() func([]*types.WebServer) bool {
 	}
 }
 
-func ExtractProjectPrefix() func(string) string {
-	return func(projectName string) string {
-		// Split string by "-", take the first part
-		parts := strings.Split(projectName, "-")
-		if len(parts) > 0 {
-			return parts[0]
-		}
-		return projectName // If no "-", return original string
+// Add returns the sum of two integers, e.g., to number protobuf fields in templates.
+func Add() func(int, int) int {
+	return func(a, b int) int {
+		return a + b
 	}
 }
 
+// ExtractProjectPrefix returns types.ProjectPrefix, so that the templates, the migrations and the
+// outbox table name the tables of a component with the same prefix.
+func ExtractProjectPrefix() func(string) string {
+	return types.ProjectPrefix
+}
+
 func GetTemplateFuncMap() template.FuncMap {
 	return template.FuncMap{
 		"kind":                 Kind(),
@@ -344,10 +390,15 @@ func GetTemplateFuncMap() template.FuncMap {
 		"underscore":           ToUnderscore(),
 		"hasGRPC":              HasGRPC(),
 		"hasGin":               HasGin(),
+		"hasGoZero":            HasGoZero(),
+		"hasHertz":             HasHertz(),
+		"hasKitex":             HasKitex(),
+		"hasConnect":           HasConnect(),
 		"hasOTel":              HasOTel(),
 		"hasServiceRegistry":   HasServiceRegistry(),
 		"extractProjectPrefix": ExtractProjectPrefix(),
 		"hasMemoryStorageType": HasMemoryStorageType(),
+		"add":                  Add(),
 	}
 }
 
@@ -474,6 +525,20 @@ func RenderTemplate(fm *file.FileManager, pairs map[string]string, funcs templat
 	return nil
 }
 
+// ExecuteTemplate renders the template at tplPath in memory.
+func ExecuteTemplate(tplPath string, funcs template.FuncMap, data any) ([]byte, error) {
+	tmpl, err := template.New(filepath.Base(tplPath)).Funcs(funcs).Parse(NewFileSystem("/").Content(tplPath))
+	if err != nil {
+		return nil, fmt.Errorf("parse template %q: %w", tplPath, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("execute template %q: %w", tplPath, err)
+	}
+	return buf.Bytes(), nil
+}
+
 func isGoFile(path string) bool {
 	return strings.HasSuffix(path, ".go")
 }
@@ -0,0 +1,207 @@
+package introspect
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// sqliteDialect reads the schema of a SQLite database from sqlite_master and table pragmas.
+type sqliteDialect struct{}
+
+func (sqliteDialect) tables(ctx context.Context, db *sql.DB) ([]string, error) {
+	return queryStrings(ctx, db,
+		`SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name`)
+}
+
+func (sqliteDialect) columns(ctx context.Context, db *sql.DB, table string) ([]*Column, error) {
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("PRAGMA table_info(%s)", quoteSQLite(table)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []*Column
+	for rows.Next() {
+		var (
+			cid, notNull, pk int
+			name, typ        string
+			dflt             sql.NullString
+		)
+		if err := rows.Scan(&cid, &name, &typ, &notNull, &dflt, &pk); err != nil {
+			return nil, err
+		}
+		columns = append(columns, &Column{Name: name, Type: strings.ToLower(typ), Nullable: notNull == 0 && pk == 0, PrimaryKey: pk > 0})
+	}
+	return columns, rows.Err()
+}
+
+func (sqliteDialect) foreignKeys(ctx context.Context, db *sql.DB, table string) ([]*ForeignKey, error) {
+	rows, err := db.QueryContext(ctx, fmt.Sprintf("PRAGMA foreign_key_list(%s)", quoteSQLite(table)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var (
+		ids  []int
+		all  []*ForeignKey
+		size = map[int]int{}
+	)
+	for rows.Next() {
+		var (
+			id, seq                             int
+			refTable, from                      string
+			to, onUpdate, onDelete, matchClause sql.NullString
+		)
+		if err := rows.Scan(&id, &seq, &refTable, &from, &to, &onUpdate, &onDelete, &matchClause); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+		size[id]++
+		all = append(all, &ForeignKey{Column: from, RefTable: refTable, RefColumn: to.String})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Composite foreign keys do not map to a relation between kinds.
+	var fks []*ForeignKey
+	for i, fk := range all {
+		if size[ids[i]] == 1 {
+			fks = append(fks, fk)
+		}
+	}
+	return fks, nil
+}
+
+// quoteSQLite quotes an identifier for SQLite.
+func quoteSQLite(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// mysqlDialect reads the schema of a MySQL or MariaDB database from information_schema.
+type mysqlDialect struct{}
+
+func (mysqlDialect) tables(ctx context.Context, db *sql.DB) ([]string, error) {
+	return queryStrings(ctx, db, `SELECT TABLE_NAME FROM information_schema.TABLES
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_TYPE = 'BASE TABLE' ORDER BY TABLE_NAME`)
+}
+
+func (mysqlDialect) columns(ctx context.Context, db *sql.DB, table string) ([]*Column, error) {
+	rows, err := db.QueryContext(ctx, `SELECT COLUMN_NAME, COLUMN_TYPE, IS_NULLABLE, COLUMN_KEY FROM information_schema.COLUMNS
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []*Column
+	for rows.Next() {
+		var name, typ, nullable, key string
+		if err := rows.Scan(&name, &typ, &nullable, &key); err != nil {
+			return nil, err
+		}
+		columns = append(columns, &Column{Name: name, Type: strings.ToLower(typ), Nullable: nullable == "YES", PrimaryKey: key == "PRI"})
+	}
+	return columns, rows.Err()
+}
+
+func (mysqlDialect) foreignKeys(ctx context.Context, db *sql.DB, table string) ([]*ForeignKey, error) {
+	return queryForeignKeys(ctx, db, `SELECT COLUMN_NAME, REFERENCED_TABLE_NAME, REFERENCED_COLUMN_NAME
+		FROM information_schema.KEY_COLUMN_USAGE
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND REFERENCED_TABLE_NAME IS NOT NULL
+		AND CONSTRAINT_NAME IN (
+			SELECT CONSTRAINT_NAME FROM information_schema.KEY_COLUMN_USAGE
+			WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND REFERENCED_TABLE_NAME IS NOT NULL
+			GROUP BY CONSTRAINT_NAME HAVING COUNT(*) = 1)`, table, table)
+}
+
+// postgresDialect reads the schema of a PostgreSQL database from information_schema, limited to
+// the current schema (search_path).
+type postgresDialect struct{}
+
+func (postgresDialect) tables(ctx context.Context, db *sql.DB) ([]string, error) {
+	return queryStrings(ctx, db, `SELECT table_name FROM information_schema.tables
+		WHERE table_schema = current_schema() AND table_type = 'BASE TABLE' ORDER BY table_name`)
+}
+
+func (postgresDialect) columns(ctx context.Context, db *sql.DB, table string) ([]*Column, error) {
+	pks, err := queryStrings(ctx, db, `SELECT kcu.column_name
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage kcu
+			ON kcu.constraint_name = tc.constraint_name AND kcu.table_schema = tc.table_schema
+		WHERE tc.table_schema = current_schema() AND tc.table_name = $1 AND tc.constraint_type = 'PRIMARY KEY'`, table)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, `SELECT column_name, data_type, is_nullable FROM information_schema.columns
+		WHERE table_schema = current_schema() AND table_name = $1 ORDER BY ordinal_position`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []*Column
+	for rows.Next() {
+		var name, typ, nullable string
+		if err := rows.Scan(&name, &typ, &nullable); err != nil {
+			return nil, err
+		}
+		columns = append(columns, &Column{Name: name, Type: strings.ToLower(typ), Nullable: nullable == "YES", PrimaryKey: slices.Contains(pks, name)})
+	}
+	return columns, rows.Err()
+}
+
+func (postgresDialect) foreignKeys(ctx context.Context, db *sql.DB, table string) ([]*ForeignKey, error) {
+	return queryForeignKeys(ctx, db, `SELECT MIN(kcu.column_name), MIN(ccu.table_name), MIN(ccu.column_name)
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage kcu
+			ON kcu.constraint_name = tc.constraint_name AND kcu.table_schema = tc.table_schema
+		JOIN information_schema.constraint_column_usage ccu
+			ON ccu.constraint_name = tc.constraint_name AND ccu.table_schema = tc.table_schema
+		WHERE tc.table_schema = current_schema() AND tc.table_name = $1 AND tc.constraint_type = 'FOREIGN KEY'
+		GROUP BY tc.constraint_name HAVING COUNT(*) = 1`, table)
+}
+
+// queryStrings returns the first column of the rows of query.
+func queryStrings(ctx context.Context, db *sql.DB, query string, args ...any) ([]string, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return values, rows.Err()
+}
+
+// queryForeignKeys returns the foreign keys of query, which selects the referencing column, the
+// referenced table and the referenced column.
+func queryForeignKeys(ctx context.Context, db *sql.DB, query string, args ...any) ([]*ForeignKey, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fks []*ForeignKey
+	for rows.Next() {
+		fk := &ForeignKey{}
+		if err := rows.Scan(&fk.Column, &fk.RefTable, &fk.RefColumn); err != nil {
+			return nil, err
+		}
+		fks = append(fks, fk)
+	}
+	return fks, rows.Err()
+}
@@ -0,0 +1,131 @@
+package introspect
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchema = `
+CREATE TABLE bl_authors (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	author_id VARCHAR(36) NOT NULL UNIQUE,
+	name VARCHAR(255) NOT NULL,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL
+);
+CREATE TABLE bl_posts (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	post_id VARCHAR(36) NOT NULL UNIQUE,
+	title VARCHAR(255) NOT NULL,
+	body TEXT,
+	published BOOLEAN NOT NULL DEFAULT 0,
+	views BIGINT NOT NULL DEFAULT 0,
+	rating DOUBLE,
+	published_at DATETIME,
+	cover BLOB,
+	writer_id VARCHAR(36) REFERENCES bl_authors(author_id),
+	legacy_id INTEGER REFERENCES legacy(id),
+	location POINT,
+	deleted_at DATETIME,
+	resource_version BIGINT NOT NULL DEFAULT 1,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL
+);
+CREATE TABLE legacy (
+	id INTEGER PRIMARY KEY,
+	label TEXT
+);
+`
+
+func newTestDB(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "blog.db")
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Exec(testSchema)
+	require.NoError(t, err)
+	return "sqlite://" + path
+}
+
+func TestInspect(t *testing.T) {
+	dsn := newTestDB(t)
+
+	tables, err := Inspect(context.Background(), dsn)
+	require.NoError(t, err)
+	var names []string
+	for _, table := range tables {
+		names = append(names, table.Name)
+	}
+	assert.Equal(t, []string{"bl_authors", "bl_posts", "legacy"}, names)
+
+	posts := tables[1]
+	id, ok := posts.Column("id")
+	require.True(t, ok)
+	assert.True(t, id.PrimaryKey)
+	body, ok := posts.Column("body")
+	require.True(t, ok)
+	assert.Equal(t, "text", body.Type)
+	assert.True(t, body.Nullable)
+
+	fk, ok := posts.ForeignKey("writer_id")
+	require.True(t, ok)
+	assert.Equal(t, &ForeignKey{Column: "writer_id", RefTable: "bl_authors", RefColumn: "author_id"}, fk)
+
+	_, err = Inspect(context.Background(), dsn, "bl_posts", "missing")
+	assert.ErrorContains(t, err, `table "missing" not found`)
+}
+
+func TestKinds(t *testing.T) {
+	tables, err := Inspect(context.Background(), newTestDB(t), "bl_authors", "bl_posts")
+	require.NoError(t, err)
+
+	kinds, notes := Kinds(tables, "bl")
+	require.Len(t, kinds, 2)
+
+	author := kinds[0]
+	assert.Equal(t, "author", author.Name)
+	assert.Equal(t, "bl_authors", author.Table)
+	assert.Equal(t, "name:string", author.FieldSpec())
+
+	post := kinds[1]
+	assert.Equal(t, "post", post.Name)
+	assert.True(t, post.SoftDelete)
+	assert.True(t, post.ResourceVersion)
+	assert.Equal(t, "title:string,body:text,published:bool,views:int64,rating:double,published_at:time,cover:bytes,writer:belongs_to:author,legacy_id:int64", post.FieldSpec())
+
+	assert.Equal(t, []string{
+		"table bl_posts: foreign key legacy_id references table legacy, which is not selected; it is mapped to a column",
+		`table bl_posts: column location has unsupported type "point" and is skipped`,
+	}, notes)
+}
+
+func TestFieldType(t *testing.T) {
+	for columnType, want := range map[string]string{
+		"tinyint(1)":                  "bool",
+		"boolean":                     "bool",
+		"int(11)":                     "int32",
+		"int unsigned":                "uint32",
+		"integer":                     "int64",
+		"bigint(20) unsigned":         "uint64",
+		"real":                        "float",
+		"decimal(10,2)":               "double",
+		"double precision":            "double",
+		"varchar(64)":                 "string",
+		"character varying":           "string",
+		"longtext":                    "text",
+		"jsonb":                       "text",
+		"timestamp without time zone": "time",
+		"datetime(3)":                 "time",
+		"bytea":                       "bytes",
+		"varbinary(16)":               "bytes",
+		"geometry":                    "",
+	} {
+		assert.Equal(t, want, FieldType(columnType), columnType)
+	}
+}
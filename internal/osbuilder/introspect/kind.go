@@ -0,0 +1,143 @@
+package introspect
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/duke-git/lancet/v2/strutil"
+	"github.com/gobuffalo/flect"
+
+	"github.com/onexstack/osbuilder/internal/osbuilder/known"
+	"github.com/onexstack/osbuilder/internal/osbuilder/types"
+)
+
+// KindName returns the kind of a table: the singular snake_case table name without the table
+// prefix of the web server (e.g., "bl_blog_posts" with prefix "bl" is "blog_post").
+func KindName(table string, prefix string) string {
+	name := strings.ToLower(table)
+	if prefix != "" {
+		name = strings.TrimPrefix(name, strings.ToLower(prefix)+"_")
+	}
+	return strutil.SnakeCase(flect.Singularize(name))
+}
+
+// Kinds maps tables to the kinds of a web server whose table prefix is prefix (see KindName).
+//
+// The columns shared by every generated model (id, <kind>_id, created_at and updated_at) are not
+// fields, while deleted_at and resource_version enable soft delete and resource versions. A
+// foreign key <name>_id that references the <kind>_id column of another table becomes a
+// belongs_to relation. Columns that cannot be mapped are skipped, and notes explains why, together
+// with the columns the generated code expects but the tables lack.
+func Kinds(tables []*Table, prefix string) (kinds []*types.Kind, notes []string) {
+	kindOf := make(map[string]string, len(tables))
+	for _, t := range tables {
+		kindOf[t.Name] = KindName(t.Name, prefix)
+	}
+
+	for _, t := range tables {
+		k := &types.Kind{Name: kindOf[t.Name], Table: t.Name}
+		idColumn := k.Name + "_id"
+		note := func(format string, args ...any) {
+			notes = append(notes, fmt.Sprintf("table %s: ", t.Name)+fmt.Sprintf(format, args...))
+		}
+
+		var missing []string
+		for _, name := range []string{"id", idColumn, "created_at", "updated_at"} {
+			if _, ok := t.Column(name); !ok {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			note("missing column(s) %s expected by the generated model", strings.Join(missing, ", "))
+		}
+
+		for _, c := range t.Columns {
+			switch c.Name {
+			case "id", idColumn, "created_at", "updated_at":
+				continue
+			case "deleted_at":
+				k.SoftDelete = true
+				continue
+			case "resource_version":
+				k.ResourceVersion = true
+				continue
+			}
+			if c.PrimaryKey {
+				note("primary key column %s is not id; it is mapped to a field", c.Name)
+			}
+
+			f := &types.Field{Name: c.Name}
+			if fk, ok := t.ForeignKey(c.Name); ok && strings.HasSuffix(c.Name, "_id") {
+				refKind, selected := kindOf[fk.RefTable]
+				switch {
+				case !selected:
+					note("foreign key %s references table %s, which is not selected; it is mapped to a column", c.Name, fk.RefTable)
+				case fk.RefColumn != refKind+"_id":
+					note("foreign key %s references %s.%s instead of %s.%s; it is mapped to a column",
+						c.Name, fk.RefTable, fk.RefColumn, fk.RefTable, refKind+"_id")
+				default:
+					f.Name, f.Type = strings.TrimSuffix(c.Name, "_id"), known.RelationBelongsTo
+					if refKind != f.Name {
+						f.Target = refKind
+					}
+				}
+			}
+			if f.Type == "" {
+				if f.Type = FieldType(c.Type); f.Type == "" {
+					note("column %s has unsupported type %q and is skipped", c.Name, c.Type)
+					continue
+				}
+			}
+			if err := f.Validate(); err != nil {
+				note("column %s is skipped: %v", c.Name, err)
+				continue
+			}
+			k.Fields = append(k.Fields, f)
+		}
+		kinds = append(kinds, k)
+	}
+	return kinds, notes
+}
+
+// FieldType returns the field type of a column type of SQLite, MySQL/MariaDB or PostgreSQL,
+// or an empty string if the column type is not supported.
+func FieldType(columnType string) string {
+	t := strings.ToLower(strings.TrimSpace(columnType))
+	unsigned := strings.Contains(t, "unsigned")
+	base, _, _ := strings.Cut(t, "(")
+	base = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(base), "unsigned"))
+
+	switch {
+	case t == "tinyint(1)", base == "bool", base == "boolean":
+		return known.FieldTypeBool
+	case base == "bigint", base == "int8", base == "bigserial", base == "serial8":
+		if unsigned {
+			return known.FieldTypeUint64
+		}
+		return known.FieldTypeInt64
+	case base == "tinyint", base == "smallint", base == "mediumint", base == "int", base == "int2", base == "int4",
+		base == "integer", base == "serial", base == "smallserial":
+		if unsigned {
+			return known.FieldTypeUint32
+		}
+		if base == "integer" && !strings.Contains(t, "(") {
+			// SQLite integers are 64-bit.
+			return known.FieldTypeInt64
+		}
+		return known.FieldTypeInt32
+	case base == "float", base == "real", base == "float4":
+		return known.FieldTypeFloat
+	case base == "double", base == "double precision", base == "float8", base == "decimal", base == "numeric":
+		return known.FieldTypeDouble
+	case strings.HasPrefix(base, "timestamp"), strings.HasPrefix(base, "datetime"), base == "date":
+		return known.FieldTypeTime
+	case strings.Contains(base, "blob"), strings.HasSuffix(base, "binary"), base == "bytea":
+		return known.FieldTypeBytes
+	case strings.Contains(base, "text"), strings.Contains(base, "clob"), base == "json", base == "jsonb":
+		return known.FieldTypeText
+	case strings.Contains(base, "char"), base == "uuid", base == "enum", base == "set":
+		return known.FieldTypeString
+	default:
+		return ""
+	}
+}
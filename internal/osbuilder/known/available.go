@@ -13,9 +13,11 @@ var (
 		WebFrameworkGRPC,
 		// WebFrameworkGRPCGateway,
 		// WebFrameworkKratos,
-		// WebFrameworkGoZero,
-		// WebFrameworkKitex,
-		// WebFrameworkHeartz,
+		WebFrameworkGoZero,
+		WebFrameworkKitex,
+		WebFrameworkHertz,
+		WebFrameworkNetHTTP,
+		WebFrameworkConnect,
 	)
 
 	// AvailableDeploymentModes lists supported deployment modes.
@@ -68,4 +70,26 @@ var (
 		// ServiceRegistryConsul,
 		// ServiceRegistryNacos,
 	)
+
+	// AvailableCaches lists supported cache backends.
+	AvailableCaches = sets.New(
+		CacheLRU,
+		CacheRedis,
+	)
+
+	// AvailableBrokers lists supported message brokers.
+	AvailableBrokers = sets.New(
+		BrokerKafka,
+		BrokerNATS,
+		BrokerRabbitMQ,
+		BrokerRedis,
+	)
+
+	// AvailableClientTypes lists supported types of typed clients.
+	AvailableClientTypes = sets.New(
+		ClientTypeREST,
+		ClientTypeS3,
+		ClientTypeSMTP,
+		ClientTypeGRPC,
+	)
 )
@@ -18,6 +18,10 @@ const (
 	WebFrameworkKitex = "kitex"
 	// Hertz high-performance HTTP framework.
 	WebFrameworkHertz = "hertz"
+	// Standard library net/http (Go 1.22 ServeMux patterns).
+	WebFrameworkNetHTTP = "nethttp"
+	// Connect RPC (connect-go), serving Connect, gRPC and gRPC-Web.
+	WebFrameworkConnect = "connect"
 	// OneX framework (project-specific).
 	WebFrameworkOneX = "onex"
 )
@@ -103,6 +107,89 @@ const (
 	ServiceRegistryNacos = "nacos"
 )
 
+// Cache backends of the read-through cache in front of the stores of a web server.
+const (
+	// CacheLRU keeps the cached records in a size-bounded in-memory LRU of each process.
+	CacheLRU = "lru"
+	// CacheRedis keeps the cached records in Redis, shared by all the replicas of the server.
+	CacheRedis = "redis"
+)
+
+// Message brokers of the MQ layer of a web server, which the transactional outbox publishes the
+// events of the kinds to.
+const (
+	// BrokerKafka publishes the messages to Kafka topics, partitioned by key.
+	BrokerKafka = "kafka"
+	// BrokerNATS publishes the messages to the subjects of a NATS JetStream stream.
+	BrokerNATS = "nats"
+	// BrokerRabbitMQ publishes the messages to a RabbitMQ topic exchange, routed by topic.
+	BrokerRabbitMQ = "rabbitmq"
+	// BrokerRedis publishes the messages to Redis Streams, one stream per topic.
+	BrokerRedis = "redis"
+)
+
+// Types of the typed clients declared by the clients of a web server.
+const (
+	// ClientTypeREST is a REST client with retries, the type of the clients declared by name only.
+	ClientTypeREST = "rest"
+	// ClientTypeS3 is a client of an S3-compatible object storage (e.g., AWS S3, MinIO, OSS).
+	ClientTypeS3 = "s3"
+	// ClientTypeSMTP is a client that sends mails through an SMTP server.
+	ClientTypeSMTP = "smtp"
+	// ClientTypeGRPC is a client of the gRPC API of another web server of the project.
+	ClientTypeGRPC = "grpc"
+)
+
+// Scalar field types accepted by the `create api --fields` spec.
+const (
+	FieldTypeString = "string"
+	FieldTypeText   = "text"
+	FieldTypeBool   = "bool"
+	FieldTypeInt    = "int"
+	FieldTypeInt32  = "int32"
+	FieldTypeInt64  = "int64"
+	FieldTypeUint32 = "uint32"
+	FieldTypeUint64 = "uint64"
+	FieldTypeFloat  = "float"
+	FieldTypeDouble = "double"
+	FieldTypeTime   = "time"
+	FieldTypeBytes  = "bytes"
+)
+
+// Relation types accepted by the `create api --fields` spec.
+const (
+	// RelationBelongsTo adds a foreign key to the target kind (e.g., post belongs_to user).
+	RelationBelongsTo = "belongs_to"
+	// RelationHasMany is the inverse side of a belongs_to relation (e.g., user has_many posts).
+	RelationHasMany = "has_many"
+)
+
+// Filter operators of the typed filters generated for List requests.
+const (
+	// ListFilterEqual matches records whose column equals the value.
+	ListFilterEqual = "eq"
+	// ListFilterIn matches records whose column is one of the values.
+	ListFilterIn = "in"
+	// ListFilterPrefix matches records whose column starts with the value.
+	ListFilterPrefix = "prefix"
+	// ListFilterAfter matches records whose timestamp is at or after the value.
+	ListFilterAfter = "after"
+	// ListFilterBefore matches records whose timestamp is before the value.
+	ListFilterBefore = "before"
+	// ListFilterMin matches records whose column is greater than or equal to the value.
+	ListFilterMin = "min"
+	// ListFilterMax matches records whose column is less than or equal to the value.
+	ListFilterMax = "max"
+)
+
+// Pagination modes of the List method of a kind.
+const (
+	// PaginationOffset pages with offset and limit and always counts the total (the default).
+	PaginationOffset = "offset"
+	// PaginationCursor pages with opaque page tokens that encode the ordering key of the last record.
+	PaginationCursor = "cursor"
+)
+
 // Default project manifest file name.
 const ProjectFileName = "PROJECT"
 
@@ -117,6 +204,8 @@ var (
 		WebFrameworkGoZero,
 		WebFrameworkKitex,
 		WebFrameworkHertz,
+		WebFrameworkNetHTTP,
+		WebFrameworkConnect,
 		WebFrameworkOneX,
 	}
 	// AllDeploymentModes lists all supported deployment modes.
@@ -173,7 +262,24 @@ var (
 		ServiceRegistryNacos,
 	}
 
+	// AllFieldTypes lists all supported scalar field types.
+	AllFieldTypes = []string{
+		FieldTypeString,
+		FieldTypeText,
+		FieldTypeBool,
+		FieldTypeInt,
+		FieldTypeInt32,
+		FieldTypeInt64,
+		FieldTypeUint32,
+		FieldTypeUint64,
+		FieldTypeFloat,
+		FieldTypeDouble,
+		FieldTypeTime,
+		FieldTypeBytes,
+	}
+
 	serviceRegistrySet = newSet(AllServiceRegistryTypes)
+	fieldTypeSet       = newSet(AllFieldTypes)
 	webFrameworkSet    = newSet(AllWebFrameworks)
 	deploymentModeSet  = newSet(AllDeploymentModes)
 	applicationTypeSet = newSet(AllApplicationTypes)
@@ -213,6 +319,9 @@ func IsValidAppStyle(v string) bool { return has(appStyleSet, v) }
 // IsValidMakefileMode reports whether v is a supported makefile mode.
 func IsValidMakefileMode(v string) bool { return has(makefileModeSet, v) }
 
+// IsValidFieldType reports whether v is a supported scalar field type.
+func IsValidFieldType(v string) bool { return has(fieldTypeSet, v) }
+
 // CanonicalWebFramework normalizes common inputs to a supported framework.
 func CanonicalWebFramework(s string) (string, bool) {
 	k := normalize(s)
@@ -231,6 +340,10 @@ func CanonicalWebFramework(s string) (string, bool) {
 		return WebFrameworkKitex, true
 	case "hertz", "heartz": // accept legacy misspelling
 		return WebFrameworkHertz, true
+	case "nethttp", "net/http", "http":
+		return WebFrameworkNetHTTP, true
+	case "connect", "connect-go", "connectrpc":
+		return WebFrameworkConnect, true
 	case "onex":
 		return WebFrameworkOneX, true
 	default:
@@ -0,0 +1,176 @@
+// Package migration generates the versioned SQL migrations that create and alter the tables of
+// the kinds of a web server, one set of files per SQL dialect.
+package migration
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/onexstack/osbuilder/internal/osbuilder/helper"
+	"github.com/onexstack/osbuilder/internal/osbuilder/known"
+	"github.com/onexstack/osbuilder/internal/osbuilder/types"
+)
+
+// Dialect is an SQL dialect that migrations are generated for. Its value is both the name of the
+// GORM dialector and the directory of its migrations.
+type Dialect string
+
+const (
+	MySQL    Dialect = "mysql"
+	Postgres Dialect = "postgres"
+	SQLite   Dialect = "sqlite"
+)
+
+// Dialects lists the dialects that every migration is generated for, so that a project can move
+// between storage types without losing its schema history.
+var Dialects = []Dialect{MySQL, Postgres, SQLite}
+
+// Column types that are not field types.
+const (
+	// typeID is the auto-increment primary key.
+	typeID = "id"
+	// typeKey is a resource ID, such as the ID of the kind, of a parent or of a belongs_to target.
+	typeKey = "key"
+	// typeTimestamp is a timestamp set by the database when the record is created.
+	typeTimestamp = "timestamp"
+)
+
+// Column describes a column of the table of a kind.
+type Column struct {
+	Name string
+	// Type is a field type (see known.AllFieldTypes) or one of the column types above.
+	Type    string
+	NotNull bool
+	// Default is the SQL default value, if any.
+	Default string
+	Index   bool
+	// Unique makes the index of the column a unique index.
+	Unique bool
+}
+
+// Script holds the statements that apply a migration and the ones that revert it.
+type Script struct {
+	Up   string
+	Down string
+}
+
+// Table returns the table of a kind, which is the table it was generated from, if any, or
+// <prefix>_<kind> where prefix is the first segment of the binary name (e.g., "mb_post").
+func Table(binaryName string, k *types.Kind) string {
+	if k.Table != "" {
+		return k.Table
+	}
+	return helper.ExtractProjectPrefix()(binaryName) + "_" + types.NewREST(k.Name, "").SingularLower
+}
+
+// Columns returns the columns of the table of a kind, in the order of the fields of its model.
+// has_many relations have no column.
+func Columns(k *types.Kind) []*Column {
+	r := types.NewREST(k.Name, "")
+	cols := []*Column{
+		{Name: "id", Type: typeID},
+		{Name: r.IDColumn(), Type: typeKey, NotNull: true, Default: "''", Index: true},
+		{Name: "created_at", Type: typeTimestamp, NotNull: true},
+		{Name: "updated_at", Type: typeTimestamp, NotNull: true},
+	}
+	if k.SoftDelete {
+		cols = append(cols, &Column{Name: "deleted_at", Type: known.FieldTypeTime, Index: true})
+	}
+	if k.ResourceVersion {
+		cols = append(cols, &Column{Name: "resource_version", Type: known.FieldTypeInt64, NotNull: true, Default: "1"})
+	}
+	if k.Nested {
+		segments := strings.Split(k.Name, "/")
+		for i := 1; i < len(segments); i++ {
+			parent := types.NewREST(strings.Join(segments[:i], "/"), "")
+			cols = append(cols, &Column{Name: parent.IDColumn(), Type: typeKey, NotNull: true, Default: "''", Index: true})
+		}
+	}
+	for _, f := range k.Fields {
+		if !f.IsRelation() {
+			cols = append(cols, &Column{Name: f.Column(), Type: f.Type})
+		}
+	}
+	for _, f := range k.Fields {
+		if f.IsBelongsTo() {
+			cols = append(cols, &Column{Name: f.ForeignKeyColumn(), Type: typeKey, Index: true})
+		}
+	}
+	return cols
+}
+
+// UserColumns returns the columns of the table of the built-in user kind, which is generated with
+// the project rather than by `create api`.
+func UserColumns() []*Column {
+	return []*Column{
+		{Name: "id", Type: typeID},
+		{Name: "user_id", Type: typeKey, NotNull: true, Default: "''", Index: true, Unique: true},
+		{Name: "username", Type: known.FieldTypeString, NotNull: true, Index: true, Unique: true},
+		{Name: "password", Type: known.FieldTypeString, NotNull: true},
+		{Name: "nickname", Type: known.FieldTypeString, NotNull: true},
+		{Name: "email", Type: known.FieldTypeString, NotNull: true},
+		{Name: "phone", Type: known.FieldTypeString, NotNull: true, Index: true, Unique: true},
+		{Name: "created_at", Type: typeTimestamp, NotNull: true},
+		{Name: "updated_at", Type: typeTimestamp, NotNull: true},
+	}
+}
+
+// OutboxColumns returns the columns of the table of the outbox messages, which are published in
+// the order of their id.
+func OutboxColumns() []*Column {
+	return []*Column{
+		{Name: "id", Type: typeID},
+		{Name: "topic", Type: known.FieldTypeString, NotNull: true},
+		{Name: "message_key", Type: typeKey, NotNull: true, Default: "''"},
+		{Name: "payload", Type: known.FieldTypeBytes},
+		{Name: "headers", Type: known.FieldTypeText},
+		{Name: "created_at", Type: typeTimestamp, NotNull: true},
+	}
+}
+
+// fileName matches the name of a migration file, e.g., "000001_create_mb_post.up.sql".
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// FileNames returns the names of the up and down files of a migration.
+func FileNames(version int, name string) (up string, down string) {
+	prefix := fmt.Sprintf("%06d_%s", version, name)
+	return prefix + ".up.sql", prefix + ".down.sql"
+}
+
+// NextVersion returns the version that follows the latest migration in the dialect directories of dir.
+func NextVersion(dir string) (int, error) {
+	latest := 0
+	for _, d := range Dialects {
+		entries, err := os.ReadDir(filepath.Join(dir, string(d)))
+		if err != nil && !os.IsNotExist(err) {
+			return 0, err
+		}
+		for _, e := range entries {
+			m := fileName.FindStringSubmatch(e.Name())
+			if m == nil {
+				continue
+			}
+			if v, _ := strconv.Atoi(m[1]); v > latest {
+				latest = v
+			}
+		}
+	}
+	return latest + 1, nil
+}
+
+// HasCreate reports whether dir has a migration that creates table.
+func HasCreate(dir string, table string) bool {
+	for _, d := range Dialects {
+		matches, _ := filepath.Glob(filepath.Join(dir, string(d), "*_create_"+table+".up.sql"))
+		for _, m := range matches {
+			if sm := fileName.FindStringSubmatch(filepath.Base(m)); sm != nil && sm[2] == "create_"+table {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package migration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onexstack/osbuilder/internal/osbuilder/types"
+)
+
+func TestTable(t *testing.T) {
+	assert.Equal(t, "mb_post", Table("mb-apiserver", &types.Kind{Name: "post"}))
+	assert.Equal(t, "mb_ticketcronjob", Table("mb-apiserver", &types.Kind{Name: "ticket/cron_job"}))
+	assert.Equal(t, "posts", Table("mb-apiserver", &types.Kind{Name: "post", Table: "posts"}))
+
+	// The tables of the kinds and the outbox table of a binary name with several dashes have the
+	// same prefix.
+	ws := &types.WebServer{BinaryName: "mb-order-apiserver"}
+	assert.Equal(t, "mb_post", Table(ws.BinaryName, &types.Kind{Name: "post"}))
+	assert.Equal(t, "mb_outbox", ws.OutboxTable())
+}
+
+func TestCreate(t *testing.T) {
+	k := &types.Kind{
+		Name:            "ticket/comment",
+		Nested:          true,
+		SoftDelete:      true,
+		ResourceVersion: true,
+		Fields: []*types.Field{
+			{Name: "content", Type: "text"},
+			{Name: "author", Type: "belongs_to", Target: "user"},
+			{Name: "replies", Type: "has_many", Target: "reply"},
+		},
+	}
+
+	assert.Equal(t, `CREATE TABLE IF NOT EXISTS "bl_comment" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "comment_id" TEXT NOT NULL DEFAULT '',
+  "created_at" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "deleted_at" DATETIME,
+  "resource_version" INTEGER NOT NULL DEFAULT 1,
+  "ticket_id" TEXT NOT NULL DEFAULT '',
+  "content" TEXT,
+  "author_id" TEXT
+);
+CREATE INDEX IF NOT EXISTS "idx_bl_comment_comment_id" ON "bl_comment" ("comment_id");
+CREATE INDEX IF NOT EXISTS "idx_bl_comment_deleted_at" ON "bl_comment" ("deleted_at");
+CREATE INDEX IF NOT EXISTS "idx_bl_comment_ticket_id" ON "bl_comment" ("ticket_id");
+CREATE INDEX IF NOT EXISTS "idx_bl_comment_author_id" ON "bl_comment" ("author_id");
+`, Create(SQLite, "bl_comment", Columns(k)).Up)
+
+	mysql := Create(MySQL, "bl_post", Columns(&types.Kind{Name: "post", Fields: []*types.Field{{Name: "views", Type: "uint32"}}}))
+	assert.Equal(t, "CREATE TABLE IF NOT EXISTS `bl_post` (\n"+
+		"  `id` BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,\n"+
+		"  `post_id` VARCHAR(64) NOT NULL DEFAULT '',\n"+
+		"  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),\n"+
+		"  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),\n"+
+		"  `views` INT UNSIGNED,\n"+
+		"  KEY `idx_bl_post_post_id` (`post_id`)\n"+
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;\n", mysql.Up)
+	assert.Equal(t, "DROP TABLE IF EXISTS `bl_post`;\n", mysql.Down)
+}
+
+func TestUserColumns(t *testing.T) {
+	assert.Equal(t, `CREATE TABLE IF NOT EXISTS "mb_user" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "user_id" TEXT NOT NULL DEFAULT '',
+  "username" TEXT NOT NULL,
+  "password" TEXT NOT NULL,
+  "nickname" TEXT NOT NULL,
+  "email" TEXT NOT NULL,
+  "phone" TEXT NOT NULL,
+  "created_at" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  "updated_at" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_mb_user_user_id" ON "mb_user" ("user_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_mb_user_username" ON "mb_user" ("username");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_mb_user_phone" ON "mb_user" ("phone");
+`, Create(SQLite, "mb_user", UserColumns()).Up)
+
+	mysql := Create(MySQL, "mb_user", UserColumns()).Up
+	assert.Contains(t, mysql, "  UNIQUE KEY `idx_mb_user_user_id` (`user_id`),\n")
+	assert.Contains(t, mysql, "  UNIQUE KEY `idx_mb_user_phone` (`phone`)\n")
+}
+
+func TestOutboxColumns(t *testing.T) {
+	assert.Equal(t, `CREATE TABLE IF NOT EXISTS "mb_outbox" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "topic" TEXT NOT NULL,
+  "message_key" TEXT NOT NULL DEFAULT '',
+  "payload" BLOB,
+  "headers" TEXT,
+  "created_at" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+`, Create(SQLite, "mb_outbox", OutboxColumns()).Up)
+}
+
+func TestAlter(t *testing.T) {
+	before := Columns(&types.Kind{Name: "post", Fields: []*types.Field{
+		{Name: "title", Type: "string"},
+		{Name: "views", Type: "int32"},
+	}})
+	after := Columns(&types.Kind{Name: "post", Fields: []*types.Field{
+		{Name: "title", Type: "string"},
+		{Name: "views", Type: "int64"},
+		{Name: "user", Type: "belongs_to"},
+	}})
+
+	script, ok := Alter(Postgres, "bl_post", before, after)
+	require.True(t, ok)
+	assert.Equal(t, `ALTER TABLE "bl_post" ALTER COLUMN "views" TYPE BIGINT USING "views"::BIGINT;
+ALTER TABLE "bl_post" ADD COLUMN "user_id" VARCHAR(64);
+CREATE INDEX "idx_bl_post_user_id" ON "bl_post" ("user_id");
+`, script.Up)
+	assert.Equal(t, `DROP INDEX IF EXISTS "idx_bl_post_user_id";
+ALTER TABLE "bl_post" DROP COLUMN "user_id";
+ALTER TABLE "bl_post" ALTER COLUMN "views" TYPE INTEGER USING "views"::INTEGER;
+`, script.Down)
+
+	script, ok = Alter(MySQL, "bl_post", after, before)
+	require.True(t, ok)
+	assert.Equal(t, "ALTER TABLE `bl_post` MODIFY COLUMN `views` INT;\n"+
+		"DROP INDEX `idx_bl_post_user_id` ON `bl_post`;\n"+
+		"ALTER TABLE `bl_post` DROP COLUMN `user_id`;\n", script.Up)
+
+	// SQLite stores int32 and int64 values in INTEGER columns.
+	script, ok = Alter(SQLite, "bl_post", before, after)
+	require.True(t, ok)
+	assert.NotContains(t, script.Up, "views")
+
+	_, ok = Alter(SQLite, "bl_post", after, after)
+	assert.False(t, ok)
+}
+
+func TestNextVersion(t *testing.T) {
+	dir := t.TempDir()
+	v, err := NextVersion(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+
+	up, down := FileNames(7, "create_bl_post")
+	assert.Equal(t, "000007_create_bl_post.up.sql", up)
+	assert.Equal(t, "000007_create_bl_post.down.sql", down)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "postgres"), 0o755))
+	for _, name := range []string{up, down, ".keep"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "postgres", name), nil, 0o644))
+	}
+
+	v, err = NextVersion(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 8, v)
+	assert.True(t, HasCreate(dir, "bl_post"))
+	assert.False(t, HasCreate(dir, "bl"))
+	assert.False(t, HasCreate(dir, "post"))
+}
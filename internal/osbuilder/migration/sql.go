@@ -0,0 +1,186 @@
+package migration
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onexstack/osbuilder/internal/osbuilder/known"
+)
+
+// columnTypes maps field and column types to the column type of each dialect.
+var columnTypes = map[string]map[Dialect]string{
+	typeKey:               {MySQL: "VARCHAR(64)", Postgres: "VARCHAR(64)", SQLite: "TEXT"},
+	typeTimestamp:         {MySQL: "DATETIME(3)", Postgres: "TIMESTAMPTZ", SQLite: "DATETIME"},
+	known.FieldTypeString: {MySQL: "VARCHAR(255)", Postgres: "VARCHAR(255)", SQLite: "TEXT"},
+	known.FieldTypeText:   {MySQL: "LONGTEXT", Postgres: "TEXT", SQLite: "TEXT"},
+	known.FieldTypeBool:   {MySQL: "TINYINT(1)", Postgres: "BOOLEAN", SQLite: "NUMERIC"},
+	known.FieldTypeInt:    {MySQL: "BIGINT", Postgres: "BIGINT", SQLite: "INTEGER"},
+	known.FieldTypeInt32:  {MySQL: "INT", Postgres: "INTEGER", SQLite: "INTEGER"},
+	known.FieldTypeInt64:  {MySQL: "BIGINT", Postgres: "BIGINT", SQLite: "INTEGER"},
+	known.FieldTypeUint32: {MySQL: "INT UNSIGNED", Postgres: "BIGINT", SQLite: "INTEGER"},
+	known.FieldTypeUint64: {MySQL: "BIGINT UNSIGNED", Postgres: "NUMERIC(20)", SQLite: "INTEGER"},
+	known.FieldTypeFloat:  {MySQL: "FLOAT", Postgres: "REAL", SQLite: "REAL"},
+	known.FieldTypeDouble: {MySQL: "DOUBLE", Postgres: "DOUBLE PRECISION", SQLite: "REAL"},
+	known.FieldTypeTime:   {MySQL: "DATETIME(3)", Postgres: "TIMESTAMPTZ", SQLite: "DATETIME"},
+	known.FieldTypeBytes:  {MySQL: "LONGBLOB", Postgres: "BYTEA", SQLite: "BLOB"},
+}
+
+// quote quotes an identifier.
+func (d Dialect) quote(name string) string {
+	if d == MySQL {
+		return "`" + name + "`"
+	}
+	return `"` + name + `"`
+}
+
+// definition returns the definition of a column in CREATE TABLE and ADD COLUMN statements.
+func (d Dialect) definition(c *Column) string {
+	if c.Type == typeID {
+		switch d {
+		case MySQL:
+			return d.quote(c.Name) + " BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY"
+		case Postgres:
+			return d.quote(c.Name) + " BIGSERIAL PRIMARY KEY"
+		default:
+			return d.quote(c.Name) + " INTEGER PRIMARY KEY AUTOINCREMENT"
+		}
+	}
+
+	def := d.quote(c.Name) + " " + columnTypes[c.Type][d]
+	if c.NotNull {
+		def += " NOT NULL"
+	}
+	switch {
+	case c.Type == typeTimestamp && d == MySQL:
+		def += " DEFAULT CURRENT_TIMESTAMP(3)"
+	case c.Type == typeTimestamp:
+		def += " DEFAULT CURRENT_TIMESTAMP"
+	case c.Default != "":
+		def += " DEFAULT " + c.Default
+	}
+	return def
+}
+
+// index returns the name of the index of a column.
+func index(table string, c *Column) string {
+	return "idx_" + table + "_" + c.Name
+}
+
+// createIndex returns the statement that creates the index of a column.
+func (d Dialect) createIndex(table string, c *Column, ifNotExists bool) string {
+	clause := ""
+	if ifNotExists {
+		clause = "IF NOT EXISTS "
+	}
+	kind := "INDEX"
+	if c.Unique {
+		kind = "UNIQUE INDEX"
+	}
+	return fmt.Sprintf("CREATE %s %s%s ON %s (%s);\n", kind, clause, d.quote(index(table, c)), d.quote(table), d.quote(c.Name))
+}
+
+// dropIndex returns the statement that drops the index of a column.
+func (d Dialect) dropIndex(table string, c *Column) string {
+	if d == MySQL {
+		return fmt.Sprintf("DROP INDEX %s ON %s;\n", d.quote(index(table, c)), d.quote(table))
+	}
+	return fmt.Sprintf("DROP INDEX IF EXISTS %s;\n", d.quote(index(table, c)))
+}
+
+// Create returns the migration that creates a table with the given columns. The table is only
+// created if it does not exist, so that the migration can be applied to a database that was
+// migrated by GORM or created from a schema file before.
+func Create(d Dialect, table string, cols []*Column) *Script {
+	defs := make([]string, 0, len(cols))
+	for _, c := range cols {
+		defs = append(defs, "  "+d.definition(c))
+	}
+
+	var up strings.Builder
+	if d == MySQL {
+		// MySQL has no CREATE INDEX IF NOT EXISTS, so the indexes are part of the table.
+		for _, c := range cols {
+			switch {
+			case c.Unique:
+				defs = append(defs, fmt.Sprintf("  UNIQUE KEY %s (%s)", d.quote(index(table, c)), d.quote(c.Name)))
+			case c.Index:
+				defs = append(defs, fmt.Sprintf("  KEY %s (%s)", d.quote(index(table, c)), d.quote(c.Name)))
+			}
+		}
+		fmt.Fprintf(&up, "CREATE TABLE IF NOT EXISTS %s (\n%s\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;\n", d.quote(table), strings.Join(defs, ",\n"))
+	} else {
+		fmt.Fprintf(&up, "CREATE TABLE IF NOT EXISTS %s (\n%s\n);\n", d.quote(table), strings.Join(defs, ",\n"))
+		for _, c := range cols {
+			if c.Index {
+				up.WriteString(d.createIndex(table, c, true))
+			}
+		}
+	}
+
+	return &Script{
+		Up:   up.String(),
+		Down: fmt.Sprintf("DROP TABLE IF EXISTS %s;\n", d.quote(table)),
+	}
+}
+
+// Alter returns the migration that changes the columns of a table from before to after: new
+// columns are added, columns that are gone are dropped and columns whose type changed are
+// converted. It reports false if the columns of the table did not change.
+func Alter(d Dialect, table string, before, after []*Column) (*Script, bool) {
+	old := map[string]*Column{}
+	for _, c := range before {
+		old[c.Name] = c
+	}
+	current := map[string]bool{}
+
+	var up, down []string
+	for _, c := range after {
+		current[c.Name] = true
+		prev, ok := old[c.Name]
+		switch {
+		case !ok:
+			u, dn := d.addColumn(table, c)
+			up, down = append(up, u), append([]string{dn}, down...)
+		case columnTypes[prev.Type][d] != columnTypes[c.Type][d]:
+			up, down = append(up, d.changeType(table, prev, c)), append([]string{d.changeType(table, c, prev)}, down...)
+		}
+	}
+	for _, c := range before {
+		if !current[c.Name] {
+			dn, u := d.addColumn(table, c)
+			up, down = append(up, u), append([]string{dn}, down...)
+		}
+	}
+
+	if len(up) == 0 {
+		return nil, false
+	}
+	return &Script{Up: strings.Join(up, ""), Down: strings.Join(down, "")}, true
+}
+
+// addColumn returns the statements that add a column and the ones that drop it.
+func (d Dialect) addColumn(table string, c *Column) (add string, drop string) {
+	add = fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s;\n", d.quote(table), d.definition(c))
+	drop = fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;\n", d.quote(table), d.quote(c.Name))
+	if c.Index {
+		add += d.createIndex(table, c, false)
+		drop = d.dropIndex(table, c) + drop
+	}
+	return add, drop
+}
+
+// changeType returns the statement that converts a column from the type of prev to the type of c.
+func (d Dialect) changeType(table string, prev, c *Column) string {
+	switch d {
+	case MySQL:
+		return fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s;\n", d.quote(table), d.definition(c))
+	case Postgres:
+		typ := columnTypes[c.Type][d]
+		return fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s TYPE %s USING %s::%s;\n",
+			d.quote(table), d.quote(c.Name), typ, d.quote(c.Name), typ)
+	default:
+		// SQLite cannot change the type of a column, but stores any value in any column.
+		return fmt.Sprintf("-- Column %s keeps its declared type %s; SQLite stores %s values in it as is.\n",
+			c.Name, columnTypes[prev.Type][d], columnTypes[c.Type][d])
+	}
+}
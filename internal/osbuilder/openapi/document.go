@@ -0,0 +1,241 @@
+// Package openapi reads the subset of OpenAPI 3 and Swagger 2 documents that `create api
+// --from-openapi` maps to kinds: the paths with their operations and the schemas they use.
+package openapi
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document is an OpenAPI 3 or Swagger 2 document, in YAML or JSON.
+type Document struct {
+	// Swagger is the version of a Swagger 2 document.
+	Swagger string `yaml:"swagger"`
+	// OpenAPI is the version of an OpenAPI 3 document.
+	OpenAPI string `yaml:"openapi"`
+	// Paths maps the paths of the API to their operations.
+	Paths map[string]*PathItem `yaml:"paths"`
+	// Components holds the schemas of an OpenAPI 3 document.
+	Components struct {
+		Schemas map[string]*Schema `yaml:"schemas"`
+	} `yaml:"components"`
+	// Definitions holds the schemas of a Swagger 2 document.
+	Definitions map[string]*Schema `yaml:"definitions"`
+}
+
+// PathItem holds the operations of a path.
+type PathItem struct {
+	Get    *Operation `yaml:"get"`
+	Put    *Operation `yaml:"put"`
+	Post   *Operation `yaml:"post"`
+	Delete *Operation `yaml:"delete"`
+	Patch  *Operation `yaml:"patch"`
+}
+
+// Operation is an operation on a path.
+type Operation struct {
+	// Parameters holds the body parameter of a Swagger 2 operation, among others.
+	Parameters []*Parameter `yaml:"parameters"`
+	// RequestBody is the request body of an OpenAPI 3 operation.
+	RequestBody *Body `yaml:"requestBody"`
+	// Responses maps status codes to responses.
+	Responses map[string]*Response `yaml:"responses"`
+}
+
+// Parameter is a parameter of an operation.
+type Parameter struct {
+	In     string  `yaml:"in"`
+	Schema *Schema `yaml:"schema"`
+}
+
+// Body is an OpenAPI 3 request body.
+type Body struct {
+	Content map[string]*MediaType `yaml:"content"`
+}
+
+// Response is the response of an operation: Schema in Swagger 2, Content in OpenAPI 3.
+type Response struct {
+	Schema  *Schema               `yaml:"schema"`
+	Content map[string]*MediaType `yaml:"content"`
+}
+
+// MediaType holds the schema of a request or response body.
+type MediaType struct {
+	Schema *Schema `yaml:"schema"`
+}
+
+// Schema is a JSON schema, limited to the keywords that map to fields.
+type Schema struct {
+	Ref        string     `yaml:"$ref"`
+	Type       SchemaType `yaml:"type"`
+	Format     string     `yaml:"format"`
+	MaxLength  int        `yaml:"maxLength"`
+	Properties Properties `yaml:"properties"`
+	AllOf      []*Schema  `yaml:"allOf"`
+}
+
+// SchemaType is the type of a schema. OpenAPI 3.1 types such as ["string", "null"] are reduced
+// to their non-null type.
+type SchemaType string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (t *SchemaType) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*t = SchemaType(node.Value)
+		return nil
+	}
+	var types []string
+	if err := node.Decode(&types); err != nil {
+		return err
+	}
+	for _, typ := range types {
+		if typ != "null" {
+			*t = SchemaType(typ)
+			return nil
+		}
+	}
+	return nil
+}
+
+// Property is a named property of an object schema.
+type Property struct {
+	Name   string
+	Schema *Schema
+}
+
+// Properties holds the properties of an object schema in document order.
+type Properties []*Property
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (p *Properties) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: properties must be a mapping", node.Line)
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		s := &Schema{}
+		if err := node.Content[i+1].Decode(s); err != nil {
+			return err
+		}
+		*p = append(*p, &Property{Name: node.Content[i].Value, Schema: s})
+	}
+	return nil
+}
+
+// Load reads the OpenAPI 3 or Swagger 2 document at path.
+func Load(path string) (*Document, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Document{}
+	if err := yaml.Unmarshal(b, doc); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") && !strings.HasPrefix(doc.Swagger, "2.") {
+		return nil, fmt.Errorf("%s is neither an OpenAPI 3 nor a Swagger 2 document", path)
+	}
+	return doc, nil
+}
+
+// Resolve follows the local $ref of s (e.g., "#/components/schemas/Post"). It returns the
+// referenced schema and its name, or s itself and an empty name if s is not a reference.
+func (d *Document) Resolve(s *Schema) (*Schema, string, error) {
+	name := ""
+	for seen := 0; s != nil && s.Ref != ""; seen++ {
+		if seen > 32 {
+			return nil, "", fmt.Errorf("$ref %q: too many levels of references", s.Ref)
+		}
+
+		var schemas map[string]*Schema
+		switch {
+		case strings.HasPrefix(s.Ref, "#/components/schemas/"):
+			name, schemas = strings.TrimPrefix(s.Ref, "#/components/schemas/"), d.Components.Schemas
+		case strings.HasPrefix(s.Ref, "#/definitions/"):
+			name, schemas = strings.TrimPrefix(s.Ref, "#/definitions/"), d.Definitions
+		default:
+			return nil, "", fmt.Errorf("$ref %q: only local schema references are supported", s.Ref)
+		}
+		ref, ok := schemas[name]
+		if !ok {
+			return nil, "", fmt.Errorf("$ref %q: schema not found", s.Ref)
+		}
+		s = ref
+	}
+	return s, name, nil
+}
+
+// Schemas returns the named schemas of the document.
+func (d *Document) Schemas() map[string]*Schema {
+	if d.Definitions != nil {
+		return d.Definitions
+	}
+	return d.Components.Schemas
+}
+
+// Properties returns the properties of the object schema s, including the properties of the
+// schemas it is composed of with allOf.
+func (d *Document) Properties(s *Schema) (Properties, error) {
+	s, _, err := d.Resolve(s)
+	if err != nil || s == nil {
+		return nil, err
+	}
+
+	props := append(Properties{}, s.Properties...)
+	for _, part := range s.AllOf {
+		more, err := d.Properties(part)
+		if err != nil {
+			return nil, err
+		}
+		props = append(props, more...)
+	}
+	return props, nil
+}
+
+// requestSchema returns the schema of the JSON request body of op, if any.
+func (op *Operation) requestSchema() *Schema {
+	if op == nil {
+		return nil
+	}
+	if op.RequestBody != nil {
+		return jsonSchema(op.RequestBody.Content)
+	}
+	for _, p := range op.Parameters {
+		if p.In == "body" {
+			return p.Schema
+		}
+	}
+	return nil
+}
+
+// responseSchema returns the schema of the successful JSON response of op, if any.
+func (op *Operation) responseSchema() *Schema {
+	if op == nil {
+		return nil
+	}
+	for _, code := range []string{"200", "201"} {
+		if r, ok := op.Responses[code]; ok && r != nil {
+			if r.Schema != nil {
+				return r.Schema
+			}
+			return jsonSchema(r.Content)
+		}
+	}
+	return nil
+}
+
+// jsonSchema returns the schema of the JSON media type of content.
+func jsonSchema(content map[string]*MediaType) *Schema {
+	if m, ok := content["application/json"]; ok && m != nil {
+		return m.Schema
+	}
+	for mediaType, m := range content {
+		if m != nil && strings.Contains(mediaType, "json") {
+			return m.Schema
+		}
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+package openapi
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/duke-git/lancet/v2/strutil"
+	"github.com/gobuffalo/flect"
+
+	"github.com/onexstack/osbuilder/internal/osbuilder/known"
+	"github.com/onexstack/osbuilder/internal/osbuilder/types"
+)
+
+var (
+	// reVersion matches the leading path segments that are not part of the resource path (e.g., "/api/v1").
+	reVersion = regexp.MustCompile(`^(api|v\d+(alpha\d*|beta\d*)?)$`)
+	// reParam matches a path parameter, optionally followed by a custom verb (e.g., "{id}:publish").
+	reParam = regexp.MustCompile(`^\{[^}]+\}(:([A-Za-z][\w-]*))?$`)
+	// reLiteral matches a literal path segment.
+	reLiteral = regexp.MustCompile(`^[A-Za-z][\w-]*$`)
+)
+
+// resource collects the operations of the paths that belong to a kind.
+type resource struct {
+	kind       *types.Kind
+	collection *PathItem
+	item       *PathItem
+}
+
+// Kinds maps the paths of the document to kinds. The resource model of osbuilder accepts:
+//   - /<kinds>: List (GET) and Create (POST)
+//   - /<kinds>/{id}: Get (GET), Update (PUT or PATCH) and Delete (DELETE)
+//   - /<kinds>/{id}:<verb> and /<kinds>/{id}/<verb>: custom methods (POST)
+//
+// behind optional "/api" and version segments. The fields of a kind are the properties of the
+// schema returned by Get, or else sent to Create or Update, or else named after the kind.
+// Properties named <kind>Id that point to another kind become belongs_to relations. Paths,
+// operations and properties that do not fit are skipped, and notes explains why.
+func Kinds(doc *Document) (kinds []*types.Kind, notes []string) {
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	byKind := map[string]*resource{}
+	var order []string
+	get := func(segment string) *resource {
+		name := kindName(segment)
+		r, ok := byKind[name]
+		if !ok {
+			r = &resource{kind: &types.Kind{Name: name}}
+			byKind[name] = r
+			order = append(order, name)
+		}
+		return r
+	}
+
+	for _, path := range paths {
+		item := doc.Paths[path]
+		if item == nil {
+			continue
+		}
+
+		segments := strings.Split(strings.Trim(path, "/"), "/")
+		for len(segments) > 1 && reVersion.MatchString(segments[0]) {
+			segments = segments[1:]
+		}
+		if !reLiteral.MatchString(segments[0]) {
+			notes = append(notes, fmt.Sprintf("path %s does not fit the resource model and is skipped", path))
+			continue
+		}
+
+		var (
+			m     []string
+			fits  bool
+			extra []string
+		)
+		if len(segments) > 1 {
+			m = reParam.FindStringSubmatch(segments[1])
+		}
+		switch {
+		case len(segments) == 1 && item.Post == nil && byKind[kindName(segments[0])] == nil && !hasItem(doc, path):
+			// A path without Create nor an item path (e.g., "/healthz") is not a resource.
+		case len(segments) == 1:
+			r := get(segments[0])
+			r.collection, fits = item, true
+			extra = methods(item, "put", "patch", "delete")
+		case len(segments) == 2 && m != nil && m[2] == "":
+			r := get(segments[0])
+			r.item, fits = item, true
+			extra = methods(item, "post")
+		case len(segments) == 2 && m != nil, len(segments) == 3 && m != nil && m[2] == "" && reLiteral.MatchString(segments[2]):
+			verb := m[2]
+			if verb == "" {
+				verb = segments[2]
+			}
+			r := get(segments[0])
+			fits = item.Post != nil
+			if fits {
+				notes = append(notes, addVerb(r.kind, path, verb)...)
+			}
+			extra = methods(item, "get", "put", "patch", "delete")
+		}
+		if !fits {
+			notes = append(notes, fmt.Sprintf("path %s does not fit the resource model and is skipped", path))
+			continue
+		}
+		for _, method := range extra {
+			notes = append(notes, fmt.Sprintf("operation %s %s does not fit the resource model and is skipped", strings.ToUpper(method), path))
+		}
+	}
+
+	for _, name := range order {
+		r := byKind[name]
+		notes = append(notes, addFields(doc, r, byKind)...)
+		if r.kind.SoftDelete {
+			// The Restore and Purge methods of soft deleted kinds are generated.
+			r.kind.Verbs = slices.DeleteFunc(r.kind.Verbs, func(v string) bool { return v == "restore" || v == "purge" })
+		}
+		kinds = append(kinds, r.kind)
+	}
+	return kinds, notes
+}
+
+// kindName returns the kind served under the collection path segment (e.g., "blog-posts" is "blog_post").
+func kindName(segment string) string {
+	return strutil.SnakeCase(flect.Singularize(segment))
+}
+
+// hasItem reports whether the document has an item path under the collection path.
+func hasItem(doc *Document, collection string) bool {
+	for path := range doc.Paths {
+		if rest, ok := strings.CutPrefix(path, collection+"/"); ok && reParam.MatchString(rest) {
+			return true
+		}
+	}
+	return false
+}
+
+// addVerb adds the custom method of path to k.
+func addVerb(k *types.Kind, path, verb string) []string {
+	name := strutil.SnakeCase(verb)
+	if err := (&types.Verb{Name: name}).Validate(); err != nil {
+		return []string{fmt.Sprintf("path %s is skipped: %v", path, err)}
+	}
+	for _, v := range k.Verbs {
+		if v == name {
+			return nil
+		}
+	}
+	k.Verbs = append(k.Verbs, name)
+	return nil
+}
+
+// addFields sets the fields of the kind of r from its schema.
+func addFields(doc *Document, r *resource, byKind map[string]*resource) (notes []string) {
+	k := r.kind
+	note := func(format string, args ...any) {
+		notes = append(notes, fmt.Sprintf("kind %s: ", k.Name)+fmt.Sprintf(format, args...))
+	}
+
+	schema, name := r.schema(doc)
+	if schema == nil {
+		note("no schema found; the kind has no fields")
+		return notes
+	}
+	props, err := doc.Properties(schema)
+	if err != nil {
+		note("schema %s is skipped: %v", name, err)
+		return notes
+	}
+
+	idColumn := k.Name + "_id"
+	seen := map[string]bool{}
+	for _, p := range props {
+		column := strutil.SnakeCase(p.Name)
+		if seen[column] {
+			continue
+		}
+		seen[column] = true
+
+		switch column {
+		case "id", idColumn, "created_at", "updated_at":
+			continue
+		case "deleted_at":
+			k.SoftDelete = true
+			continue
+		case "resource_version":
+			k.ResourceVersion = true
+			continue
+		}
+
+		prop, _, err := doc.Resolve(p.Schema)
+		if err != nil {
+			note("property %s is skipped: %v", p.Name, err)
+			continue
+		}
+
+		f := &types.Field{Name: column}
+		if target := strings.TrimSuffix(column, "_id"); target != column && prop.Type == "string" && byKind[target] != nil {
+			f.Name, f.Type = target, known.RelationBelongsTo
+		} else if f.Type = FieldType(prop); f.Type == "" {
+			note("property %s of type %q is skipped", p.Name, prop.Type)
+			continue
+		}
+		if err := f.Validate(); err != nil {
+			note("property %s is skipped: %v", p.Name, err)
+			continue
+		}
+		k.Fields = append(k.Fields, f)
+	}
+	return notes
+}
+
+// schema returns the schema of the kind of r: the response of Get, the request of Create or
+// Update, or the named schema of the document matching the kind.
+func (r *resource) schema(doc *Document) (*Schema, string) {
+	var candidates []*Schema
+	if r.item != nil {
+		candidates = append(candidates, r.item.Get.responseSchema(), r.item.Put.requestSchema(), r.item.Patch.requestSchema())
+	}
+	if r.collection != nil {
+		candidates = append(candidates, r.collection.Post.requestSchema())
+	}
+	for _, s := range candidates {
+		if resolved, name, err := doc.Resolve(s); err == nil && resolved != nil && (len(resolved.Properties) > 0 || len(resolved.AllOf) > 0) {
+			return s, name
+		}
+	}
+
+	names := make([]string, 0, len(doc.Schemas()))
+	for name := range doc.Schemas() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if strutil.SnakeCase(name) == r.kind.Name {
+			return doc.Schemas()[name], name
+		}
+	}
+	return nil, ""
+}
+
+// FieldType returns the field type of a property schema, or an empty string if it has no scalar type.
+func FieldType(s *Schema) string {
+	switch s.Type {
+	case "string":
+		switch s.Format {
+		case "date-time", "date":
+			return known.FieldTypeTime
+		case "byte", "binary":
+			return known.FieldTypeBytes
+		}
+		if s.MaxLength > 255 {
+			return known.FieldTypeText
+		}
+		return known.FieldTypeString
+	case "integer":
+		switch s.Format {
+		case "int32":
+			return known.FieldTypeInt32
+		case "uint32":
+			return known.FieldTypeUint32
+		case "uint64":
+			return known.FieldTypeUint64
+		}
+		return known.FieldTypeInt64
+	case "number":
+		if s.Format == "float" {
+			return known.FieldTypeFloat
+		}
+		return known.FieldTypeDouble
+	case "boolean":
+		return known.FieldTypeBool
+	default:
+		return ""
+	}
+}
+
+// methods returns the methods of item among the given methods.
+func methods(item *PathItem, names ...string) []string {
+	ops := map[string]*Operation{"get": item.Get, "put": item.Put, "post": item.Post, "delete": item.Delete, "patch": item.Patch}
+	var found []string
+	for _, name := range names {
+		if ops[name] != nil {
+			found = append(found, name)
+		}
+	}
+	return found
+}
@@ -0,0 +1,142 @@
+package openapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testOpenAPI = `
+openapi: 3.1.0
+paths:
+  /api/v1/authors:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: "#/components/schemas/Author"}
+  /api/v1/posts:
+    get: {}
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {$ref: "#/components/schemas/Post"}
+  /api/v1/posts/{postID}:
+    get:
+      responses:
+        200:
+          content:
+            application/json:
+              schema: {$ref: "#/components/schemas/Post"}
+    patch: {}
+    delete: {}
+    post: {}
+  /api/v1/posts/{postID}:mark-read:
+    post: {}
+  /api/v1/posts/{postID}/restore:
+    post: {}
+  /api/v1/posts/{postID}/comments/{commentID}:
+    get: {}
+  /healthz:
+    get: {}
+components:
+  schemas:
+    Author:
+      type: object
+      properties:
+        name: {type: string}
+    Base:
+      type: object
+      properties:
+        id: {type: integer}
+        postID: {type: string}
+        createdAt: {type: string, format: date-time}
+        updatedAt: {type: string, format: date-time}
+        deletedAt: {type: [string, "null"], format: date-time}
+    Post:
+      allOf:
+        - $ref: "#/components/schemas/Base"
+        - type: object
+          properties:
+            title: {type: string}
+            body: {type: string, maxLength: 65535}
+            views: {type: integer, format: int32}
+            rating: {type: number}
+            published: {type: boolean}
+            publishedAt: {type: string, format: date-time}
+            cover: {type: string, format: byte}
+            authorId: {type: string}
+            tags: {type: array, items: {type: string}}
+            meta: {$ref: "#/components/schemas/Missing"}
+`
+
+const testSwagger = `
+swagger: "2.0"
+paths:
+  /tags:
+    get: {}
+    post:
+      parameters:
+        - in: body
+          schema: {$ref: "#/definitions/Tag"}
+  /tags/{id}:
+    get: {}
+definitions:
+  Tag:
+    type: object
+    properties:
+      label: {type: string}
+      resourceVersion: {type: integer}
+`
+
+func load(t *testing.T, content string) *Document {
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	doc, err := Load(path)
+	require.NoError(t, err)
+	return doc
+}
+
+func TestKinds(t *testing.T) {
+	kinds, notes := Kinds(load(t, testOpenAPI))
+	require.Len(t, kinds, 2)
+
+	author := kinds[0]
+	assert.Equal(t, "author", author.Name)
+	assert.Equal(t, "name:string", author.FieldSpec())
+
+	post := kinds[1]
+	assert.Equal(t, "post", post.Name)
+	assert.True(t, post.SoftDelete)
+	assert.False(t, post.ResourceVersion)
+	assert.Equal(t, []string{"mark_read"}, post.Verbs)
+	assert.Equal(t, "title:string,body:text,views:int32,rating:double,published:bool,published_at:time,cover:bytes,author:belongs_to", post.FieldSpec())
+
+	assert.Equal(t, []string{
+		"operation POST /api/v1/posts/{postID} does not fit the resource model and is skipped",
+		"path /api/v1/posts/{postID}/comments/{commentID} does not fit the resource model and is skipped",
+		"path /healthz does not fit the resource model and is skipped",
+		`kind post: property tags of type "array" is skipped`,
+		`kind post: property meta is skipped: $ref "#/components/schemas/Missing": schema not found`,
+	}, notes)
+}
+
+func TestKindsSwagger(t *testing.T) {
+	kinds, notes := Kinds(load(t, testSwagger))
+	require.Len(t, kinds, 1)
+	assert.Equal(t, "tag", kinds[0].Name)
+	assert.True(t, kinds[0].ResourceVersion)
+	assert.Equal(t, "label:string", kinds[0].FieldSpec())
+	assert.Empty(t, notes)
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("openapi: 2.0\n"), 0o644))
+	_, err := Load(path)
+	assert.ErrorContains(t, err, "neither an OpenAPI 3 nor a Swagger 2 document")
+}
@@ -0,0 +1,193 @@
+package protofile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/duke-git/lancet/v2/strutil"
+	"github.com/gobuffalo/flect"
+
+	"github.com/onexstack/osbuilder/internal/osbuilder/known"
+	"github.com/onexstack/osbuilder/internal/osbuilder/types"
+)
+
+// standardMethods are the prefixes of the methods that the generated layers implement for every kind.
+var standardMethods = []string{"Create", "Update", "Delete", "Get", "List", "Restore", "Purge"}
+
+// scalarTypes maps the proto types of fields to field types.
+var scalarTypes = map[string]string{
+	"string":                    known.FieldTypeString,
+	"bool":                      known.FieldTypeBool,
+	"bytes":                     known.FieldTypeBytes,
+	"int32":                     known.FieldTypeInt32,
+	"sint32":                    known.FieldTypeInt32,
+	"sfixed32":                  known.FieldTypeInt32,
+	"int64":                     known.FieldTypeInt64,
+	"sint64":                    known.FieldTypeInt64,
+	"sfixed64":                  known.FieldTypeInt64,
+	"uint32":                    known.FieldTypeUint32,
+	"fixed32":                   known.FieldTypeUint32,
+	"uint64":                    known.FieldTypeUint64,
+	"fixed64":                   known.FieldTypeUint64,
+	"float":                     known.FieldTypeFloat,
+	"double":                    known.FieldTypeDouble,
+	"google.protobuf.Timestamp": known.FieldTypeTime,
+}
+
+// Kinds maps the resources of a proto file to kinds. A resource is a message <Kind> with a
+// Create<Kind> method in the services of the file or, if the file has no service, with a
+// Create<Kind>Request message. The other <Verb><Kind> methods (or <Verb><Kind>Request and
+// <Verb><Kind>Response messages) become custom methods.
+//
+// The fields of <Kind> become the fields of the kind, except <kind>ID, createdAt and updatedAt,
+// which every generated model has, and deletedAt and resourceVersion, which enable soft delete
+// and resource versions. A string field <other>ID, where <other> is another resource of the
+// file, becomes a belongs_to relation. Other string fields are text fields unless
+// List<Kind>Request filters by them, and a pageToken field in List<Kind>Request selects cursor
+// pagination. Fields and methods that cannot be mapped are skipped, and notes explains why.
+func Kinds(f *File) (kinds []*types.Kind, notes []string) {
+	methods := f.methods(&notes)
+
+	var resources []string
+	for _, m := range f.Messages {
+		for _, method := range methods {
+			if method == "Create"+m.Name {
+				resources = append(resources, m.Name)
+				break
+			}
+		}
+	}
+
+	byResource := map[string]*types.Kind{}
+	for _, name := range resources {
+		byResource[name] = &types.Kind{Name: strutil.SnakeCase(name)}
+	}
+
+	standard := map[string]bool{}
+	for _, name := range resources {
+		for _, prefix := range standardMethods {
+			standard[prefix+name] = true
+		}
+		standard["Delete"+flect.Pluralize(name)] = true
+	}
+
+	for _, method := range methods {
+		if standard[method] {
+			continue
+		}
+		resource := ""
+		for _, name := range resources {
+			if strings.HasSuffix(method, name) && len(method) > len(name) && len(name) > len(resource) {
+				resource = name
+			}
+		}
+		if resource == "" {
+			notes = append(notes, fmt.Sprintf("method %s does not belong to a resource and is skipped", method))
+			continue
+		}
+
+		k := byResource[resource]
+		name := strutil.SnakeCase(strings.TrimSuffix(method, resource))
+		if err := (&types.Verb{Name: name}).Validate(); err != nil {
+			notes = append(notes, fmt.Sprintf("method %s is skipped: %v", method, err))
+			continue
+		}
+		k.Verbs = append(k.Verbs, name)
+	}
+
+	for _, name := range resources {
+		k := byResource[name]
+		notes = append(notes, f.addFields(k, name, byResource)...)
+		if list, ok := f.Message("List" + name + "Request"); ok {
+			if _, ok := list.Field("pageToken"); ok {
+				k.Pagination = known.PaginationCursor
+			}
+		}
+		kinds = append(kinds, k)
+	}
+	return kinds, notes
+}
+
+// methods returns the names of the methods of the file: the unary methods of its services or,
+// if the file has no service, the <Method>Request messages with a <Method>Response message.
+func (f *File) methods(notes *[]string) []string {
+	var methods []string
+	for _, s := range f.Services {
+		for _, rpc := range s.RPCs {
+			if rpc.Streaming {
+				*notes = append(*notes, fmt.Sprintf("method %s streams messages and is skipped", rpc.Name))
+				continue
+			}
+			methods = append(methods, rpc.Name)
+		}
+	}
+	if len(f.Services) > 0 {
+		return methods
+	}
+
+	for _, m := range f.Messages {
+		method, ok := strings.CutSuffix(m.Name, "Request")
+		if !ok || method == "" {
+			continue
+		}
+		if _, ok := f.Message(method + "Response"); ok {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
+
+// addFields sets the fields of k from the resource message.
+func (f *File) addFields(k *types.Kind, resource string, byResource map[string]*types.Kind) (notes []string) {
+	note := func(format string, args ...any) {
+		notes = append(notes, fmt.Sprintf("message %s: ", resource)+fmt.Sprintf(format, args...))
+	}
+
+	m, _ := f.Message(resource)
+	list, hasList := f.Message("List" + resource + "Request")
+	filters := func(name string) bool {
+		if !hasList {
+			return false
+		}
+		_, ok := list.Field(name)
+		return ok
+	}
+	idField := strutil.LowerFirst(resource) + "ID"
+	for _, field := range m.Fields {
+		switch field.Name {
+		case idField, "createdAt", "updatedAt":
+			continue
+		case "deletedAt":
+			k.SoftDelete = true
+			continue
+		case "resourceVersion":
+			k.ResourceVersion = true
+			continue
+		}
+
+		typ, ok := scalarTypes[field.Type]
+		if !ok || field.Label == "repeated" || field.Label == "oneof" {
+			note("field %s of type `%s` is skipped", field.Name, strings.TrimSpace(field.Label+" "+field.Type))
+			continue
+		}
+
+		spec := &types.Field{Name: strutil.SnakeCase(field.Name), Type: typ}
+		if typ == known.FieldTypeString {
+			target, isID := strings.CutSuffix(field.Name, "ID")
+			_, isResource := byResource[strutil.UpperFirst(target)]
+			switch {
+			case isID && isResource:
+				spec.Name, spec.Type = strutil.SnakeCase(target), known.RelationBelongsTo
+			case !filters(field.Name):
+				// List filters by string fields, but not by text fields.
+				spec.Type = known.FieldTypeText
+			}
+		}
+		if err := spec.Validate(); err != nil {
+			note("field %s is skipped: %v", field.Name, err)
+			continue
+		}
+		k.Fields = append(k.Fields, spec)
+	}
+	return notes
+}
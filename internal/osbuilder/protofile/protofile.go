@@ -0,0 +1,195 @@
+// Package protofile reads the messages and services of an existing proto file for `create api
+// --from-proto`, and compares them with the messages that the generated layers expect.
+package protofile
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/emicklei/proto"
+)
+
+// File holds the top-level messages and the services of a proto file.
+type File struct {
+	// Package is the proto package (e.g., "apiserver.v1").
+	Package  string
+	Messages []*Message
+	Services []*Service
+}
+
+// Message is a top-level message. Nested messages and enums are not recorded.
+type Message struct {
+	Name   string
+	Fields []*Field
+}
+
+// Field is a field of a message.
+type Field struct {
+	Name string
+	// Type is the type of the field, without the package of the file (e.g., "string",
+	// "google.protobuf.Timestamp", "Post" or "map<string,string>").
+	Type string
+	// Label is one of "optional", "repeated", "oneof" or empty.
+	Label string
+}
+
+// Service is a service with its methods.
+type Service struct {
+	Name string
+	RPCs []*RPC
+}
+
+// RPC is a method of a service.
+type RPC struct {
+	Name     string
+	Request  string
+	Response string
+	// Streaming marks methods that stream their requests or responses.
+	Streaming bool
+}
+
+// Load parses the proto file at path.
+func Load(path string) (*File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	file, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return file, nil
+}
+
+// Parse parses a proto file.
+func Parse(r io.Reader) (*File, error) {
+	def, err := proto.NewParser(r).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	f := &File{}
+	for _, e := range def.Elements {
+		if p, ok := e.(*proto.Package); ok {
+			f.Package = p.Name
+		}
+	}
+	for _, e := range def.Elements {
+		switch e := e.(type) {
+		case *proto.Message:
+			f.Messages = append(f.Messages, f.message(e))
+		case *proto.Service:
+			s := &Service{Name: e.Name}
+			for _, se := range e.Elements {
+				if rpc, ok := se.(*proto.RPC); ok {
+					s.RPCs = append(s.RPCs, &RPC{
+						Name:      rpc.Name,
+						Request:   f.typeName(rpc.RequestType),
+						Response:  f.typeName(rpc.ReturnsType),
+						Streaming: rpc.StreamsRequest || rpc.StreamsReturns,
+					})
+				}
+			}
+			f.Services = append(f.Services, s)
+		}
+	}
+	return f, nil
+}
+
+func (f *File) message(m *proto.Message) *Message {
+	msg := &Message{Name: m.Name}
+	for _, e := range m.Elements {
+		switch e := e.(type) {
+		case *proto.NormalField:
+			field := &Field{Name: e.Name, Type: f.typeName(e.Type)}
+			switch {
+			case e.Repeated:
+				field.Label = "repeated"
+			case e.Optional:
+				field.Label = "optional"
+			}
+			msg.Fields = append(msg.Fields, field)
+		case *proto.MapField:
+			msg.Fields = append(msg.Fields, &Field{Name: e.Name, Type: fmt.Sprintf("map<%s,%s>", e.KeyType, f.typeName(e.Type))})
+		case *proto.Oneof:
+			for _, oe := range e.Elements {
+				if of, ok := oe.(*proto.OneOfField); ok {
+					msg.Fields = append(msg.Fields, &Field{Name: of.Name, Type: f.typeName(of.Type), Label: "oneof"})
+				}
+			}
+		}
+	}
+	return msg
+}
+
+// typeName returns the type name without the leading dot and the package of the file.
+func (f *File) typeName(name string) string {
+	name = strings.TrimPrefix(name, ".")
+	if f.Package != "" {
+		name = strings.TrimPrefix(name, f.Package+".")
+	}
+	return name
+}
+
+// Message returns the top-level message with the given name.
+func (f *File) Message(name string) (*Message, bool) {
+	for _, m := range f.Messages {
+		if m.Name == name {
+			return m, true
+		}
+	}
+	return nil, false
+}
+
+// Field returns the field with the given name.
+func (m *Message) Field(name string) (*Field, bool) {
+	for _, field := range m.Fields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return nil, false
+}
+
+// String returns the field as declared in a proto file, without its number (e.g., "optional string title").
+func (f *Field) String() string {
+	if f.Label == "" {
+		return f.Type + " " + f.Name
+	}
+	return f.Label + " " + f.Type + " " + f.Name
+}
+
+// Check lists the request and response messages of rpcs that got lacks, and the fields of the
+// messages of want that got lacks or declares differently. Messages of want that got lacks are
+// only reported through the rpcs that use them, and fields that are only in got are ignored.
+func Check(want, got *File, rpcs []*RPC) []string {
+	var problems []string
+	for _, rpc := range rpcs {
+		for _, name := range []string{rpc.Request, rpc.Response} {
+			if _, ok := got.Message(name); !ok {
+				problems = append(problems, fmt.Sprintf("rpc %s: message %s is missing", rpc.Name, name))
+			}
+		}
+	}
+
+	for _, wm := range want.Messages {
+		gm, ok := got.Message(wm.Name)
+		if !ok {
+			continue
+		}
+		for _, wf := range wm.Fields {
+			gf, ok := gm.Field(wf.Name)
+			switch {
+			case !ok:
+				problems = append(problems, fmt.Sprintf("field %s.%s is missing, want `%s`", wm.Name, wf.Name, wf))
+			case gf.Type != wf.Type || gf.Label != wf.Label:
+				problems = append(problems, fmt.Sprintf("field %s.%s is `%s`, want `%s`", wm.Name, wf.Name, gf, wf))
+			}
+		}
+	}
+	return problems
+}
@@ -0,0 +1,147 @@
+package protofile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProto = `
+syntax = "proto3";
+
+package apiserver.v1;
+
+import "google/protobuf/timestamp.proto";
+
+service BlogService {
+    rpc CreateAuthor(CreateAuthorRequest) returns (CreateAuthorResponse);
+    rpc CreatePost(CreatePostRequest) returns (CreatePostResponse);
+    rpc DeletePosts(DeletePostsRequest) returns (DeletePostsResponse);
+    rpc ListPost(ListPostRequest) returns (ListPostResponse);
+    rpc PublishPost(PublishPostRequest) returns (PublishPostResponse);
+    rpc WatchPost(WatchPostRequest) returns (stream Post);
+    rpc Healthz(HealthzRequest) returns (HealthzResponse);
+}
+
+message Author {
+    string authorID = 1;
+    string name = 2;
+}
+
+message Post {
+    string postID = 1;
+    google.protobuf.Timestamp createdAt = 2;
+    google.protobuf.Timestamp updatedAt = 3;
+    string title = 4;
+    int32 views = 5;
+    google.protobuf.Timestamp publishedAt = 6;
+    string authorID = 7;
+    repeated string tags = 8;
+    map<string, string> labels = 9;
+    .apiserver.v1.Author editor = 10;
+    oneof cover {
+        string coverURL = 11;
+    }
+    google.protobuf.Timestamp deletedAt = 12;
+}
+
+message ListPostRequest {
+    string pageToken = 1;
+    optional string title = 2;
+}
+`
+
+func TestParse(t *testing.T) {
+	f, err := Parse(strings.NewReader(testProto))
+	require.NoError(t, err)
+	assert.Equal(t, "apiserver.v1", f.Package)
+	require.Len(t, f.Services, 1)
+	assert.Equal(t, &RPC{Name: "WatchPost", Request: "WatchPostRequest", Response: "Post", Streaming: true}, f.Services[0].RPCs[5])
+
+	post, ok := f.Message("Post")
+	require.True(t, ok)
+	var fields []string
+	for _, field := range post.Fields[7:] {
+		fields = append(fields, field.String())
+	}
+	assert.Equal(t, []string{
+		"repeated string tags", "map<string,string> labels", "Author editor", "oneof string coverURL",
+		"google.protobuf.Timestamp deletedAt",
+	}, fields)
+
+	list, _ := f.Message("ListPostRequest")
+	title, ok := list.Field("title")
+	require.True(t, ok)
+	assert.Equal(t, "optional string title", title.String())
+}
+
+func TestKinds(t *testing.T) {
+	f, err := Parse(strings.NewReader(testProto))
+	require.NoError(t, err)
+
+	kinds, notes := Kinds(f)
+	require.Len(t, kinds, 2)
+	assert.Equal(t, "author", kinds[0].Name)
+	assert.Equal(t, "name:text", kinds[0].FieldSpec())
+
+	post := kinds[1]
+	assert.Equal(t, "post", post.Name)
+	assert.Equal(t, []string{"publish"}, post.Verbs)
+	assert.Equal(t, "cursor", post.Pagination)
+	assert.True(t, post.SoftDelete)
+	assert.Equal(t, "title:string,views:int32,published_at:time,author:belongs_to", post.FieldSpec())
+
+	assert.Equal(t, []string{
+		"method WatchPost streams messages and is skipped",
+		"method Healthz does not belong to a resource and is skipped",
+		"message Post: field tags of type `repeated string` is skipped",
+		"message Post: field labels of type `map<string,string>` is skipped",
+		"message Post: field editor of type `Author` is skipped",
+		"message Post: field coverURL of type `oneof string` is skipped",
+	}, notes)
+}
+
+func TestKindsWithoutService(t *testing.T) {
+	f, err := Parse(strings.NewReader(`
+syntax = "proto3";
+message Tag { string tagID = 1; string label = 2; }
+message CreateTagRequest { string label = 1; }
+message CreateTagResponse { string tagID = 1; }
+message MergeTagRequest { string tagID = 1; }
+message MergeTagResponse {}
+`))
+	require.NoError(t, err)
+
+	kinds, notes := Kinds(f)
+	require.Len(t, kinds, 1)
+	assert.Equal(t, "tag", kinds[0].Name)
+	assert.Equal(t, []string{"merge"}, kinds[0].Verbs)
+	assert.Equal(t, "label:text", kinds[0].FieldSpec())
+	assert.Empty(t, notes)
+}
+
+func TestCheck(t *testing.T) {
+	want, err := Parse(strings.NewReader(`
+syntax = "proto3";
+message Post { string postID = 1; optional string title = 2; int64 views = 3; }
+message GetPostRequest { string postID = 1; }
+message GetPostResponse { Post post = 1; }
+`))
+	require.NoError(t, err)
+	got, err := Parse(strings.NewReader(`
+syntax = "proto3";
+message Post { string postID = 1; string title = 2; string extra = 3; }
+message GetPostResponse { Post post = 1; }
+`))
+	require.NoError(t, err)
+
+	rpcs := []*RPC{{Name: "GetPost", Request: "GetPostRequest", Response: "GetPostResponse"}}
+	assert.Equal(t, []string{
+		"rpc GetPost: message GetPostRequest is missing",
+		"field Post.title is `string title`, want `optional string title`",
+		"field Post.views is missing, want `int64 views`",
+	}, Check(want, got, rpcs))
+	assert.Empty(t, Check(want, want, rpcs))
+}
@@ -1,190 +1,223 @@
+// Command gen-gorm-model regenerates the typed gorm/gen query objects of the kinds of every
+// component, together with the models of the other tables of the component, from the database
+// of the component's configuration. Run it from the project root:
+//
+//	go run ./cmd/gen-gorm-model --component apiserver
+//
+// It is regenerated by `osbuilder create api`, so that it knows every kind of the project.
 package main
 
 import (
 	"fmt"
 	"log"
-	"path/filepath"
+	"strings"
 
-	"github.com/onexstack/onexstack/pkg/db"
 	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	{{- if hasGoZero .WebServers}}
+	"github.com/zeromicro/go-zero/core/conf"
+	{{- end}}
 	"gorm.io/gen"
 	"gorm.io/gen/field"
 	"gorm.io/gorm"
+	{{- range .WebServers}}
+
+	{{- if eq .WebFramework "go-zero"}}
+	{{.Name}}config "{{$.D.ModuleName}}/internal/{{.Name}}/config"
+	{{- else}}
+	{{.Name}}options "{{$.D.ModuleName}}/cmd/{{.BinaryName}}/app/options"
+	{{- end}}
+	{{- if .Models}}
+	{{.Name}}model "{{$.D.ModuleName}}/internal/{{.Name}}/model"
+	{{- end}}
+	{{- if eq .WebFramework "go-zero"}}
+	{{.Name}}svc "{{$.D.ModuleName}}/internal/{{.Name}}/svc"
+	{{- end}}
+	{{- end}}
 )
 
-// 帮助信息文本.
-const helpText = `Usage: main [flags] arg [arg...]
-
-This is a pflag example.
-
-Flags:
-`
-
-// Querier 定义了数据库查询接口.
-type Querier interface {
-	// FilterWithNameAndRole 按名称和角色查询记录
-	FilterWithNameAndRole(name string) ([]gen.T, error)
-}
-
-// GenerateConfig 保存代码生成的配置.
-type GenerateConfig struct {
-	ModelPackagePath string
-	GenerateFunc     func(g *gen.Generator)
+// component describes where the models and query objects of a component live and how to open
+// its database.
+type component struct {
+	// ConfigFile is the default configuration file of the component, which holds its database options.
+	ConfigFile string
+	// ModelDir is the package directory of the models of the component.
+	ModelDir string
+	// QueryDir is the package directory of the generated query objects.
+	QueryDir string
+	// TablePrefix is the prefix of the tables of the component, e.g., "mb_".
+	TablePrefix string
+	// Models are the models of the kinds generated by osbuilder.
+	Models []any
+	// OpenDB opens the database described by a configuration file.
+	OpenDB func(configFile string) (*gorm.DB, error)
 }
 
-// 预定义的生成配置.
-var generateConfigs = map[string]GenerateConfig{
-	"mb": {ModelPackagePath: "../../internal/apiserver/model", GenerateFunc: GenerateMiniBlogModels},
+// components lists the components of the project by name.
+var components = map[string]*component{
+	{{- range .WebServers}}
+	"{{.Name}}": {
+		ConfigFile:  "configs/{{.BinaryName}}.yaml",
+		ModelDir:    "internal/{{.Name}}/model",
+		QueryDir:    "internal/{{.Name}}/store/query",
+		TablePrefix: "{{extractProjectPrefix .BinaryName}}_",
+		Models: []any{
+			{{- $name := .Name}}
+			{{- range .Models}}
+			&{{$name}}model.{{.}}{},
+			{{- end}}
+		},
+		OpenDB: func(configFile string) (*gorm.DB, error) {
+			{{- if eq .WebFramework "go-zero"}}
+			var c {{.Name}}config.Config
+			if err := conf.Load(configFile, &c); err != nil {
+				return nil, err
+			}
+			return {{.Name}}svc.OpenDB(c)
+			{{- else}}
+			opts := {{.Name}}options.NewServerOptions()
+			if err := loadConfig(configFile, opts); err != nil {
+				return nil, err
+			}
+			if err := opts.Complete(); err != nil {
+				return nil, err
+			}
+			cfg, err := opts.Config()
+			if err != nil {
+				return nil, err
+			}
+			return cfg.OpenDB()
+			{{- end}}
+		},
+	},
+	{{- end}}
 }
 
-// 命令行参数.
+// Command-line flags.
 var (
-	addr       = pflag.StringP("addr", "a", "127.0.0.1:3306", "MySQL host address.")
-	username   = pflag.StringP("username", "u", "miniblog", "Username to connect to the database.")
-	password   = pflag.StringP("password", "p", "miniblog1234", "Password to use when connecting to the database.")
-	database   = pflag.StringP("db", "d", "miniblog", .Dbase name to connect to.")
-	modelPath  = pflag.String("model-pkg-path", "", "Generated model code's package name.")
-	components = pflag.StringSlice("component", []string{"mb"}, "Generated model code's for specified component.")
-	help       = pflag.BoolP("help", "h", false, "Show this help message.")
+	names      = pflag.StringSlice("component", nil, "Components to generate the query objects of. Defaults to all components.")
+	configFile = pflag.StringP("config", "c", "", "Configuration file of the component, which holds its database options. "+
+		"Defaults to configs/<binary>.yaml; requires a single --component.")
+	help = pflag.BoolP("help", "h", false, "Show this help message.")
 )
 
 func main() {
-	// 设置自定义的使用说明函数
 	pflag.Usage = func() {
-		fmt.Printf("%s", helpText)
+		fmt.Printf("Usage: go run ./cmd/gen-gorm-model [flags]\n\nFlags:\n")
 		pflag.PrintDefaults()
 	}
 	pflag.Parse()
-
-	// 如果设置了帮助标志，则显示帮助信息并退出
 	if *help {
 		pflag.Usage()
 		return
 	}
 
-	// 初始化数据库连接
-	dbInstance, err := initializ.Dbase()
-	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+	selected := *names
+	if len(selected) == 0 {
+		for name := range components {
+			selected = append(selected, name)
+		}
 	}
-
-	// 处理组件并生成代码
-	for _, component := range *components {
-		processComponent(component, dbInstance)
+	if *configFile != "" && len(selected) != 1 {
+		log.Fatalf("--config requires a single --component")
 	}
-}
 
-// initializ.Dbase 创建并返回一个数据库连接.
-func initializ.Dbase() (*gorm.DB, error) {
-	dbOptions := &db.MySQLOptions{
-		Addr:     *addr,
-		Username: *username,
-		Password: *password,
-	.Dbase: *database,
+	for _, name := range selected {
+		c, ok := components[name]
+		if !ok {
+			log.Fatalf("unknown component %q", name)
+		}
+		if err := generate(c); err != nil {
+			log.Fatalf("component %s: %v", name, err)
+		}
 	}
-
-	// 创建并返回数据库连接
-	return db.NewMySQL(dbOptions)
 }
 
-// processComponent 处理单个组件以生成代码.
-func processComponent(component string, dbInstance *gorm.DB) {
-	config, ok := generateConfigs[component]
-	if !ok {
-		log.Printf("Component '%s' not found in configuration. Skipping.", component)
-		return
+// loadConfig reads a configuration file into the options of a component, as the component does.
+func loadConfig(configFile string, opts any) error {
+	v := viper.New()
+	v.SetConfigFile(configFile)
+	if err := v.ReadInConfig(); err != nil {
+		return err
 	}
-
-	// 解析模型包路径
-	modelPkgPath := resolveModelPackagePath(config.ModelPackagePath)
-
-	// 创建生成器实例
-	generator := createGenerator(modelPkgPath)
-	generator.UseDB(dbInstance)
-
-	// 应用自定义生成器选项
-	applyGeneratorOptions(generator)
-
-	// 使用指定的函数生成模型
-	config.GenerateFunc(generator)
-
-	// 执行代码生成
-	generator.Execute()
+	return v.Unmarshal(opts)
 }
 
-// resolveModelPackagePath 确定模型生成的包路径.
-func resolveModelPackagePath(defaultPath string) string {
-	if *modelPath != "" {
-		return *modelPath
+// generate regenerates the query objects of a component, and the models of the tables of the
+// component that are not the table of a kind.
+func generate(c *component) error {
+	file := c.ConfigFile
+	if *configFile != "" {
+		file = *configFile
 	}
-	absPath, err := filepath.Abs(defaultPath)
+	db, err := c.OpenDB(file)
 	if err != nil {
-		log.Printf("Error resolving path: %v", err)
-		return defaultPath
+		return fmt.Errorf("open the database of %s: %w", file, err)
 	}
-	return absPath
-}
 
-// createGenerator 初始化并返回一个新的生成器实例.
-func createGenerator(packagePath string) *gen.Generator {
-	return gen.NewGenerator(gen.Config{
-		Mode:              gen.WithDefaultQuery | gen.WithQueryInterface | gen.WithoutContext,
-		ModelPkgPath:      packagePath,
-		WithUnitTest:      true,
-		FieldNullable:     true,  // 对于数据库中可空的字段，使用指针类型。
-		FieldSignable:     false, // 禁用无符号属性以提高兼容性。
-		FieldWithIndexTag: false, // 不包含 GORM 的索引标签。
-		FieldWithTypeTag:  false, // 不包含 GORM 的类型标签。
+	g := gen.NewGenerator(gen.Config{
+		Mode:              gen.WithDefaultQuery | gen.WithQueryInterface,
+		OutPath:           c.QueryDir,
+		ModelPkgPath:      c.ModelDir,
+		FieldNullable:     true,  // Use pointers for nullable columns.
+		FieldSignable:     false, // Disable unsigned types for compatibility.
+		FieldWithIndexTag: false, // Do not include GORM index tags.
+		FieldWithTypeTag:  false, // Do not include GORM type tags.
 	})
-}
-
-// applyGeneratorOptions 设置自定义生成器选项.
-func applyGeneratorOptions(g *gen.Generator) {
-	// 为特定字段自定义 GORM 标签
+	g.UseDB(db)
 	g.WithOpts(
-		gen.FieldGORMTag("createdAt", func(tag field.GormTag) field.GormTag {
+		gen.FieldGORMTag("created_at", func(tag field.GormTag) field.GormTag {
 			tag.Set("default", "current_timestamp")
 			return tag
 		}),
-		gen.FieldGORMTag("updatedAt", func(tag field.GormTag) field.GormTag {
+		gen.FieldGORMTag("updated_at", func(tag field.GormTag) field.GormTag {
 			tag.Set("default", "current_timestamp")
 			return tag
 		}),
 	)
+
+	// Components may share a database and a table prefix, so the tables of the kinds of every
+	// component are skipped.
+	known := map[string]bool{"schema_migrations": true}
+	for _, other := range components {
+		for _, m := range other.Models {
+			if t, ok := m.(interface{ TableName() string }); ok {
+				known[t.TableName()] = true
+			}
+		}
+	}
+	tables, err := db.Migrator().GetTables()
+	if err != nil {
+		return err
+	}
+
+	models := append([]any{}, c.Models...)
+	for _, table := range tables {
+		if known[table] || !strings.HasPrefix(table, c.TablePrefix) {
+			continue
+		}
+		name := modelName(strings.TrimPrefix(table, c.TablePrefix))
+		log.Printf("generating model %s for table %s", name, table)
+		models = append(models, g.GenerateModelAs(table, name))
+	}
+	if len(models) == 0 {
+		log.Printf("no kinds or tables found in %s", c.ModelDir)
+		return nil
+	}
+
+	g.ApplyBasic(models...)
+	g.Execute()
+	return nil
 }
 
-// GenerateMiniBlogModels 为 miniblog 组件生成模型.
-func GenerateMiniBlogModels(g *gen.Generator) {
-	g.GenerateModelAs(
-		"user",
-		"UserM",
-		gen.FieldIgnore("placeholder"),
-		gen.FieldGORMTag("username", func(tag field.GormTag) field.GormTag {
-			tag.Set("uniqueIndex", "idx_user_username")
-			return tag
-		}),
-		gen.FieldGORMTag("userID", func(tag field.GormTag) field.GormTag {
-			tag.Set("uniqueIndex", "idx_user_userID")
-			return tag
-		}),
-		gen.FieldGORMTag("phone", func(tag field.GormTag) field.GormTag {
-			tag.Set("uniqueIndex", "idx_user_phone")
-			return tag
-		}),
-	)
-	g.GenerateModelAs(
-		"post",
-		"PostM",
-		gen.FieldIgnore("placeholder"),
-		gen.FieldGORMTag("postID", func(tag field.GormTag) field.GormTag {
-			tag.Set("uniqueIndex", "idx_post_postID")
-			return tag
-		}),
-	)
-	g.GenerateModelAs(
-		"casbin_rule",
-		"CasbinRuleM",
-		gen.FieldRename("ptype", "PType"),
-		gen.FieldIgnore("placeholder"),
-	)
+// modelName returns the name of the model of a table without its prefix, e.g., "CasbinRuleM"
+// for "casbin_rule".
+func modelName(table string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(table, "_") {
+		if part != "" {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String() + "M"
 }
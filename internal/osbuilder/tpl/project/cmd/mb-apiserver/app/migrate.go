@@ -0,0 +1,168 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	{{- if .Web.Datasources}}
+	"path/filepath"
+	{{- end}}
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	{{- if .Web.Datasources}}
+	"gorm.io/gorm"
+	{{- end}}
+
+	"{{.D.ModuleName}}/cmd/{{.Web.BinaryName}}/app/options"
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/migrations"
+	"{{.D.ModuleName}}/internal/pkg/migrate"
+)
+{{- if .Web.Datasources}}
+
+// datasource is the named datasource whose migrations are applied, reverted or printed instead of
+// those of the primary database.
+var datasource string
+{{- end}}
+
+// newMigrateCommand creates the migrate command, which applies and reverts the versioned schema
+// migrations of {{.Web.BinaryName}} using the database of its configuration.
+func newMigrateCommand(opts *options.ServerOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "migrate",
+		Short:        "Apply and revert the database schema migrations",
+		SilenceUsage: true,
+	}
+	{{- if .Web.Datasources}}
+	cmd.PersistentFlags().StringVar(&datasource, "datasource", "", "Named datasource to migrate instead of the primary database.")
+	{{- end}}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "up [N]",
+		Short: "Apply the next N pending migrations, or all of them",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMigrator(cmd.Context(), opts, args, func(ctx context.Context, mg *migrate.Migrator, n int) error {
+				done, err := mg.Up(ctx, n)
+				for _, m := range done {
+					fmt.Printf("applied %s\n", m)
+				}
+				return err
+			})
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "down [N]",
+		Short: "Revert the last N applied migrations, or the last one",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMigrator(cmd.Context(), opts, args, func(ctx context.Context, mg *migrate.Migrator, n int) error {
+				done, err := mg.Down(ctx, n)
+				for _, m := range done {
+					fmt.Printf("reverted %s\n", m)
+				}
+				return err
+			})
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: "Print the migrations and when they were applied",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMigrator(cmd.Context(), opts, nil, func(ctx context.Context, mg *migrate.Migrator, _ int) error {
+				statuses, err := mg.Status(ctx)
+				if err != nil {
+					return err
+				}
+				w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+				fmt.Fprintln(w, "MIGRATION\tAPPLIED AT")
+				for _, s := range statuses {
+					appliedAt := s.AppliedAt
+					if appliedAt == "" {
+						appliedAt = "pending"
+					}
+					fmt.Fprintf(w, "%s\t%s\n", s.Migration, appliedAt)
+				}
+				return w.Flush()
+			})
+		},
+	})
+
+	var dir string
+	create := &cobra.Command{
+		Use:   "create NAME",
+		Short: "Create empty up and down migrations for every SQL dialect",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			{{- if .Web.Datasources}}
+			// The migrations of a datasource are in the directory of the datasource.
+			if datasource != "" && !cmd.Flags().Changed("dir") {
+				dir = filepath.Join(dir, "datasources", datasource)
+			}
+			{{- end}}
+			paths, err := migrate.Create(dir, args[0])
+			for _, path := range paths {
+				fmt.Printf("created %s\n", path)
+			}
+			return err
+		},
+	}
+	create.Flags().StringVar(&dir, "dir", "internal/{{.Web.Name}}/migrations", "Directory of the migrations, with one subdirectory per SQL dialect.")
+	cmd.AddCommand(create)
+
+	return cmd
+}
+
+// runMigrator loads the configuration, opens the database without migrating it and runs fn
+// with the migrator of its dialect and the optional count argument.
+func runMigrator(ctx context.Context, opts *options.ServerOptions, args []string, fn func(context.Context, *migrate.Migrator, int) error) error {
+	n := 0
+	if len(args) > 0 {
+		var err error
+		if n, err = strconv.Atoi(args[0]); err != nil || n <= 0 {
+			return fmt.Errorf("invalid number of migrations %q", args[0])
+		}
+	}
+
+	// Unmarshal, complete and validate the configuration as the server does.
+	if err := viper.Unmarshal(opts); err != nil {
+		return fmt.Errorf("failed to unmarshal configuration: %w", err)
+	}
+	if err := opts.Complete(); err != nil {
+		return fmt.Errorf("failed to complete options: %w", err)
+	}
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("invalid options: %w", err)
+	}
+	cfg, err := opts.Config()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	{{- if .Web.Datasources}}
+
+	open, newMigrator := cfg.OpenDB, migrations.NewMigrator
+	if datasource != "" {
+		open = func() (*gorm.DB, error) { return cfg.OpenDatasource(datasource) }
+		newMigrator = func(db *gorm.DB) (*migrate.Migrator, error) { return migrations.NewDatasourceMigrator(db, datasource) }
+	}
+	db, err := open()
+	{{- else}}
+
+	db, err := cfg.OpenDB()
+	newMigrator := migrations.NewMigrator
+	{{- end}}
+	if err != nil {
+		return err
+	}
+	mg, err := newMigrator(db)
+	if err != nil {
+		return err
+	}
+	return fn(ctx, mg, n)
+}
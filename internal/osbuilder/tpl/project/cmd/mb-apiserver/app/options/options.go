@@ -2,10 +2,8 @@
 package options
 
 import (
-	{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "grpc-gateway")}}
-	{{- if .Web.WithOTel}}
+	{{- if or (and (or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "kitex") (eq .Web.WebFramework "connect") (eq .Web.WebFramework "grpc-gateway")) .Web.WithOTel) .Web.Datasources}}
 	"fmt"
-    {{- end}}
     {{- end}}
 
 	{{- if .Web.WithUser}}
@@ -21,6 +19,18 @@ import (
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 
 	"{{.D.ModuleName}}/internal/{{.Web.Name}}"
+	{{- range .Web.AllClients }}
+	"{{$.D.ModuleName}}/internal/{{$.Web.Name}}/pkg/clientset/typed/{{.PackageName}}"
+	{{- end}}
+	{{- if .Web.Cache}}
+	"{{.D.ModuleName}}/internal/pkg/cache"
+	{{- end}}
+	{{- if .Web.Broker}}
+	"{{.D.ModuleName}}/internal/pkg/mq"
+	{{- end}}
+	{{- if .Web.Outbox}}
+	"{{.D.ModuleName}}/internal/pkg/outbox"
+	{{- end}}
 )
 
 // ServerOptions contains the configuration options for the server.
@@ -33,11 +43,11 @@ type ServerOptions struct {
     {{- end}}
 	// TLSOptions contains the TLS configuration options.
 	TLSOptions *genericoptions.TLSOptions `json:"tls" mapstructure:"tls"`
-	{{- if or (eq .Web.WebFramework "gin") (eq .Web.WebFramework "grpc-gateway")}}
+	{{- if or (eq .Web.WebFramework "gin") (eq .Web.WebFramework "hertz") (eq .Web.WebFramework "nethttp") (eq .Web.WebFramework "grpc-gateway")}}
 	// HTTPOptions contains the HTTP configuration options.
 	HTTPOptions *genericoptions.HTTPOptions `json:"http" mapstructure:"http"`
 	{{- end}}
-	{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "grpc-gateway")}}
+	{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "kitex") (eq .Web.WebFramework "connect") (eq .Web.WebFramework "grpc-gateway")}}
 	// GRPCOptions contains the gRPC configuration options.
 	GRPCOptions *genericoptions.GRPCOptions `json:"grpc" mapstructure:"grpc"`
 	{{- end}}
@@ -53,6 +63,16 @@ type ServerOptions struct {
 	// SQLiteOptions contains the SQLite configuration options.
 	SQLiteOptions *genericoptions.SQLiteOptions `json:"sqlite" mapstructure:"sqlite"`
 	{{- end}}
+	{{- if .Web.ReadReplicas}}
+	// ReplicaOptions contains the options of the read replicas of the database, which serve the
+	// queries of the stores. They are only read from the configuration file.
+	ReplicaOptions []*genericoptions.{{.Web.DBOptions}} `json:"{{.Web.DBConfigKey}}-replicas" mapstructure:"{{.Web.DBConfigKey}}-replicas"`
+	{{- end}}
+	{{- if .Web.Datasources}}
+	// DatasourceOptions contains the options of the named datasources of the kinds created with
+	// `osbuilder create api --datasource`, by name. They are only read from the configuration file.
+	DatasourceOptions map[string]*{{.Web.Name}}.DatasourceOptions `json:"datasources" mapstructure:"datasources"`
+	{{- end}}
 	{{- if eq .Web.ServiceRegistry "polaris" }}
 	// PolarisOptions used to specify the polaris options.
     PolarisOptions *genericoptions.PolarisOptions `json:"polaris" mapstructure:"polaris"`
@@ -60,7 +80,7 @@ type ServerOptions struct {
 	{{- if .Web.WithOTel}}
     // OTelOptions used to specify the otel options.
     OTelOptions *genericoptions.OTelOptions `json:"otel" mapstructure:"otel"`
-	{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "grpc-gateway")}}
+	{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "kitex") (eq .Web.WebFramework "connect") (eq .Web.WebFramework "grpc-gateway")}}
     // MetricsAddr specifies the address for Prometheus metrics endpoint.
     MetricsAddr string `json:"metrics-addr" mapstructure:"metrics-addr"`
 	{{- end}}
@@ -68,10 +88,22 @@ type ServerOptions struct {
     // SlogOptions used to specify the slog options.
     SlogOptions *genericoptions.SlogOptions `json:"slog" mapstructure:"slog"`
 	{{- end }}
-	{{- range .Web.Clients }}
-    // {{. | kind}}Options specifies whether to create a {{. | lowerkind}} client.
-    {{. | kind}}Options *genericoptions.RestyOptions `json:"{{. | lowerkind}}" mapstructure:"{{. | lowerkind}}"`
+	{{- range .Web.AllClients }}
+    // {{.GoName}}Options contains the options of the {{.Name}} client.
+    {{.GoName}}Options *{{.PackageName}}.Options `json:"{{.ConfigKey}}" mapstructure:"{{.ConfigKey}}"`
 	{{- end }}
+	{{- if .Web.Cache}}
+	// CacheOptions contains the options of the read-through cache of the stores.
+	CacheOptions *cache.Options `json:"cache" mapstructure:"cache"`
+	{{- end}}
+	{{- if .Web.Broker}}
+	// MQOptions contains the options of the producers and the consumers of the message broker.
+	MQOptions *mq.Options `json:"mq" mapstructure:"mq"`
+	{{- end}}
+	{{- if .Web.Outbox}}
+	// OutboxOptions contains the options of the relay that publishes the events of the outbox.
+	OutboxOptions *outbox.Options `json:"outbox" mapstructure:"outbox"`
+	{{- end}}
 }
 
 // NewServerOptions creates a ServerOptions instance with default values.
@@ -82,10 +114,10 @@ func NewServerOptions() *ServerOptions {
         Expiration:        2 * time.Hour,
 		{{- end}}
 		TLSOptions:        genericoptions.NewTLSOptions(),
-		{{- if or (eq .Web.WebFramework "gin") (eq .Web.WebFramework "grpc-gateway")}}
+		{{- if or (eq .Web.WebFramework "gin") (eq .Web.WebFramework "hertz") (eq .Web.WebFramework "nethttp") (eq .Web.WebFramework "grpc-gateway")}}
 		HTTPOptions:       genericoptions.NewHTTPOptions(),
 		{{- end}}
-		{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "grpc-gateway")}}
+		{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "kitex") (eq .Web.WebFramework "connect") (eq .Web.WebFramework "grpc-gateway")}}
 		GRPCOptions:       genericoptions.NewGRPCOptions(),
 		{{- end}}
 		{{- if eq .Web.StorageType "mariadb"}}
@@ -97,34 +129,46 @@ func NewServerOptions() *ServerOptions {
 		{{- if eq .Web.StorageType "sqlite"}}
 		SQLiteOptions:      genericoptions.NewSQLiteOptions(),
 		{{- end}}
+		{{- if .Web.Datasources}}
+		DatasourceOptions: map[string]*{{.Web.Name}}.DatasourceOptions{},
+		{{- end}}
 	    {{- if eq .Web.ServiceRegistry "polaris" }}
 		PolarisOptions: genericoptions.NewPolarisOptions(),
 		{{- end}}
 		{{- if .Web.WithOTel }}
 		OTelOptions: genericoptions.NewOTelOptions(),
-		{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "grpc-gateway")}}
+		{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "kitex") (eq .Web.WebFramework "connect") (eq .Web.WebFramework "grpc-gateway")}}
     	MetricsAddr: "0.0.0.0:29090",
 		{{- end}}
 		{{- else}}
 		SlogOptions: genericoptions.NewSlogOptions(),
 		{{- end}}
-		{{- range .Web.Clients }}
-		{{. | kind}}Options: genericoptions.NewRestyOptions(),
+		{{- range .Web.AllClients }}
+		{{.GoName}}Options: {{.PackageName}}.NewOptions(),
+		{{- end}}
+		{{- if .Web.Cache}}
+		CacheOptions: cache.NewOptions(),
+		{{- end}}
+		{{- if .Web.Broker}}
+		MQOptions: mq.NewOptions(),
+		{{- end}}
+		{{- if .Web.Outbox}}
+		OutboxOptions: outbox.NewOptions(),
 		{{- end}}
 	}
-	{{- if or (eq .Web.WebFramework "gin") (eq .Web.WebFramework "grpc-gateway")}}
+	{{- if or (eq .Web.WebFramework "gin") (eq .Web.WebFramework "hertz") (eq .Web.WebFramework "nethttp") (eq .Web.WebFramework "grpc-gateway")}}
 	opts.HTTPOptions.Addr = ":5555"
 	{{- end}}
-	{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "grpc-gateway")}}
+	{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "kitex") (eq .Web.WebFramework "connect") (eq .Web.WebFramework "grpc-gateway")}}
 	opts.GRPCOptions.Addr = ":6666"
 	{{- end}}
 
 	{{- if eq .Web.ServiceRegistry "polaris" }}
 	// If enable polaris register
-	{{- if or (eq .Web.WebFramework "gin") (eq .Web.WebFramework "grpc-gateway")}}
+	{{- if or (eq .Web.WebFramework "gin") (eq .Web.WebFramework "hertz") (eq .Web.WebFramework "nethttp") (eq .Web.WebFramework "grpc-gateway")}}
 	_, port, _:= net.SplitHostPort(opts.HTTPOptions.Addr)
 	{{- end}}
-	{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "grpc-gateway")}}
+	{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "kitex") (eq .Web.WebFramework "connect") (eq .Web.WebFramework "grpc-gateway")}}
 	_, port, _:= net.SplitHostPort(opts.GRPCOptions.Addr)
 	{{- end}}
 	intPort, _ := strconv.Atoi(port)
@@ -144,10 +188,10 @@ func (o *ServerOptions) AddFlags(fs *pflag.FlagSet) {
 	{{- end}}
 	// Add command-line flags for sub-options.
 	o.TLSOptions.AddFlags(fs, "tls")
-	{{- if or (eq .Web.WebFramework "gin") (eq .Web.WebFramework "grpc-gateway")}}
+	{{- if or (eq .Web.WebFramework "gin") (eq .Web.WebFramework "hertz") (eq .Web.WebFramework "nethttp") (eq .Web.WebFramework "grpc-gateway")}}
 	o.HTTPOptions.AddFlags(fs, "http")
 	{{- end}}
-	{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "grpc-gateway")}}
+	{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "kitex") (eq .Web.WebFramework "connect") (eq .Web.WebFramework "grpc-gateway")}}
 	o.GRPCOptions.AddFlags(fs, "grpc")
 	{{- end}}
 	{{- if eq .Web.StorageType "mariadb"}}
@@ -164,15 +208,24 @@ func (o *ServerOptions) AddFlags(fs *pflag.FlagSet) {
 	{{- end}}
     {{- if .Web.WithOTel}}
 	o.OTelOptions.AddFlags(fs, "otel")
-	{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "grpc-gateway")}}
+	{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "kitex") (eq .Web.WebFramework "connect") (eq .Web.WebFramework "grpc-gateway")}}
 	fs.StringVar(&o.MetricsAddr, "metrics-addr", o.MetricsAddr, "The address to expose the Prometheus /metrics endpoint.")
     {{- end}}
     {{- else}}
 	o.SlogOptions.AddFlags(fs, "slog")
     {{- end}}
-	{{- range .Web.Clients }}
-	o.{{. | kind}}Options.AddFlags(fs, "{{. | lowerkind}}")
+	{{- range .Web.AllClients }}
+	o.{{.GoName}}Options.AddFlags(fs, "{{.ConfigKey}}")
     {{- end}}
+	{{- if .Web.Cache}}
+	o.CacheOptions.AddFlags(fs, "cache")
+	{{- end}}
+	{{- if .Web.Broker}}
+	o.MQOptions.AddFlags(fs, "mq")
+	{{- end}}
+	{{- if .Web.Outbox}}
+	o.OutboxOptions.AddFlags(fs, "outbox")
+	{{- end}}
 }
 
 // Complete completes all the required options.
@@ -194,10 +247,10 @@ func (o *ServerOptions) Validate() error {
 
 	// Validate sub-options.
 	errs = append(errs, o.TLSOptions.Validate()...)
-	{{- if or (eq .Web.WebFramework "gin") (eq .Web.WebFramework "grpc-gateway")}}
+	{{- if or (eq .Web.WebFramework "gin") (eq .Web.WebFramework "hertz") (eq .Web.WebFramework "nethttp") (eq .Web.WebFramework "grpc-gateway")}}
 	errs = append(errs, o.HTTPOptions.Validate()...)
 	{{- end}}
-	{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "grpc-gateway")}}
+	{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "kitex") (eq .Web.WebFramework "connect") (eq .Web.WebFramework "grpc-gateway")}}
 	errs = append(errs, o.GRPCOptions.Validate()...)
 	{{- end}}
 	{{- if eq .Web.StorageType "mariadb"}}
@@ -209,12 +262,24 @@ func (o *ServerOptions) Validate() error {
 	{{- if eq .Web.StorageType "sqlite"}}
 	errs = append(errs, o.SQLiteOptions.Validate()...)
 	{{- end}}
+	{{- if .Web.ReadReplicas}}
+	for _, replica := range o.ReplicaOptions {
+		errs = append(errs, replica.Validate()...)
+	}
+	{{- end}}
+	{{- if .Web.Datasources}}
+	for name, ds := range o.DatasourceOptions {
+		for _, err := range ds.Validate() {
+			errs = append(errs, fmt.Errorf("datasource %q: %w", name, err))
+		}
+	}
+	{{- end}}
 	{{- if eq .Web.ServiceRegistry "polaris" }}
 	errs = append(errs, o.PolarisOptions.Validate()...)
 	{{- end}}
 	{{- if .Web.WithOTel}}
 	errs = append(errs, o.OTelOptions.Validate()...)
-	{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "grpc-gateway")}}
+	{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "kitex") (eq .Web.WebFramework "connect") (eq .Web.WebFramework "grpc-gateway")}}
     // Validate metrics address format.
     if o.MetricsAddr == "" {
         errs = append(errs, fmt.Errorf("metrics-addr cannot be empty"))
@@ -224,9 +289,18 @@ func (o *ServerOptions) Validate() error {
 
 	errs = append(errs, o.SlogOptions.Validate()...)
     {{- end}}
-	{{- range .Web.Clients }}
-	errs = append(errs, o.{{. | kind}}Options.Validate()...)
+	{{- range .Web.AllClients }}
+	errs = append(errs, o.{{.GoName}}Options.Validate()...)
     {{- end}}
+	{{- if .Web.Cache}}
+	errs = append(errs, o.CacheOptions.Validate()...)
+	{{- end}}
+	{{- if .Web.Broker}}
+	errs = append(errs, o.MQOptions.Validate()...)
+	{{- end}}
+	{{- if .Web.Outbox}}
+	errs = append(errs, o.OutboxOptions.Validate()...)
+	{{- end}}
 
 	// Aggregate all errors and return them.
 	return utilerrors.NewAggregate(errs)
@@ -240,10 +314,10 @@ func (o *ServerOptions) Config() (*{{.Web.Name}}.Config, error) {
         Expiration:        o.Expiration,
 	    {{- end}}
 		TLSOptions:        o.TLSOptions,
-		{{- if or (eq .Web.WebFramework "gin") (eq .Web.WebFramework "grpc-gateway")}}
+		{{- if or (eq .Web.WebFramework "gin") (eq .Web.WebFramework "hertz") (eq .Web.WebFramework "nethttp") (eq .Web.WebFramework "grpc-gateway")}}
 		HTTPOptions:       o.HTTPOptions,
 		{{- end}}
-		{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "grpc-gateway")}}
+		{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "kitex") (eq .Web.WebFramework "connect") (eq .Web.WebFramework "grpc-gateway")}}
 		GRPCOptions:       o.GRPCOptions,
 		{{- end}}
 		{{- if eq .Web.StorageType "mariadb"}}
@@ -255,16 +329,31 @@ func (o *ServerOptions) Config() (*{{.Web.Name}}.Config, error) {
 		{{- if eq .Web.StorageType "sqlite"}}
 		SQLiteOptions:      o.SQLiteOptions,
 		{{- end}}
+		{{- if .Web.ReadReplicas}}
+		ReplicaOptions: o.ReplicaOptions,
+		{{- end}}
+		{{- if .Web.Datasources}}
+		DatasourceOptions: o.DatasourceOptions,
+		{{- end}}
 		{{- if eq .Web.ServiceRegistry "polaris" }}
 	    PolarisOptions: o.PolarisOptions,
 		{{- end}}
-		{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "grpc-gateway")}}
+		{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "kitex") (eq .Web.WebFramework "connect") (eq .Web.WebFramework "grpc-gateway")}}
 		{{- if .Web.WithOTel}}
 		MetricsAddr: o.MetricsAddr,
 		{{- end}}
 		{{- end}}
-		{{- range .Web.Clients }}
-		{{. | kind}}Options: o.{{. | kind}}Options,
+		{{- range .Web.AllClients }}
+		{{.GoName}}Options: o.{{.GoName}}Options,
+		{{- end}}
+		{{- if .Web.Cache}}
+		CacheOptions: o.CacheOptions,
+		{{- end}}
+		{{- if .Web.Broker}}
+		MQOptions: o.MQOptions,
+		{{- end}}
+		{{- if .Web.Outbox}}
+		OutboxOptions: o.OutboxOptions,
 		{{- end}}
 	}, nil
 }
@@ -104,6 +104,9 @@ func NewAPIServerCommand() *cobra.Command {
 	// Add the standard --version flag to the command.
 	version.AddFlags(cmd.PersistentFlags())
 
+	// Add the migrate command, which applies and reverts the database schema migrations.
+	cmd.AddCommand(newMigrateCommand(opts))
+
 	return cmd
 }
 
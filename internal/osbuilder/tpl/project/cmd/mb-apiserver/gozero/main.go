@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/zeromicro/go-zero/core/conf"
+	"github.com/zeromicro/go-zero/core/service"
+	"github.com/zeromicro/go-zero/rest"
+	"github.com/zeromicro/go-zero/zrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/config"
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/handler"
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/server"
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/svc"
+	{{.Web.APIImportPath}}
+)
+
+// configFile is the path to the go-zero configuration file of {{.Web.BinaryName}}.
+var configFile = flag.String("f", "configs/{{.Web.BinaryName}}.yaml", "Path to the {{.Web.BinaryName}} configuration file.")
+
+// The default entry point of a Go program. Serves as the starting point
+// for reading the project code.
+func main() {
+	flag.Parse()
+
+	var c config.Config
+	conf.MustLoad(*configFile, &c)
+
+	// Open the database and wire the store, biz and validation layers shared by both servers.
+	svcCtx := svc.NewServiceContext(c)
+
+	// The REST server serves the HTTP API.
+	restServer := rest.MustNewServer(c.RestConf)
+	handler.RegisterHandlers(restServer, svcCtx)
+
+	// The zrpc server serves the gRPC API of the same logics.
+	rpcServer := zrpc.MustNewServer(c.RPC, func(grpcServer *grpc.Server) {
+		{{.D.APIAlias}}.Register{{.Web.GRPCServiceName}}Server(grpcServer, server.NewServer(svcCtx))
+
+		if c.Mode == service.DevMode || c.Mode == service.TestMode {
+			reflection.Register(grpcServer)
+		}
+	})
+
+	// Run both servers until the process receives a termination signal, then stop them gracefully.
+	group := service.NewServiceGroup()
+	defer group.Stop()
+	group.Add(restServer)
+	group.Add(rpcServer)
+
+	fmt.Printf("Starting {{.Web.BinaryName}} REST server at %s:%d and RPC server at %s...\n", c.Host, c.Port, c.RPC.ListenOn)
+	group.Start()
+}
@@ -64,7 +64,10 @@ INSERT INTO `casbin_rule` VALUES
 (7,'p','role::user','/v1.MiniBlog/DeleteUser','CALL','deny','',''),
 (8,'p','role::user','/v1.MiniBlog/ListUser','CALL','deny','',''),
 (9,'p','role::user','/v1/users','GET','deny','',''),
-(10,'p','role::user','/v1/users/*','DELETE','deny','','');
+(10,'p','role::user','/v1/users/*','DELETE','deny','',''),
+(11,'p','role::user','*','read-deleted','deny','',''),
+(12,'p','role::user','*','restore','deny','',''),
+(13,'p','role::user','*','purge','deny','','');
 /*!40000 ALTER TABLE `casbin_rule` ENABLE KEYS */;
 UNLOCK TABLES;
 
@@ -118,8 +118,12 @@ func list{{.Web.R.SingularName}}(ctx context.Context, cli {{.D.APIAlias}}.{{.Web
 	log.Println("Listing {{.Web.R.PluralLower}}...")
 
 	req := &{{.D.APIAlias}}.List{{.Web.R.SingularName}}Request{
+		{{- if .Web.R.IsCursorPaginated}}
+		Limit: defaultSize,
+		{{- else}}
 		Offset: defaultPage,
 		Limit:  defaultSize,
+		{{- end}}
 	}
 
 	resp, err := cli.List{{.Web.R.SingularName}}(ctx, req)
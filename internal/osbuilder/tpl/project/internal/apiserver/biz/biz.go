@@ -10,7 +10,7 @@ import (
     {{- if .Web.WithUser }}
     userv1 "{{.D.ModuleName}}/internal/{{.Web.Name}}/biz/v1/user"
     {{- end}}
-    {{- if .Web.Clients }}
+    {{- if .Web.AllClients }}
     "{{.D.ModuleName}}/internal/{{.Web.Name}}/pkg/clientset"
     {{- end}}
     {{- if .Web.WithWS }}
@@ -39,7 +39,7 @@ type biz struct {
     {{- if .Web.WithUser }}
     authz *authz.Authz
     {{- end}}
-    {{- if .Web.Clients }}
+    {{- if .Web.AllClients }}
     clientset clientset.Interface
     {{- end}}
 }
@@ -48,8 +48,8 @@ type biz struct {
 var _ IBiz = (*biz)(nil)
 
 // NewBiz creates and returns a new instance of the business logic layer.
-func NewBiz(store store.IStore{{- if .Web.WithUser }}, authz *authz.Authz{{- end -}}{{- if .Web.Clients }}, clientset clientset.Interface{{- end -}}) *biz {
-    return &biz{store: store{{- if .Web.WithUser }}, authz: authz{{end}}{{- if .Web.Clients }}, clientset: clientset{{- end -}}}
+func NewBiz(store store.IStore{{- if .Web.WithUser }}, authz *authz.Authz{{- end -}}{{- if .Web.AllClients }}, clientset clientset.Interface{{- end -}}) *biz {
+    return &biz{store: store{{- if .Web.WithUser }}, authz: authz{{end}}{{- if .Web.AllClients }}, clientset: clientset{{- end -}}}
 }
 
 {{- if .Web.WithUser }}
@@ -62,6 +62,6 @@ func (b *biz) UserV1() userv1.UserBiz {
 {{- if .Web.WithWS }}
 // WSV1 returns an instance that implements the WSBiz interface.
 func (b *biz) WSV1() wsv1.WSBiz {
-    return wsv1.New(b.store{{- if .Web.Clients }}, b.clientset{{- end -}})
+    return wsv1.New(b.store{{- if .Web.AllClients }}, b.clientset{{- end -}})
 }
 {{- end}}
@@ -0,0 +1,51 @@
+package {{.Web.R.Last.SingularLower}}
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"{{.D.ModuleName}}/internal/pkg/outbox"
+	{{.Web.APIImportPath}}
+)
+
+// Topic is the topic that the events of the {{.Web.R.PluralLower}} are published to.
+const Topic = "{{.Web.Name}}.{{.Web.R.PluralLower}}"
+
+// enqueueCreated writes the event of a created {{.Web.R.SingularLower}} into the outbox.
+func (b *{{.Web.R.SingularLowerFirst}}Biz) enqueueCreated(ctx context.Context, {{.Web.R.Last.SingularLowerFirst}}ID string, rq *{{.D.APIAlias}}.Create{{.Web.R.SingularName}}Request) error {
+	return b.enqueueEvent(ctx, {{.Web.R.Last.SingularLowerFirst}}ID, &{{.D.APIAlias}}.{{.Web.R.SingularName}}EventEnvelope{
+		Type:    {{.D.APIAlias}}.{{.Web.R.SingularName}}EventType_{{.Web.R.EventTypePrefix}}_EVENT_TYPE_CREATED,
+		Payload: &{{.D.APIAlias}}.{{.Web.R.SingularName}}EventEnvelope_Created{Created: rq},
+	})
+}
+
+// enqueueUpdated writes the event of an updated {{.Web.R.SingularLower}} into the outbox.
+func (b *{{.Web.R.SingularLowerFirst}}Biz) enqueueUpdated(ctx context.Context, rq *{{.D.APIAlias}}.Update{{.Web.R.SingularName}}Request) error {
+	return b.enqueueEvent(ctx, rq.{{.Web.R.Last.SingularName}}ID, &{{.D.APIAlias}}.{{.Web.R.SingularName}}EventEnvelope{
+		Type:    {{.D.APIAlias}}.{{.Web.R.SingularName}}EventType_{{.Web.R.EventTypePrefix}}_EVENT_TYPE_UPDATED,
+		Payload: &{{.D.APIAlias}}.{{.Web.R.SingularName}}EventEnvelope_Updated{Updated: rq},
+	})
+}
+
+// enqueueDeleted writes the event of a deleted {{.Web.R.SingularLower}} into the outbox.
+func (b *{{.Web.R.SingularLowerFirst}}Biz) enqueueDeleted(ctx context.Context, rq *{{.D.APIAlias}}.Delete{{.Web.R.SingularName}}Request) error {
+	return b.enqueueEvent(ctx, rq.{{.Web.R.Last.SingularName}}ID, &{{.D.APIAlias}}.{{.Web.R.SingularName}}EventEnvelope{
+		Type:    {{.D.APIAlias}}.{{.Web.R.SingularName}}EventType_{{.Web.R.EventTypePrefix}}_EVENT_TYPE_DELETED,
+		Payload: &{{.D.APIAlias}}.{{.Web.R.SingularName}}EventEnvelope_Deleted{Deleted: rq},
+	})
+}
+
+// enqueueEvent writes an event into the outbox in the transaction of ctx, so that it is published
+// if and only if the write that raised it is committed. The event is keyed by the ID of the
+// {{.Web.R.SingularLower}}, so that the events of a {{.Web.R.SingularLower}} are published in order.
+func (b *{{.Web.R.SingularLowerFirst}}Biz) enqueueEvent(ctx context.Context, key string, event *{{.D.APIAlias}}.{{.Web.R.SingularName}}EventEnvelope) error {
+	event.EventID = uuid.NewString()
+	event.Timestamp = time.Now().UnixMilli()
+	msg, err := outbox.NewMessage(ctx, Topic, key, event)
+	if err != nil {
+		return err
+	}
+	return b.store.Outbox().Create(ctx, msg)
+}
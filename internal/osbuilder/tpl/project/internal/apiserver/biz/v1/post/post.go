@@ -4,10 +4,18 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	{{- if or .Web.R.Fields .Web.R.BelongsTo}}
+	"slices"
+	{{- end}}
 	"sync"
+	{{- if .Web.R.HasTimeField}}
+	"time"
+	{{- end}}
 
 	"github.com/onexstack/onexstack/pkg/core"
+	{{- if or (not .Web.R.IsNested) (not .Web.R.Parent.IsNested) .Web.R.HasRelations}}
 	"github.com/onexstack/onexstack/pkg/store/where"
+	{{- end}}
 	{{- if .Web.WithOTel}}
     "go.opentelemetry.io/otel"
     "go.opentelemetry.io/otel/attribute"
@@ -23,11 +31,14 @@ import (
 	"{{.D.ModuleName}}/internal/{{.Web.Name}}/store"
 	// "{{.D.ModuleName}}/internal/pkg/contextx"
 	{{.Web.APIImportPath}}
-    {{- if .Web.Clients }}
+    {{- if .Web.AllClients }}
     "{{.D.ModuleName}}/internal/{{.Web.Name}}/pkg/clientset"
     {{- end}}
 )
 
+{{- $readStore := printf "b.store.%s()" .Web.R.SingularName}}
+{{- if .Web.R.IsSoftDelete}}{{$readStore = printf "b.%sStore(rq.GetIncludeDeleted())" .Web.R.SingularLowerFirst}}{{end}}
+
 // {{.Web.R.SingularName}}Biz defines the interface for handling {{.Web.R.SingularLower}}-related business logic.
 type {{.Web.R.SingularName}}Biz interface {
 	// Create creates a new {{.Web.R.SingularLower}} based on the provided request parameters.
@@ -47,6 +58,14 @@ type {{.Web.R.SingularName}}Biz interface {
 
 	// List retrieves a list of {{.Web.R.PluralLower}} and their total count based on the provided request parameters.
 	List(ctx context.Context, rq *{{.D.APIAlias}}.List{{.Web.R.SingularName}}Request) (*{{.D.APIAlias}}.List{{.Web.R.SingularName}}Response, error)
+	{{- if .Web.R.IsSoftDelete}}
+
+	// Restore undeletes a deleted {{.Web.R.SingularLower}} based on the provided request parameters.
+	Restore(ctx context.Context, rq *{{.D.APIAlias}}.Restore{{.Web.R.SingularName}}Request) (*{{.D.APIAlias}}.Restore{{.Web.R.SingularName}}Response, error)
+
+	// Purge permanently removes a {{.Web.R.SingularLower}}, whether it is deleted or not.
+	Purge(ctx context.Context, rq *{{.D.APIAlias}}.Purge{{.Web.R.SingularName}}Request) (*{{.D.APIAlias}}.Purge{{.Web.R.SingularName}}Response, error)
+	{{- end}}
 
 	// {{.Web.R.SingularName}}Expansion defines additional methods for extended {{.Web.R.SingularLower}} operations, if needed.
 	{{.Web.R.SingularName}}Expansion
@@ -58,7 +77,7 @@ type {{.Web.R.SingularName}}Expansion interface{}
 // {{.Web.R.SingularLowerFirst}}Biz implements the {{.Web.R.SingularName}}Biz interface.
 type {{.Web.R.SingularLowerFirst}}Biz struct {
 	store store.IStore
-	{{- if .Web.Clients }}
+	{{- if .Web.AllClients }}
 	clientset clientset.Interface
 	{{- end}}
 }
@@ -67,8 +86,8 @@ type {{.Web.R.SingularLowerFirst}}Biz struct {
 var _ {{.Web.R.SingularName}}Biz = (*{{.Web.R.SingularLowerFirst}}Biz)(nil)
 
 // New creates and returns a new instance of {{.Web.R.SingularName}}Biz.
-func New(store store.IStore{{- if .Web.Clients }}, clientset clientset.Interface{{- end -}}) *{{.Web.R.SingularLowerFirst}}Biz {
-	return &{{.Web.R.SingularLowerFirst}}Biz{store: store{{- if .Web.Clients}}, clientset: clientset{{- end -}}}
+func New(store store.IStore{{- if .Web.AllClients }}, clientset clientset.Interface{{- end -}}) *{{.Web.R.SingularLowerFirst}}Biz {
+	return &{{.Web.R.SingularLowerFirst}}Biz{store: store{{- if .Web.AllClients}}, clientset: clientset{{- end -}}}
 }
 
 // Create implements the Create method of the {{.Web.R.SingularName}}Biz.
@@ -81,6 +100,17 @@ func (b *{{.Web.R.SingularLowerFirst}}Biz) Create(ctx context.Context, rq *{{.D.
     span.AddEvent("{{.Web.R.Last.SingularLower}}.creation.started")
     {{- end}}
 
+	{{- with .Web.R.Parent}}
+	if err := b.ensureParentExists(ctx, {{range .Parents}}rq.{{.IDField}}, {{end}}rq.{{.IDField}}); err != nil {
+		return nil, err
+	}
+	{{- end}}
+	{{- range .Web.R.BelongsTo}}
+	if err := b.ensure{{.GoName}}Exists(ctx, rq.{{.ForeignKey}}); err != nil {
+		return nil, err
+	}
+	{{- end}}
+
 	var {{.Web.R.Last.SingularLowerFirst}}M model.{{.Web.R.GORMModel}}
 	_ = core.Copy(&{{.Web.R.Last.SingularLowerFirst}}M, rq)
 	// TODO: Retrieve the UserID from the custom context and assign it as needed.
@@ -88,7 +118,18 @@ func (b *{{.Web.R.SingularLowerFirst}}Biz) Create(ctx context.Context, rq *{{.D.
                                                                                 
 	slog.InfoContext(ctx, "creating {{.Web.R.SingularLower}} in database")
 
+	{{- if .Web.R.HasOutbox}}
+	// The {{.Web.R.SingularLower}} and its created event are written in the same transaction.
+	err := b.store.TX(ctx, func(ctx context.Context) error {
+		if err := b.store.{{.Web.R.SingularName}}().Create(ctx, &{{.Web.R.Last.SingularLowerFirst}}M); err != nil {
+			return err
+		}
+		return b.enqueueCreated(ctx, {{.Web.R.Last.SingularLowerFirst}}M.{{.Web.R.Last.SingularName}}ID, rq)
+	})
+	if err != nil {
+	{{- else}}
 	if err := b.store.{{.Web.R.SingularName}}().Create(ctx, &{{.Web.R.Last.SingularLowerFirst}}M); err != nil {
+	{{- end}}
     	{{- if .Web.WithOTel}}
 		core.RecordSpanError(ctx, span, err)
     	{{- end}}
@@ -104,26 +145,112 @@ func (b *{{.Web.R.SingularLowerFirst}}Biz) Create(ctx context.Context, rq *{{.D.
 
 // Update implements the Update method of the {{.Web.R.SingularName}}Biz.
 func (b *{{.Web.R.SingularLowerFirst}}Biz) Update(ctx context.Context, rq *{{.D.APIAlias}}.Update{{.Web.R.SingularName}}Request) (*{{.D.APIAlias}}.Update{{.Web.R.SingularName}}Response, error) {
-	whr := where.F("{{.Web.R.Last.SingularLower}}_id", rq.{{.Web.R.Last.SingularName}}ID)
+	whr := {{if .Web.R.IsNested}}store.{{.Web.R.SingularName}}Scope({{range $i, $p := .Web.R.Parents}}{{if $i}}, {{end}}rq.{{$p.IDField}}{{end}}).F{{else}}where.F{{end}}("{{.Web.R.Last.SingularLower}}_id", rq.{{.Web.R.Last.SingularName}}ID)
 	{{.Web.R.Last.SingularLowerFirst}}M, err := b.store.{{.Web.R.SingularName}}().Get(ctx, whr)
 	if err != nil {
 		return nil, errno.Err{{.Web.R.SingularName}}UpdateFailed.WithMessage(err.Error())
 	}
+	{{- if .Web.R.HasResourceVersion}}
+	if rq.ResourceVersion != 0 && rq.ResourceVersion != {{.Web.R.Last.SingularLowerFirst}}M.ResourceVersion {
+		return nil, errno.Err{{.Web.R.SingularName}}VersionConflict.WithMessage("{{.Web.R.SingularLower}} %s is at version %d, not %d",
+			rq.{{.Web.R.Last.SingularName}}ID, {{.Web.R.Last.SingularLowerFirst}}M.ResourceVersion, rq.ResourceVersion)
+	}
+	{{- end}}
 
-    // TODO: Apply updates to {{.Web.R.Last.SingularLowerFirst}}M from rq.
+
+	var columns []string
+	{{- if or .Web.R.Fields .Web.R.BelongsTo}}
+	// With an update mask, exactly the fields in the mask are updated, so that fields can be cleared.
+	// Without a mask, only the fields that are set in the request are updated.
+	paths := rq.GetUpdateMask().GetPaths()
+	masked := func(path string, set bool) bool {
+		if len(paths) == 0 {
+			return set
+		}
+		if !slices.Contains(paths, path) {
+			return false
+		}
+		columns = append(columns, store.{{.Web.R.SingularName}}UpdateColumns[path])
+		return true
+	}
+	{{- range .Web.R.Fields}}
+	if masked("{{.JSONName}}", rq.{{.GoName}} != nil) {
+		{{- if .IsTime}}
+		{{$.Web.R.Last.SingularLowerFirst}}M.{{.GoName}} = time.Time{}
+		if rq.{{.GoName}} != nil {
+			{{$.Web.R.Last.SingularLowerFirst}}M.{{.GoName}} = rq.{{.GoName}}.AsTime()
+		}
+		{{- else}}
+		{{$.Web.R.Last.SingularLowerFirst}}M.{{.GoName}} = rq.Get{{.GoName}}()
+		{{- end}}
+	}
+	{{- end}}
+	{{- range .Web.R.BelongsTo}}
+	if masked("{{.ForeignKeyJSON}}", rq.{{.ForeignKey}} != nil) {
+		if err := b.ensure{{.GoName}}Exists(ctx, rq.Get{{.ForeignKey}}()); err != nil {
+			return nil, err
+		}
+		{{$.Web.R.Last.SingularLowerFirst}}M.{{.ForeignKey}} = rq.Get{{.ForeignKey}}()
+	}
+	{{- end}}
+	{{- end}}
+    // TODO: Apply additional updates to {{.Web.R.Last.SingularLowerFirst}}M from rq, and append their columns
+    // to columns when the request has an update mask.
     // Example: {{.Web.R.Last.SingularLowerFirst}}M.Status = rq.Status
 
-	if err := b.store.{{.Web.R.SingularName}}().Update(ctx, {{.Web.R.Last.SingularLowerFirst}}M); err != nil {
+	{{- if .Web.R.HasOutbox}}
+	// The {{.Web.R.SingularLower}} and its updated event are written in the same transaction.
+	err = b.store.TX(ctx, func(ctx context.Context) error {
+		var err error
+		if len(columns) > 0 {
+			err = b.store.{{.Web.R.SingularName}}().UpdateColumns(ctx, {{.Web.R.Last.SingularLowerFirst}}M, columns...)
+		} else {
+			err = b.store.{{.Web.R.SingularName}}().Update(ctx, {{.Web.R.Last.SingularLowerFirst}}M)
+		}
+		if err != nil {
+			return err
+		}
+		return b.enqueueUpdated(ctx, rq)
+	})
+	{{- else}}
+	if len(columns) > 0 {
+		err = b.store.{{.Web.R.SingularName}}().UpdateColumns(ctx, {{.Web.R.Last.SingularLowerFirst}}M, columns...)
+	} else {
+		err = b.store.{{.Web.R.SingularName}}().Update(ctx, {{.Web.R.Last.SingularLowerFirst}}M)
+	}
+	{{- end}}
+	if err != nil {
+		{{- if .Web.R.HasResourceVersion}}
+		if errors.Is(err, store.ErrResourceVersionConflict) {
+			return nil, errno.Err{{.Web.R.SingularName}}VersionConflict
+		}
+		{{- end}}
 		return nil, errno.Err{{.Web.R.SingularName}}UpdateFailed.WithMessage(err.Error())
 	}
 
-	return &{{.D.APIAlias}}.Update{{.Web.R.SingularName}}Response{}, nil
+	return &{{.D.APIAlias}}.Update{{.Web.R.SingularName}}Response{ {{- if .Web.R.HasResourceVersion}}ResourceVersion: {{.Web.R.Last.SingularLowerFirst}}M.ResourceVersion{{end -}} }, nil
 }
 
 // Delete implements the Delete method of the {{.Web.R.SingularName}}Biz.
 func (b *{{.Web.R.SingularLowerFirst}}Biz) Delete(ctx context.Context, rq *{{.D.APIAlias}}.Delete{{.Web.R.SingularName}}Request) (*{{.D.APIAlias}}.Delete{{.Web.R.SingularName}}Response, error) {
-	whr := where.F("{{.Web.R.Last.SingularLower}}_id", rq.{{.Web.R.Last.SingularName}}ID)
+	{{- if .Web.R.HasMany}}
+	if err := b.ensureNotReferenced(ctx, rq.{{.Web.R.Last.SingularName}}ID); err != nil {
+		return nil, err
+	}
+	{{end}}
+	whr := {{if .Web.R.IsNested}}store.{{.Web.R.SingularName}}Scope({{range $i, $p := .Web.R.Parents}}{{if $i}}, {{end}}rq.{{$p.IDField}}{{end}}).F{{else}}where.F{{end}}("{{.Web.R.Last.SingularLower}}_id", rq.{{.Web.R.Last.SingularName}}ID)
+	{{- if .Web.R.HasOutbox}}
+	// The {{.Web.R.SingularLower}} and its deleted event are written in the same transaction.
+	err := b.store.TX(ctx, func(ctx context.Context) error {
+		if err := b.store.{{.Web.R.SingularName}}().Delete(ctx, whr); err != nil {
+			return err
+		}
+		return b.enqueueDeleted(ctx, rq)
+	})
+	if err != nil {
+	{{- else}}
 	if err := b.store.{{.Web.R.SingularName}}().Delete(ctx, whr); err != nil {
+	{{- end}}
 		return nil, errno.Err{{.Web.R.SingularName}}DeleteFailed.WithMessage(err.Error())
 	}
 
@@ -132,8 +259,30 @@ func (b *{{.Web.R.SingularLowerFirst}}Biz) Delete(ctx context.Context, rq *{{.D.
 
 // DeleteCollection implements the DeleteCollection method of the {{.Web.R.SingularName}}Biz.
 func (b *{{.Web.R.SingularLowerFirst}}Biz) DeleteCollection(ctx context.Context, rq *v1.Delete{{.Web.R.PluralName}}Request) (*v1.Delete{{.Web.R.PluralName}}Response, error) {
-    whr := where.F("{{.Web.R.Last.SingularLower}}_id", rq.{{.Web.R.Last.SingularName}}IDs)
+    {{- if .Web.R.HasMany}}
+	if err := b.ensureNotReferenced(ctx, rq.{{.Web.R.Last.SingularName}}IDs); err != nil {
+		return nil, err
+	}
+    {{end}}
+    whr := {{if .Web.R.IsNested}}store.{{.Web.R.SingularName}}Scope({{range $i, $p := .Web.R.Parents}}{{if $i}}, {{end}}rq.{{$p.IDField}}{{end}}).F{{else}}where.F{{end}}("{{.Web.R.Last.SingularLower}}_id", rq.{{.Web.R.Last.SingularName}}IDs)
+    {{- if .Web.R.HasOutbox}}
+	// The {{.Web.R.PluralLower}} and a deleted event of each of them are written in the same transaction.
+	err := b.store.TX(ctx, func(ctx context.Context) error {
+		if err := b.store.{{.Web.R.SingularName}}().Delete(ctx, whr); err != nil {
+			return err
+		}
+		for _, id := range rq.{{.Web.R.Last.SingularName}}IDs {
+			deleted := &{{.D.APIAlias}}.Delete{{.Web.R.SingularName}}Request{ {{- range .Web.R.Parents}}{{.IDField}}: rq.{{.IDField}}, {{end}}{{.Web.R.Last.SingularName}}ID: id}
+			if err := b.enqueueDeleted(ctx, deleted); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+    {{- else}}
     if err := b.store.{{.Web.R.SingularName}}().Delete(ctx, whr); err != nil {
+    {{- end}}
         return nil, errno.Err{{.Web.R.SingularName}}DeleteFailed.WithMessage(err.Error())
     }
 
@@ -151,8 +300,8 @@ func (b *{{.Web.R.SingularLowerFirst}}Biz) Get(ctx context.Context, rq *{{.D.API
 
 	slog.InfoContext(ctx, "retrieving job from database", "job_id", rq.{{.Web.R.Last.SingularName}}ID)
 
-	whr := where.F("{{.Web.R.Last.SingularLower}}_id", rq.{{.Web.R.Last.SingularName}}ID)
-	{{.Web.R.Last.SingularLowerFirst}}M, err := b.store.{{.Web.R.SingularName}}().Get(ctx, whr)
+	whr := {{if .Web.R.IsNested}}store.{{.Web.R.SingularName}}Scope({{range $i, $p := .Web.R.Parents}}{{if $i}}, {{end}}rq.{{$p.IDField}}{{end}}).F{{else}}where.F{{end}}("{{.Web.R.Last.SingularLower}}_id", rq.{{.Web.R.Last.SingularName}}ID)
+	{{.Web.R.Last.SingularLowerFirst}}M, err := {{$readStore}}.Get(ctx, whr)
 	if err != nil {
 		{{- if .Web.WithOTel}}
 		core.RecordSpanError(ctx, span, err)
@@ -169,8 +318,68 @@ func (b *{{.Web.R.SingularLowerFirst}}Biz) Get(ctx context.Context, rq *{{.D.API
 
 // List implements the List method of the {{.Web.R.SingularName}}Biz.
 func (b *{{.Web.R.SingularLowerFirst}}Biz) List(ctx context.Context, rq *{{.D.APIAlias}}.List{{.Web.R.SingularName}}Request) (*{{.D.APIAlias}}.List{{.Web.R.SingularName}}Response, error) {
-	whr := where.P(int(rq.Offset), int(rq.Limit))
-	count, {{.Web.R.Last.SingularLowerFirst}}List, err := b.store.{{.Web.R.SingularName}}().List(ctx, whr)
+	{{- if .Web.R.IsCursorPaginated}}
+	whr := {{if .Web.R.IsNested}}store.{{.Web.R.SingularName}}Scope({{range $i, $p := .Web.R.Parents}}{{if $i}}, {{end}}rq.{{$p.IDField}}{{end}}){{else}}where.NewWhere(){{end}}
+	{{- else}}
+	whr := {{if .Web.R.IsNested}}store.{{.Web.R.SingularName}}Scope({{range $i, $p := .Web.R.Parents}}{{if $i}}, {{end}}rq.{{$p.IDField}}{{end}}).P{{else}}where.P{{end}}(int(rq.Offset), int(rq.Limit))
+	{{- end}}
+	{{- range .Web.R.BelongsTo}}
+	if rq.{{.ForeignKey}} != "" {
+		whr = whr.F("{{.ForeignKeyColumn}}", rq.{{.ForeignKey}})
+	}
+	{{- end}}
+	{{- range .Web.R.ListFilters}}
+	{{- if eq .Op "eq"}}
+	if rq.{{.GoName}} != nil {
+		whr = whr.F("{{.Column}}", rq.Get{{.GoName}}())
+	}
+	{{- else if eq .Op "in"}}
+	if len(rq.{{.GoName}}) > 0 {
+		whr = whr.C(store.In("{{.Column}}", rq.Get{{.GoName}}()))
+	}
+	{{- else if eq .Op "prefix"}}
+	if rq.{{.GoName}} != nil {
+		whr = whr.C(store.HasPrefix("{{.Column}}", rq.Get{{.GoName}}()))
+	}
+	{{- else if eq .Op "min"}}
+	if rq.{{.GoName}} != nil {
+		whr = whr.C(store.AtLeast("{{.Column}}", rq.Get{{.GoName}}()))
+	}
+	{{- else if eq .Op "max"}}
+	if rq.{{.GoName}} != nil {
+		whr = whr.C(store.AtMost("{{.Column}}", rq.Get{{.GoName}}()))
+	}
+	{{- else}}
+	if rq.{{.GoName}} != nil {
+		expr, err := store.{{if eq .Op "after"}}Since{{else}}Until{{end}}("{{.Column}}", rq.Get{{.GoName}}())
+		if err != nil {
+			return nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error())
+		}
+		whr = whr.C(expr)
+	}
+	{{- end}}
+	{{- end}}
+	{{- if .Web.R.IsCursorPaginated}}
+	count, {{.Web.R.Last.SingularLowerFirst}}List, nextPageToken, err := {{$readStore}}.ListByCursor(ctx, whr, &store.PageQuery{
+		PageToken: rq.GetPageToken(),
+		Limit:     int(rq.GetLimit()),
+		OrderBy:   rq.GetOrderBy(),
+		Columns:   store.{{.Web.R.SingularName}}SortColumns,
+		SkipTotal: rq.GetSkipTotal(),
+	})
+	if errors.Is(err, store.ErrInvalidPageToken) {
+		return nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error())
+	}
+	{{- else}}
+	if rq.GetOrderBy() != "" {
+		orderBy, err := store.OrderBy(rq.GetOrderBy(), store.{{.Web.R.SingularName}}SortColumns)
+		if err != nil {
+			return nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error())
+		}
+		whr = whr.C(orderBy)
+	}
+	count, {{.Web.R.Last.SingularLowerFirst}}List, err := {{$readStore}}.List(ctx, whr)
+	{{- end}}
 	if err != nil {
 		slog.ErrorContext(ctx, "failed to list {{.Web.R.PluralLower}}", "error", err)
 		return nil, errno.Err{{.Web.R.SingularName}}ListFailed.WithMessage(err.Error())
@@ -192,6 +401,7 @@ func (b *{{.Web.R.SingularLowerFirst}}Biz) List(ctx context.Context, rq *{{.D.AP
 			default:
 				converted := conversion.{{.Web.R.MapModelToAPIFunc}}({{.Web.R.Last.SingularLowerFirst}})
 				// TODO: Add complex enrichment logic here if needed.
+				conversion.ApplyFieldMask(converted, rq.GetFieldMask())
 				m.Store({{.Web.R.Last.SingularLowerFirst}}.ID, converted)
 
 				return nil
@@ -212,5 +422,92 @@ func (b *{{.Web.R.SingularLowerFirst}}Biz) List(ctx context.Context, rq *{{.D.AP
         }
 	}
 
-	return &{{.D.APIAlias}}.List{{.Web.R.SingularName}}Response{Total: count, {{.Web.R.Last.PluralName}}: {{.Web.R.Last.PluralLowerFirst}}}, nil
+	return &{{.D.APIAlias}}.List{{.Web.R.SingularName}}Response{Total: count, {{.Web.R.Last.PluralName}}: {{.Web.R.Last.PluralLowerFirst}}{{if .Web.R.IsCursorPaginated}}, NextPageToken: nextPageToken{{end}}}, nil
+}
+{{- if .Web.R.IsSoftDelete}}
+
+// Restore implements the Restore method of the {{.Web.R.SingularName}}Biz.
+func (b *{{.Web.R.SingularLowerFirst}}Biz) Restore(ctx context.Context, rq *{{.D.APIAlias}}.Restore{{.Web.R.SingularName}}Request) (*{{.D.APIAlias}}.Restore{{.Web.R.SingularName}}Response, error) {
+	whr := {{if .Web.R.IsNested}}store.{{.Web.R.SingularName}}Scope({{range $i, $p := .Web.R.Parents}}{{if $i}}, {{end}}rq.{{$p.IDField}}{{end}}).F{{else}}where.F{{end}}("{{.Web.R.Last.SingularLower}}_id", rq.{{.Web.R.Last.SingularName}}ID)
+	if err := b.store.{{.Web.R.SingularName}}().Restore(ctx, whr); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.Err{{.Web.R.SingularName}}NotFound.WithMessage("no deleted {{.Web.R.SingularLower}} %s found", rq.{{.Web.R.Last.SingularName}}ID)
+		}
+		return nil, errno.Err{{.Web.R.SingularName}}RestoreFailed.WithMessage(err.Error())
+	}
+
+	return &{{.D.APIAlias}}.Restore{{.Web.R.SingularName}}Response{}, nil
+}
+
+// Purge implements the Purge method of the {{.Web.R.SingularName}}Biz.
+func (b *{{.Web.R.SingularLowerFirst}}Biz) Purge(ctx context.Context, rq *{{.D.APIAlias}}.Purge{{.Web.R.SingularName}}Request) (*{{.D.APIAlias}}.Purge{{.Web.R.SingularName}}Response, error) {
+	{{- if .Web.R.HasMany}}
+	if err := b.ensureNotReferenced(ctx, rq.{{.Web.R.Last.SingularName}}ID); err != nil {
+		return nil, err
+	}
+	{{end}}
+	whr := {{if .Web.R.IsNested}}store.{{.Web.R.SingularName}}Scope({{range $i, $p := .Web.R.Parents}}{{if $i}}, {{end}}rq.{{$p.IDField}}{{end}}).F{{else}}where.F{{end}}("{{.Web.R.Last.SingularLower}}_id", rq.{{.Web.R.Last.SingularName}}ID)
+	if err := b.store.{{.Web.R.SingularName}}().Purge(ctx, whr); err != nil {
+		return nil, errno.Err{{.Web.R.SingularName}}PurgeFailed.WithMessage(err.Error())
+	}
+
+	return &{{.D.APIAlias}}.Purge{{.Web.R.SingularName}}Response{}, nil
+}
+
+// {{.Web.R.SingularLowerFirst}}Store returns the {{.Web.R.SingularLower}} store, which also matches deleted {{.Web.R.PluralLower}} if includeDeleted is set.
+func (b *{{.Web.R.SingularLowerFirst}}Biz) {{.Web.R.SingularLowerFirst}}Store(includeDeleted bool) store.{{.Web.R.SingularName}}Store {
+	if includeDeleted {
+		return b.store.{{.Web.R.SingularName}}().Unscoped()
+	}
+	return b.store.{{.Web.R.SingularName}}()
+}
+{{- end}}
+{{- with .Web.R.Parent}}
+
+// ensureParentExists verifies that the parent {{.SingularLower}} of a {{$.Web.R.SingularLower}} exists
+{{- if .IsNested}} under the
+// parents given in the request path, so that a {{$.Web.R.SingularLower}} cannot be created under a
+// {{.Last.SingularLower}} of other parents{{end}}.
+func (b *{{$.Web.R.SingularLowerFirst}}Biz) ensureParentExists(ctx context.Context, {{range .Parents}}{{.IDJSON}}, {{end}}{{.IDJSON}} string) error {
+	whr := {{if .IsNested}}store.{{.SingularName}}Scope({{range $i, $p := .Parents}}{{if $i}}, {{end}}{{$p.IDJSON}}{{end}}).F{{else}}where.F{{end}}("{{.IDColumn}}", {{.IDJSON}})
+	if _, err := b.store.{{.SingularName}}().Get(ctx, whr); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errno.Err{{.SingularName}}NotFound
+		}
+		return errno.ErrDBRead.WithMessage(err.Error())
+	}
+	return nil
+}
+{{- end}}
+{{- range .Web.R.BelongsTo}}
+
+// ensure{{.GoName}}Exists verifies that the {{.Ref.SingularLower}} referenced by a {{$.Web.R.SingularLower}} exists.
+func (b *{{$.Web.R.SingularLowerFirst}}Biz) ensure{{.GoName}}Exists(ctx context.Context, {{.ForeignKeyJSON}} string) error {
+	if {{.ForeignKeyJSON}} == "" {
+		return errno.ErrInvalidArgument.WithMessage("{{.ForeignKeyJSON}} cannot be empty")
+	}
+
+	if _, err := b.store.{{.Ref.SingularName}}().Get(ctx, where.F("{{.RefColumn}}", {{.ForeignKeyJSON}})); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errno.Err{{.Ref.SingularName}}NotFound
+		}
+		return errno.ErrDBRead.WithMessage(err.Error())
+	}
+	return nil
+}
+{{- end}}
+{{- if .Web.R.HasMany}}
+
+// ensureNotReferenced verifies that no resource still references the given {{.Web.R.PluralLower}}.
+// The ids argument may be a single identifier or a list of identifiers.
+func (b *{{.Web.R.SingularLowerFirst}}Biz) ensureNotReferenced(ctx context.Context, ids any) error {
+	{{- range .Web.R.HasMany}}
+	if count, _, err := b.store.{{.Ref.SingularName}}().List(ctx, where.F("{{.Inverse.ForeignKeyColumn}}", ids).L(1)); err != nil {
+		return errno.ErrDBRead.WithMessage(err.Error())
+	} else if count > 0 {
+		return errno.Err{{$.Web.R.SingularName}}InUse.WithMessage("{{$.Web.R.SingularLower}} is still referenced by %d {{.Ref.PluralLower}}", count)
+	}
+	{{- end}}
+	return nil
 }
+{{- end}}
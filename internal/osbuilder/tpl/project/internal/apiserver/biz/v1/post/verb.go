@@ -0,0 +1,33 @@
+package {{.Web.R.Last.SingularLower}}
+
+import (
+	"context"
+
+	{{- if not .Web.R.IsNested}}
+	"github.com/onexstack/onexstack/pkg/store/where"
+	{{- end}}
+
+	"{{.D.ModuleName}}/internal/pkg/errno"
+	{{- if .Web.R.IsNested}}
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/store"
+	{{- end}}
+	{{.Web.APIImportPath}}
+)
+
+// {{.Web.R.Verb.GoName}} implements the {{.Web.R.Verb.GoName}} method of the {{.Web.R.SingularName}}Biz.
+func (b *{{.Web.R.SingularLowerFirst}}Biz) {{.Web.R.Verb.GoName}}(ctx context.Context, rq *{{.D.APIAlias}}.{{.Web.R.Verb.GoName}}{{.Web.R.SingularName}}Request) (*{{.D.APIAlias}}.{{.Web.R.Verb.GoName}}{{.Web.R.SingularName}}Response, error) {
+	whr := {{if .Web.R.IsNested}}store.{{.Web.R.SingularName}}Scope({{range $i, $p := .Web.R.Parents}}{{if $i}}, {{end}}rq.{{$p.IDField}}{{end}}).F{{else}}where.F{{end}}("{{.Web.R.IDColumn}}", rq.{{.Web.R.IDField}})
+	{{.Web.R.Last.SingularLowerFirst}}M, err := b.store.{{.Web.R.SingularName}}().Get(ctx, whr)
+	if err != nil {
+		return nil, errno.Err{{.Web.R.SingularName}}NotFound.WithMessage(err.Error())
+	}
+
+	// TODO: Implement the {{.Web.R.Verb.Label}} operation on {{.Web.R.Last.SingularLowerFirst}}M.
+	// Example: {{.Web.R.Last.SingularLowerFirst}}M.Status = "{{.Web.R.Verb.PathName}}"
+
+	if err := b.store.{{.Web.R.SingularName}}().Update(ctx, {{.Web.R.Last.SingularLowerFirst}}M); err != nil {
+		return nil, errno.Err{{.Web.R.SingularName}}UpdateFailed.WithMessage(err.Error())
+	}
+
+	return &{{.D.APIAlias}}.{{.Web.R.Verb.GoName}}{{.Web.R.SingularName}}Response{}, nil
+}
@@ -5,7 +5,7 @@ import (
 	"log/slog"
 	"time"
 
-    {{- if .Web.Clients }}
+    {{- if .Web.AllClients }}
     "{{.D.ModuleName}}/internal/{{.Web.Name}}/pkg/clientset"
     {{- end}}
     "{{.D.ModuleName}}/internal/{{.Web.Name}}/store"
@@ -21,7 +21,7 @@ type WSBiz interface {
 // wsBiz implements the WSBiz interface.
 type wsBiz struct {
 	store     store.IStore
-    {{- if .Web.Clients }}
+    {{- if .Web.AllClients }}
     clientset clientset.Interface
     {{- end}}
 }
@@ -30,8 +30,8 @@ type wsBiz struct {
 var _ WSBiz = (*wsBiz)(nil)
 
 // New creates and returns a new instance of wsBiz.
-func New(s store.IStore{{- if .Web.Clients }}, c clientset.Interface{{- end -}}) *wsBiz {
-    return &wsBiz{store: s{{- if .Web.Clients}}, clientset: c{{- end -}}}
+func New(s store.IStore{{- if .Web.AllClients }}, c clientset.Interface{{- end -}}) *wsBiz {
+    return &wsBiz{store: s{{- if .Web.AllClients}}, clientset: c{{- end -}}}
 }
 
 // Ping handles the heartbeat request.
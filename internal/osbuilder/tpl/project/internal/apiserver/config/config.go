@@ -0,0 +1,36 @@
+package config
+
+import (
+	"github.com/zeromicro/go-zero/rest"
+	"github.com/zeromicro/go-zero/zrpc"
+)
+
+// Config is the configuration of {{.Web.BinaryName}}, loaded by go-zero from configs/{{.Web.BinaryName}}.yaml.
+// The embedded rest.RestConf configures the REST server, and RPC configures the zrpc server,
+// which serve the same API.
+type Config struct {
+	rest.RestConf
+
+	// RPC configures the zrpc server, which serves the {{.Web.GRPCServiceName}} gRPC service.
+	RPC zrpc.RpcServerConf
+	{{- if ne .Web.StorageType "memory" }}
+
+	// DB configures the database of the stores.
+	DB DBConf
+	{{- end}}
+}
+{{- if ne .Web.StorageType "memory" }}
+
+// DBConf is the configuration of the {{.Web.StorageType}} database of the stores.
+type DBConf struct {
+	// DataSource is the data source name of the database
+	{{- if eq .Web.StorageType "mariadb" }}
+	// (e.g., "user:password@tcp(127.0.0.1:3306)/db?charset=utf8mb4&parseTime=true&loc=Local").
+	{{- else if eq .Web.StorageType "postgresql" }}
+	// (e.g., "host=127.0.0.1 port=5432 user=onex password=onex dbname=onex sslmode=disable").
+	{{- else}}
+	// (e.g., "/tmp/{{.Web.BinaryName}}.db").
+	{{- end}}
+	DataSource string
+}
+{{- end}}
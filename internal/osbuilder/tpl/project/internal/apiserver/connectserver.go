@@ -0,0 +1,169 @@
+package {{.Web.Name}}
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"connectrpc.com/connect"
+	{{- if .Web.WithOTel}}
+	"connectrpc.com/otelconnect"
+	{{- end}}
+	"github.com/onexstack/onexstack/pkg/server"
+	genericvalidation "github.com/onexstack/onexstack/pkg/validation"
+	{{- if .Web.WithOTel}}
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	{{- end}}
+
+	mw "{{.D.ModuleName}}/internal/pkg/middleware/connect"
+	{{- if .Web.WithUser}}
+	{{.D.APIAlias}} "{{.D.ModuleName}}/pkg/api/{{.Web.Name}}/{{.D.APIVersion}}"
+	{{- end}}
+)
+
+// connectServer implements the server.Server interface using Connect.
+type connectServer struct {
+	srv *http.Server
+	{{- if .Web.WithOTel}}
+	// metrics serves the Prometheus /metrics endpoint.
+	metrics *http.Server
+	{{- end}}
+}
+
+// Ensure that *connectServer implements the server.Server interface.
+var _ server.Server = (*connectServer)(nil)
+
+// NewConnectServer creates and initializes a Connect server, which serves the {{.Web.GRPCServiceName}} service to
+// Connect, gRPC and gRPC-Web clients on a single HTTP handler.
+func (c *ServerConfig) NewConnectServer() (server.Server, error) {
+	{{- if .Web.WithOTel}}
+	otelInterceptor, err := otelconnect.NewInterceptor()
+	if err != nil {
+		return nil, err
+	}
+
+	{{end -}}
+	// Note the order of interceptors! The first one is the outermost.
+	interceptors := connect.WithInterceptors(
+		{{- if .Web.WithOTel}}
+		otelInterceptor,
+		{{- end}}
+		mw.StatusInterceptor(),
+		// Request ID interceptor.
+		mw.RequestIDInterceptor(),
+		{{- if .Web.WithUser}}
+		// Authentication interceptor.
+		mw.Selector(mw.AuthnInterceptor(c.Retriever), NewAuthnWhiteListMatcher()),
+		// Authorization interceptor.
+		mw.Selector(mw.AuthzInterceptor(c.Authz), NewAuthzWhiteListMatcher()),
+		{{- end}}
+		// Default value setting interceptor.
+		mw.DefaulterInterceptor(),
+		// Request validation interceptor.
+		mw.ValidatorInterceptor(genericvalidation.NewValidator(c.Validator)),
+		{{- if .Web.WithOTel}}
+		mw.Context(),
+		{{- end}}
+	)
+
+	mux := http.NewServeMux()
+	c.Handler.ApplyTo(mux, interceptors)
+
+	// Serve HTTP/1.1 for the Connect and gRPC-Web protocols, and HTTP/2 for all of them, including
+	// gRPC. Without TLS, HTTP/2 is served in cleartext (h2c) to gRPC clients.
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(true)
+	protocols.SetUnencryptedHTTP2(true)
+
+	srv := &http.Server{
+		Addr:      c.GRPCOptions.Addr,
+		Handler:   mw.Cors(mux),
+		Protocols: protocols,
+	}
+	if c.TLSOptions != nil && c.TLSOptions.UseTLS {
+		srv.TLSConfig = c.TLSOptions.MustTLSConfig()
+	}
+
+	{{- if .Web.WithOTel}}
+
+	// Serve the Prometheus metrics on a separate HTTP server.
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", promhttp.HandlerFor(
+		prometheus.DefaultGatherer,
+		promhttp.HandlerOpts{
+			EnableOpenMetrics: true,
+		},
+	))
+
+	return &connectServer{srv: srv, metrics: &http.Server{Addr: c.MetricsAddr, Handler: metricsMux}}, nil
+	{{- else}}
+
+	return &connectServer{srv: srv}, nil
+	{{- end}}
+}
+
+// RunOrDie starts the Connect server and panics if startup fails.
+func (s *connectServer) RunOrDie(ctx context.Context) {
+	{{- if .Web.WithOTel}}
+	go func() {
+		slog.InfoContext(ctx, "start metrics server", "addr", s.metrics.Addr)
+		if err := s.metrics.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.ErrorContext(ctx, "failed to start metrics server", "error", err)
+		}
+	}()
+
+	{{end -}}
+	slog.InfoContext(ctx, "start to listening the incoming requests", "protocol", "connect", "addr", s.srv.Addr)
+
+	var err error
+	if s.srv.TLSConfig != nil {
+		err = s.srv.ListenAndServeTLS("", "")
+	} else {
+		err = s.srv.ListenAndServe()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		slog.ErrorContext(ctx, "failed to start connect server", "error", err)
+		panic(err)
+	}
+}
+
+// GracefulStop gracefully stops the Connect server.
+func (s *connectServer) GracefulStop(ctx context.Context) {
+	{{- if .Web.WithOTel}}
+	_ = s.metrics.Shutdown(ctx)
+	{{- end}}
+	if err := s.srv.Shutdown(ctx); err != nil {
+		slog.ErrorContext(ctx, "failed to gracefully stop connect server", "error", err)
+	}
+}
+
+{{- if .Web.WithUser}}
+
+// authWhiteList lists the procedures that are called without authentication and authorization.
+var authWhiteList = map[string]struct{}{
+	{{- if .Web.WithHealthz}}
+	{{.D.APIAlias}}.{{.Web.GRPCServiceName}}_Healthz_FullMethodName:    {},
+	{{- end}}
+	{{.D.APIAlias}}.{{.Web.GRPCServiceName}}_CreateUser_FullMethodName: {},
+	{{.D.APIAlias}}.{{.Web.GRPCServiceName}}_Login_FullMethodName:      {},
+}
+
+// NewAuthnWhiteListMatcher creates an authentication whitelist matcher.
+func NewAuthnWhiteListMatcher() mw.MatchFunc {
+	return func(ctx context.Context, procedure string) bool {
+		_, ok := authWhiteList[procedure]
+		return !ok
+	}
+}
+
+// NewAuthzWhiteListMatcher creates an authorization whitelist matcher.
+func NewAuthzWhiteListMatcher() mw.MatchFunc {
+	return func(ctx context.Context, procedure string) bool {
+		_, ok := authWhiteList[procedure]
+		return !ok
+	}
+}
+{{- end}}
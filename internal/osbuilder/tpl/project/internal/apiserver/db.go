@@ -0,0 +1,158 @@
+package {{.Web.Name}}
+
+import (
+	{{- if .Web.IsResolved}}
+	"fmt"
+	{{- end}}
+	"log/slog"
+
+	{{- if eq .Web.StorageType "memory" }}
+	"github.com/onexstack/onexstack/pkg/db"
+	{{- end}}
+	{{- if .Web.IsResolved}}
+	genericoptions "github.com/onexstack/onexstack/pkg/options"
+	{{- end}}
+	"gorm.io/gorm"
+	{{- if .Web.IsResolved}}
+	"gorm.io/plugin/dbresolver"
+	{{- if .Web.Datasources}}
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/store"
+	{{- end}}
+	{{- end}}
+)
+{{- if .Web.Datasources}}
+
+// DatasourceOptions contains the options of a named datasource: its primary database and the
+// read replicas of the primary.
+type DatasourceOptions struct {
+	// Primary contains the options of the primary database, which serves the writes.
+	Primary *genericoptions.{{.Web.DBOptions}} `json:"primary" mapstructure:"primary"`
+	// Replicas contains the options of the read replicas, which serve the queries.
+	Replicas []*genericoptions.{{.Web.DBOptions}} `json:"replicas" mapstructure:"replicas"`
+}
+
+// Validate checks whether the options of the datasource are valid.
+func (o *DatasourceOptions) Validate() []error {
+	if o.Primary == nil {
+		return []error{fmt.Errorf("the primary database of the datasource is not configured")}
+	}
+	errs := o.Primary.Validate()
+	for _, replica := range o.Replicas {
+		errs = append(errs, replica.Validate()...)
+	}
+	return errs
+}
+{{- end}}
+
+// OpenDB opens the database of {{.Web.BinaryName}} without changing its schema.
+{{- if .Web.IsResolved}}
+// The queries are routed by dbresolver: reads go to the read replicas, and the tables of the kinds
+// created with `osbuilder create api --datasource` go to their named datasources.
+{{- end}}
+func (cfg *Config) OpenDB() (*gorm.DB, error) {
+	slog.Info("initializing database connection", "type", "{{.Web.StorageType}}")
+
+	{{- if eq .Web.StorageType "mariadb" }}
+	dbInstance, err := cfg.MySQLOptions.NewDB()
+	{{- end}}
+	{{- if eq .Web.StorageType "postgresql" }}
+	dbInstance, err := cfg.PostgreSQLOptions.NewDB()
+	{{- end}}
+	{{- if eq .Web.StorageType "sqlite" }}
+	dbInstance, err := cfg.SQLiteOptions.NewDB()
+	{{- end}}
+	{{- if eq .Web.StorageType "memory" }}
+	// TODO: Retrieve the database path from configuration instead of hardcoding.
+	dbInstance, err := db.NewInMemorySQLite("/tmp/{{.Web.BinaryName}}.db")
+	{{- end}}
+	if err != nil {
+		slog.Error("failed to create database connection", "error", err)
+		return nil, err
+	}
+	{{- if .Web.IsResolved}}
+
+	if err := cfg.useResolver(dbInstance); err != nil {
+		slog.Error("failed to route database queries", "error", err)
+		return nil, err
+	}
+	{{- end}}
+	return dbInstance, nil
+}
+{{- if .Web.IsResolved}}
+{{- if .Web.Datasources}}
+
+// OpenDatasource opens the primary database of the named datasource without changing its schema.
+func (cfg *Config) OpenDatasource(name string) (*gorm.DB, error) {
+	ds, ok := cfg.DatasourceOptions[name]
+	if !ok || ds.Primary == nil {
+		return nil, fmt.Errorf("datasource %q is not configured", name)
+	}
+	return ds.Primary.NewDB()
+}
+{{- end}}
+
+// useResolver registers the dbresolver plugin on db, which sends the queries to the read replicas
+// of the primary database{{if .Web.Datasources}}, and the tables of the kinds routed to a named datasource to that
+// datasource{{end}}. Writes and transactions always use the primary database of a datasource.
+func (cfg *Config) useResolver(db *gorm.DB) error {
+	{{- if .Web.ReadReplicas}}
+	replicas, err := openReplicas(cfg.ReplicaOptions)
+	if err != nil {
+		return err
+	}
+	resolver := dbresolver.Register(dbresolver.Config{Replicas: replicas})
+	slog.Info("routing queries to read replicas", "replicas", len(replicas))
+	{{- else}}
+	resolver := &dbresolver.DBResolver{}
+	{{- end}}
+	{{- if .Web.Datasources}}
+
+	for name, models := range store.DatasourceModels() {
+		ds, ok := cfg.DatasourceOptions[name]
+		if !ok || ds.Primary == nil {
+			return fmt.Errorf("datasource %q of the models %v is not configured", name, models)
+		}
+		primary, err := ds.Primary.NewDB()
+		if err != nil {
+			return fmt.Errorf("failed to open datasource %q: %w", name, err)
+		}
+		dsReplicas, err := openReplicas(ds.Replicas)
+		if err != nil {
+			return fmt.Errorf("failed to open the replicas of datasource %q: %w", name, err)
+		}
+		sources := []gorm.Dialector{pooledDialector{Dialector: primary.Dialector, pool: primary.ConnPool}}
+		resolver = resolver.Register(dbresolver.Config{Sources: sources, Replicas: dsReplicas}, models...)
+		slog.Info("routing models to datasource", "datasource", name, "models", len(models), "replicas", len(dsReplicas))
+	}
+	{{- end}}
+
+	return db.Use(resolver)
+}
+
+// openReplicas opens the read replicas described by opts.
+func openReplicas(opts []*genericoptions.{{.Web.DBOptions}}) ([]gorm.Dialector, error) {
+	replicas := make([]gorm.Dialector, 0, len(opts))
+	for i, o := range opts {
+		replica, err := o.NewDB()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open read replica %d: %w", i, err)
+		}
+		replicas = append(replicas, pooledDialector{Dialector: replica.Dialector, pool: replica.ConnPool})
+	}
+	return replicas, nil
+}
+
+// pooledDialector hands the connection pool of a database opened from its options to dbresolver,
+// which would otherwise open the database again without the pool settings of the options.
+type pooledDialector struct {
+	gorm.Dialector
+	pool gorm.ConnPool
+}
+
+// Initialize makes db use the connection pool of the opened database.
+func (d pooledDialector) Initialize(db *gorm.DB) error {
+	db.ConnPool = d.pool
+	return nil
+}
+{{- end}}
@@ -72,8 +72,18 @@ func (c *ServerConfig) InstallRESTAPI(engine *gin.Engine) {
 	
 	authMiddlewares := []gin.HandlerFunc{mw.AuthnMiddleware(c.Retriever), mw.AuthzMiddleware(c.Authz)}
 	c.Handler.ApplyTo({{.D.APIVersion}}, authMiddlewares...)
+	{{- if .Web.WithGraphQL}}
+
+	// Register the GraphQL endpoint, which is protected as the RESTful APIs are.
+	engine.POST("/graphql", append(authMiddlewares, gin.WrapH(c.GraphQL))...)
+	{{- end}}
 	{{- else}}
 	c.Handler.ApplyTo({{.D.APIVersion}})
+	{{- if .Web.WithGraphQL}}
+
+	// Register the GraphQL endpoint.
+	engine.POST("/graphql", gin.WrapH(c.GraphQL))
+	{{- end}}
 	{{- end}}
 }
 
@@ -0,0 +1,144 @@
+package graph
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"{{.D.ModuleName}}/internal/pkg/errno"
+)
+
+// defaultPageSize is the number of nodes of a connection when first is not given.
+const defaultPageSize = 20
+
+// offsetCursorPrefix prefixes the offsets encoded in the cursors of offset paginated kinds.
+const offsetCursorPrefix = "offset:"
+
+// connectionResolver resolves the fields of the <Kind>Connection types: a page of T nodes.
+type connectionResolver[T any] struct {
+	nodes       []T
+	cursors     []string
+	total       int64
+	endCursor   string
+	hasNextPage bool
+}
+
+// edgeResolver resolves the fields of the <Kind>Edge types.
+type edgeResolver[T any] struct {
+	cursor string
+	node   T
+}
+
+// pageInfoResolver resolves the fields of the PageInfo type.
+type pageInfoResolver struct {
+	endCursor   string
+	hasNextPage bool
+}
+
+// newOffsetConnection returns the connection of a page of an offset paginated kind that starts at
+// offset. The cursor of a node encodes the offset of the node that follows it.
+func newOffsetConnection[T any](nodes []T, total int64, offset int64) *connectionResolver[T] {
+	c := &connectionResolver[T]{
+		nodes:       nodes,
+		cursors:     make([]string, len(nodes)),
+		total:       total,
+		hasNextPage: offset+int64(len(nodes)) < total,
+	}
+	for i := range nodes {
+		c.cursors[i] = base64.RawURLEncoding.EncodeToString([]byte(offsetCursorPrefix + strconv.FormatInt(offset+int64(i)+1, 10)))
+	}
+	if len(nodes) > 0 {
+		c.endCursor = c.cursors[len(nodes)-1]
+	}
+	return c
+}
+
+// newCursorConnection returns the connection of a page of a cursor paginated kind. Page tokens
+// are only issued for the end of a page, so only the last edge has a cursor.
+func newCursorConnection[T any](nodes []T, total int64, nextPageToken string) *connectionResolver[T] {
+	c := &connectionResolver[T]{
+		nodes:       nodes,
+		cursors:     make([]string, len(nodes)),
+		total:       total,
+		endCursor:   nextPageToken,
+		hasNextPage: nextPageToken != "",
+	}
+	if len(nodes) > 0 {
+		c.cursors[len(nodes)-1] = nextPageToken
+	}
+	return c
+}
+
+// TotalCount resolves the number of nodes that match the query.
+func (c *connectionResolver[T]) TotalCount() Int64 {
+	return Int64(c.total)
+}
+
+// Edges resolves the nodes of the page with their cursors.
+func (c *connectionResolver[T]) Edges() []*edgeResolver[T] {
+	edges := make([]*edgeResolver[T], len(c.nodes))
+	for i, node := range c.nodes {
+		edges[i] = &edgeResolver[T]{cursor: c.cursors[i], node: node}
+	}
+	return edges
+}
+
+// Nodes resolves the nodes of the page.
+func (c *connectionResolver[T]) Nodes() []T {
+	return c.nodes
+}
+
+// PageInfo resolves the position of the page.
+func (c *connectionResolver[T]) PageInfo() *pageInfoResolver {
+	return &pageInfoResolver{endCursor: c.endCursor, hasNextPage: c.hasNextPage}
+}
+
+// Cursor resolves the cursor of the edge.
+func (e *edgeResolver[T]) Cursor() string {
+	return e.cursor
+}
+
+// Node resolves the node of the edge.
+func (e *edgeResolver[T]) Node() T {
+	return e.node
+}
+
+// HasNextPage resolves whether more nodes follow the page.
+func (p *pageInfoResolver) HasNextPage() bool {
+	return p.hasNextPage
+}
+
+// EndCursor resolves the cursor to pass as after to get the next page, or nil on the last page.
+func (p *pageInfoResolver) EndCursor() *string {
+	if p.endCursor == "" {
+		return nil
+	}
+	return &p.endCursor
+}
+
+// limitOf returns the limit of the List request of a page of first nodes.
+func limitOf(first *int32) (int64, error) {
+	if first == nil {
+		return defaultPageSize, nil
+	}
+	if *first < 0 {
+		return 0, errno.ErrInvalidArgument.WithMessage("first must not be negative")
+	}
+	return int64(*first), nil
+}
+
+// offsetOf decodes the offset of the after cursor of an offset paginated kind.
+func offsetOf(after *string) (int64, error) {
+	if after == nil || *after == "" {
+		return 0, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(*after)
+	if err != nil || !strings.HasPrefix(string(data), offsetCursorPrefix) {
+		return 0, errno.ErrInvalidArgument.WithMessage("invalid cursor %q", *after)
+	}
+	offset, err := strconv.ParseInt(strings.TrimPrefix(string(data), offsetCursorPrefix), 10, 64)
+	if err != nil || offset < 0 {
+		return 0, errno.ErrInvalidArgument.WithMessage("invalid cursor %q", *after)
+	}
+	return offset, nil
+}
@@ -0,0 +1,95 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"strings"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	{{- if .Web.WithOTel}}
+	gqlotel "github.com/graph-gophers/graphql-go/trace/otel"
+	{{- end}}
+	"github.com/onexstack/onexstack/pkg/errorsx"
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/biz"
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/pkg/validation"
+)
+
+// maxDepth is the maximum nesting depth of a query, which bounds the relations a query may follow.
+const maxDepth = 10
+
+// Handler serves the GraphQL endpoint over HTTP: it executes the query of the JSON body of a POST
+// request and writes the JSON response, whose errors carry the code and reason of the API errors
+// in their extensions.
+type Handler struct {
+	schema *graphql.Schema
+}
+
+// Ensure *Handler implements the http.Handler interface.
+var _ http.Handler = (*Handler)(nil)
+
+// NewHandler parses the schema of the endpoint and binds it to the resolvers.
+func NewHandler(biz biz.IBiz, val *validation.Validator) (*Handler, error) {
+	files, err := fs.Glob(schemaFS, "schema/*.graphql")
+	if err != nil {
+		return nil, err
+	}
+
+	var sdl strings.Builder
+	for _, name := range files {
+		data, err := schemaFS.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		sdl.Write(data)
+		sdl.WriteString("\n")
+	}
+
+	schema, err := graphql.ParseSchema(sdl.String(), &Resolver{biz: biz, val: val},
+		graphql.UseStringDescriptions(),
+		graphql.MaxDepth(maxDepth),
+		{{- if .Web.WithOTel}}
+		graphql.Tracer(gqlotel.DefaultTracer()),
+		{{- end}}
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse graphql schema: %w", err)
+	}
+	return &Handler{schema: schema}, nil
+}
+
+// ServeHTTP executes a GraphQL request. The DataLoaders of the relations live as long as the request.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Query         string         `json:"query"`
+		OperationName string         `json:"operationName"`
+		Variables     map[string]any `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		writeResponse(w, http.StatusBadRequest, &graphql.Response{
+			Errors: []*gqlerrors.QueryError{gqlerrors.Errorf("invalid request body: %v", err)},
+		})
+		return
+	}
+
+	resp := h.schema.Exec(withLoaders(r.Context()), params.Query, params.OperationName, params.Variables)
+	for _, qerr := range resp.Errors {
+		if qerr.ResolverError == nil {
+			continue
+		}
+		errx := errorsx.FromError(qerr.ResolverError)
+		qerr.Message = errx.Message
+		qerr.Extensions = map[string]any{"code": errx.Code, "reason": errx.Reason}
+	}
+	writeResponse(w, http.StatusOK, resp)
+}
+
+// writeResponse writes resp as JSON with the HTTP status code.
+func writeResponse(w http.ResponseWriter, status int, resp *graphql.Response) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,91 @@
+package graph
+
+import (
+	"context"
+	"sync"
+
+	"github.com/graph-gophers/dataloader/v7"
+	"golang.org/x/sync/errgroup"
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/store"
+	"{{.D.ModuleName}}/internal/pkg/known"
+)
+
+// maxRelatedItems is the maximum number of items that the DataLoader of a has_many relation lists
+// for all the keys of a batch.
+const maxRelatedItems = 1000
+
+// loadersKey is the context key of the DataLoaders of a request.
+type loadersKey struct{}
+
+// loaders holds the DataLoaders of a request by name. A DataLoader is created by the first resolver
+// that loads a key with it, and caches the loaded values until the end of the request.
+type loaders struct {
+	mu sync.Mutex
+	m  map[string]any
+}
+
+// withLoaders returns a copy of ctx that carries the DataLoaders of a request.
+func withLoaders(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loadersKey{}, &loaders{m: map[string]any{}})
+}
+
+// load loads the value of key with the DataLoader of the request named name, which passes the keys
+// loaded by the concurrent resolvers of a query to batch at once. Without DataLoaders in ctx, the
+// key is loaded on its own.
+func load[K comparable, V any](ctx context.Context, name string, key K, batch dataloader.BatchFunc[K, V]) (V, error) {
+	ls, ok := ctx.Value(loadersKey{}).(*loaders)
+	if !ok {
+		result := batch(ctx, []K{key})[0]
+		return result.Data, result.Error
+	}
+
+	ls.mu.Lock()
+	loader, ok := ls.m[name].(*dataloader.Loader[K, V])
+	if !ok {
+		loader = dataloader.NewBatchedLoader(batch, dataloader.WithBatchCapacity[K, V](store.MaxFilterValues))
+		ls.m[name] = loader
+	}
+	ls.mu.Unlock()
+
+	return loader.Load(ctx, key)()
+}
+
+// batchGet retrieves the values of the IDs of a batch concurrently with get.
+func batchGet[V any](ctx context.Context, ids []string, get func(context.Context, string) (V, error)) []*dataloader.Result[V] {
+	results := make([]*dataloader.Result[V], len(ids))
+
+	var eg errgroup.Group
+	eg.SetLimit(known.MaxErrGroupConcurrency)
+	for i, id := range ids {
+		eg.Go(func() error {
+			v, err := get(ctx, id)
+			results[i] = &dataloader.Result[V]{Data: v, Error: err}
+			return nil
+		})
+	}
+	_ = eg.Wait()
+
+	return results
+}
+
+// groupBy distributes the items listed for the keys of a batch to the keys they belong to, as
+// returned by keyOf. If the items could not be listed, err is returned for every key.
+func groupBy[V any](keys []string, items []V, err error, keyOf func(V) string) []*dataloader.Result[[]V] {
+	results := make([]*dataloader.Result[[]V], len(keys))
+	index := make(map[string]int, len(keys))
+	for i, key := range keys {
+		results[i] = &dataloader.Result[[]V]{Data: []V{}, Error: err}
+		index[key] = i
+	}
+	if err != nil {
+		return results
+	}
+
+	for _, item := range items {
+		if i, ok := index[keyOf(item)]; ok {
+			results[i].Data = append(results[i].Data, item)
+		}
+	}
+	return results
+}
@@ -0,0 +1,392 @@
+package graph
+
+{{- $r := .Web.R}}
+{{- $loadsMany := false}}
+{{- range $r.HasMany}}{{if $.Web.IsLoadable .}}{{$loadsMany = true}}{{end}}{{end}}
+
+import (
+	"context"
+
+	{{- if or (not $r.IsNested) $loadsMany}}
+	"github.com/graph-gophers/dataloader/v7"
+	{{- end}}
+	graphql "github.com/graph-gophers/graphql-go"
+	{{- if $r.HasTimeField}}
+	"google.golang.org/protobuf/types/known/timestamppb"
+	{{- end}}
+
+	{{.Web.APIImportPath}}
+)
+
+// {{$r.SingularLowerFirst}}Resolver resolves the fields of the {{$r.SingularName}} type.
+type {{$r.SingularLowerFirst}}Resolver struct {
+	root *Resolver
+	msg  *{{.D.APIAlias}}.{{$r.SingularName}}
+}
+
+// {{$r.IDField}} resolves the unique identifier of the {{$r.SingularLower}}.
+func (r *{{$r.SingularLowerFirst}}Resolver) {{$r.IDField}}() graphql.ID {
+	return graphql.ID(r.msg.Get{{$r.IDField}}())
+}
+
+// CreatedAt resolves the time the {{$r.SingularLower}} was created.
+func (r *{{$r.SingularLowerFirst}}Resolver) CreatedAt() *graphql.Time {
+	return timeOf(r.msg.GetCreatedAt())
+}
+
+// UpdatedAt resolves the time the {{$r.SingularLower}} was last updated.
+func (r *{{$r.SingularLowerFirst}}Resolver) UpdatedAt() *graphql.Time {
+	return timeOf(r.msg.GetUpdatedAt())
+}
+{{- range $r.Parents}}
+
+// {{.IDField}} resolves the identifier of the parent {{.SingularLower}}.
+func (r *{{$r.SingularLowerFirst}}Resolver) {{.IDField}}() graphql.ID {
+	return graphql.ID(r.msg.Get{{.IDField}}())
+}
+{{- end}}
+{{- range $r.Fields}}
+
+// {{.GoName}} resolves the {{.Label}} of the {{$r.SingularLower}}.
+func (r *{{$r.SingularLowerFirst}}Resolver) {{.GoName}}() {{if .IsTime}}*{{end}}{{.GraphQLGoType}} {
+	return {{.GraphQLValue (printf "r.msg.Get%s()" .GoName)}}
+}
+{{- end}}
+{{- range $r.BelongsTo}}
+
+// {{.ForeignKey}} resolves the identifier of the {{.Ref.SingularLower}} the {{$r.SingularLower}} belongs to.
+func (r *{{$r.SingularLowerFirst}}Resolver) {{.ForeignKey}}() graphql.ID {
+	return graphql.ID(r.msg.Get{{.ForeignKey}}())
+}
+{{- if $.Web.IsLoadable .}}
+
+// {{.GoName}} resolves the {{.Ref.SingularLower}} the {{$r.SingularLower}} belongs to, or null if it belongs to none.
+func (r *{{$r.SingularLowerFirst}}Resolver) {{.GoName}}(ctx context.Context) (*{{.Ref.SingularLowerFirst}}Resolver, error) {
+	if r.msg.Get{{.ForeignKey}}() == "" {
+		return nil, nil
+	}
+	{{.Ref.SingularLowerFirst}}, err := load(ctx, "{{.Ref.SingularLower}}", r.msg.Get{{.ForeignKey}}(), r.root.batchGet{{.Ref.SingularName}})
+	if err != nil {
+		return nil, err
+	}
+	return &{{.Ref.SingularLowerFirst}}Resolver{root: r.root, msg: {{.Ref.SingularLowerFirst}}}, nil
+}
+{{- end}}
+{{- end}}
+{{- range $r.HasMany}}
+{{- if $.Web.IsLoadable .}}
+
+// {{.GoName}} resolves the {{.Ref.PluralLower}} of the {{$r.SingularLower}}.
+func (r *{{$r.SingularLowerFirst}}Resolver) {{.GoName}}(ctx context.Context) ([]*{{.Ref.SingularLowerFirst}}Resolver, error) {
+	{{.Ref.PluralLowerFirst}}, err := load(ctx, "{{$r.SingularLower}}.{{.JSONName}}", r.msg.Get{{$r.IDField}}(), r.root.batchList{{$r.SingularName}}{{.GoName}})
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*{{.Ref.SingularLowerFirst}}Resolver, len({{.Ref.PluralLowerFirst}}))
+	for i, {{.Ref.SingularLowerFirst}} := range {{.Ref.PluralLowerFirst}} {
+		ret[i] = &{{.Ref.SingularLowerFirst}}Resolver{root: r.root, msg: {{.Ref.SingularLowerFirst}}}
+	}
+	return ret, nil
+}
+
+// batchList{{$r.SingularName}}{{.GoName}} lists the {{.Ref.PluralLower}} of the {{$r.PluralLower}} of a batch. At most
+// maxRelatedItems {{.Ref.PluralLower}} are listed for the whole batch.
+func (r *Resolver) batchList{{$r.SingularName}}{{.GoName}}(ctx context.Context, ids []string) []*dataloader.Result[[]*{{$.D.APIAlias}}.{{.Ref.SingularName}}] {
+	resp, err := r.biz.{{.Ref.BusinessFactoryName}}().List(ctx, &{{$.D.APIAlias}}.List{{.Ref.SingularName}}Request{ {{.Inverse.ForeignKey}}In: ids, Limit: maxRelatedItems})
+	return groupBy(ids, resp.Get{{.Ref.PluralName}}(), err, (*{{$.D.APIAlias}}.{{.Ref.SingularName}}).Get{{.Inverse.ForeignKey}})
+}
+{{- end}}
+{{- end}}
+{{- if $r.IsSoftDelete}}
+
+// DeletedAt resolves the time the {{$r.SingularLower}} was deleted, or null if it is not deleted.
+func (r *{{$r.SingularLowerFirst}}Resolver) DeletedAt() *graphql.Time {
+	return timeOf(r.msg.GetDeletedAt())
+}
+{{- end}}
+{{- if $r.HasResourceVersion}}
+
+// ResourceVersion resolves the version of the {{$r.SingularLower}}, which is incremented by every update.
+func (r *{{$r.SingularLowerFirst}}Resolver) ResourceVersion() Int64 {
+	return Int64(r.msg.GetResourceVersion())
+}
+{{- end}}
+{{- if $r.ListFilters}}
+
+// {{$r.SingularLowerFirst}}Filter is the {{$r.SingularName}}Filter input of the {{$r.PluralLowerFirst}} query.
+type {{$r.SingularLowerFirst}}Filter struct {
+	{{- range $r.BelongsTo}}
+	{{.ForeignKey}} *graphql.ID
+	{{- end}}
+	{{- range $r.ListFilters}}
+	{{.GoName}} {{.GraphQLGoType}}
+	{{- end}}
+}
+
+// apply sets the filters of a List{{$r.SingularName}}Request.
+func (f *{{$r.SingularLowerFirst}}Filter) apply(rq *{{.D.APIAlias}}.List{{$r.SingularName}}Request) {
+	if f == nil {
+		return
+	}
+	{{- range $r.BelongsTo}}
+	if f.{{.ForeignKey}} != nil {
+		rq.{{.ForeignKey}} = string(*f.{{.ForeignKey}})
+	}
+	{{- end}}
+	{{- range $r.ListFilters}}
+	if f.{{.GoName}} != nil {
+		rq.{{.GoName}} = {{if .IsRepeated}}{{.ProtoValue (printf "*f.%s" .GoName)}}{{else}}ptr({{.ProtoValue (printf "*f.%s" .GoName)}}){{end}}
+	}
+	{{- end}}
+}
+{{- end}}
+{{- if or $r.Parents $r.Fields $r.BelongsTo}}
+
+// create{{$r.SingularName}}Input is the Create{{$r.SingularName}}Input input of the create{{$r.SingularName}} mutation.
+type create{{$r.SingularName}}Input struct {
+	{{- range $r.Parents}}
+	{{.IDField}} graphql.ID
+	{{- end}}
+	{{- range $r.Fields}}
+	{{.GoName}} *{{.GraphQLGoType}}
+	{{- end}}
+	{{- range $r.BelongsTo}}
+	{{.ForeignKey}} *graphql.ID
+	{{- end}}
+}
+{{- end}}
+
+// update{{$r.SingularName}}Input is the Update{{$r.SingularName}}Input input of the update{{$r.SingularName}} mutation.
+type update{{$r.SingularName}}Input struct {
+	{{$r.IDField}} graphql.ID
+	{{- range $r.Parents}}
+	{{.IDField}} graphql.ID
+	{{- end}}
+	{{- range $r.Fields}}
+	{{.GoName}} *{{.GraphQLGoType}}
+	{{- end}}
+	{{- range $r.BelongsTo}}
+	{{.ForeignKey}} *graphql.ID
+	{{- end}}
+	{{- if $r.HasResourceVersion}}
+	ResourceVersion *Int64
+	{{- end}}
+}
+
+// {{$r.SingularName}} resolves the {{$r.SingularLowerFirst}} query.
+func (r *Resolver) {{$r.SingularName}}(ctx context.Context, args struct {
+	{{$r.IDField}} graphql.ID
+	{{- range $r.Parents}}
+	{{.IDField}} graphql.ID
+	{{- end}}
+	{{- if $r.IsSoftDelete}}
+	IncludeDeleted *bool
+	{{- end}}
+}) (*{{$r.SingularLowerFirst}}Resolver, error) {
+	rq := &{{.D.APIAlias}}.Get{{$r.SingularName}}Request{
+		{{$r.IDField}}: string(args.{{$r.IDField}}),
+		{{- range $r.Parents}}
+		{{.IDField}}: string(args.{{.IDField}}),
+		{{- end}}
+		{{- if $r.IsSoftDelete}}
+		IncludeDeleted: valueOf(args.IncludeDeleted),
+		{{- end}}
+	}
+	if err := r.val.ValidateGet{{$r.SingularName}}Request(ctx, rq); err != nil {
+		return nil, err
+	}
+	return r.get{{$r.SingularName}}(ctx, rq)
+}
+
+// {{$r.PluralName}} resolves the {{$r.PluralLowerFirst}} query.
+func (r *Resolver) {{$r.PluralName}}(ctx context.Context, args struct {
+	{{- range $r.Parents}}
+	{{.IDField}} graphql.ID
+	{{- end}}
+	First   *int32
+	After   *string
+	{{- if $r.ListFilters}}
+	Filter  *{{$r.SingularLowerFirst}}Filter
+	{{- end}}
+	OrderBy *string
+	{{- if $r.IsSoftDelete}}
+	IncludeDeleted *bool
+	{{- end}}
+}) (*connectionResolver[*{{$r.SingularLowerFirst}}Resolver], error) {
+	limit, err := limitOf(args.First)
+	if err != nil {
+		return nil, err
+	}
+	{{- if not $r.IsCursorPaginated}}
+	offset, err := offsetOf(args.After)
+	if err != nil {
+		return nil, err
+	}
+	{{- end}}
+
+	rq := &{{.D.APIAlias}}.List{{$r.SingularName}}Request{
+		{{- if $r.IsCursorPaginated}}
+		PageToken: valueOf(args.After),
+		{{- else}}
+		Offset:    offset,
+		{{- end}}
+		Limit:     limit,
+		{{- range $r.Parents}}
+		{{.IDField}}: string(args.{{.IDField}}),
+		{{- end}}
+		OrderBy:   valueOf(args.OrderBy),
+		{{- if $r.IsCursorPaginated}}
+		// The total is only counted if the query selects it.
+		SkipTotal: !graphql.HasSelectedField(ctx, "totalCount"),
+		{{- end}}
+		{{- if $r.IsSoftDelete}}
+		IncludeDeleted: valueOf(args.IncludeDeleted),
+		{{- end}}
+	}
+	{{- if $r.ListFilters}}
+	args.Filter.apply(rq)
+	{{- end}}
+	if err := r.val.ValidateList{{$r.SingularName}}Request(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	resp, err := r.biz.{{$r.BusinessFactoryName}}().List(ctx, rq)
+	if err != nil {
+		return nil, err
+	}
+	nodes := make([]*{{$r.SingularLowerFirst}}Resolver, len(resp.Get{{$r.Last.PluralName}}()))
+	for i, {{$r.Last.SingularLowerFirst}} := range resp.Get{{$r.Last.PluralName}}() {
+		nodes[i] = &{{$r.SingularLowerFirst}}Resolver{root: r, msg: {{$r.Last.SingularLowerFirst}}}
+	}
+	{{- if $r.IsCursorPaginated}}
+	return newCursorConnection(nodes, resp.GetTotal(), resp.GetNextPageToken()), nil
+	{{- else}}
+	return newOffsetConnection(nodes, resp.GetTotal(), offset), nil
+	{{- end}}
+}
+
+// Create{{$r.SingularName}} resolves the create{{$r.SingularName}} mutation.
+{{- if or $r.Parents $r.Fields $r.BelongsTo}}
+func (r *Resolver) Create{{$r.SingularName}}(ctx context.Context, args struct{ Input create{{$r.SingularName}}Input }) (*{{$r.SingularLowerFirst}}Resolver, error) {
+	in := args.Input
+	rq := &{{.D.APIAlias}}.Create{{$r.SingularName}}Request{
+		{{- range $r.Parents}}
+		{{.IDField}}: string(in.{{.IDField}}),
+		{{- end}}
+	}
+	{{- range $r.Fields}}
+	if in.{{.GoName}} != nil {
+		rq.{{.GoName}} = {{if .IsTime}}{{.ProtoValue (printf "in.%s" .GoName)}}{{else}}{{.ProtoValue (printf "*in.%s" .GoName)}}{{end}}
+	}
+	{{- end}}
+	{{- range $r.BelongsTo}}
+	if in.{{.ForeignKey}} != nil {
+		rq.{{.ForeignKey}} = string(*in.{{.ForeignKey}})
+	}
+	{{- end}}
+{{- else}}
+func (r *Resolver) Create{{$r.SingularName}}(ctx context.Context) (*{{$r.SingularLowerFirst}}Resolver, error) {
+	rq := &{{.D.APIAlias}}.Create{{$r.SingularName}}Request{}
+{{- end}}
+	if err := r.val.ValidateCreate{{$r.SingularName}}Request(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	resp, err := r.biz.{{$r.BusinessFactoryName}}().Create(ctx, rq)
+	if err != nil {
+		return nil, err
+	}
+	return r.get{{$r.SingularName}}(ctx, &{{.D.APIAlias}}.Get{{$r.SingularName}}Request{
+		{{$r.IDField}}: resp.Get{{$r.IDField}}(),
+		{{- range $r.Parents}}
+		{{.IDField}}: rq.{{.IDField}},
+		{{- end}}
+	})
+}
+
+// Update{{$r.SingularName}} resolves the update{{$r.SingularName}} mutation. Only the fields set in the input are updated.
+func (r *Resolver) Update{{$r.SingularName}}(ctx context.Context, args struct{ Input update{{$r.SingularName}}Input }) (*{{$r.SingularLowerFirst}}Resolver, error) {
+	in := args.Input
+	rq := &{{.D.APIAlias}}.Update{{$r.SingularName}}Request{
+		{{$r.IDField}}: string(in.{{$r.IDField}}),
+		{{- range $r.Parents}}
+		{{.IDField}}: string(in.{{.IDField}}),
+		{{- end}}
+	}
+	{{- range $r.Fields}}
+	if in.{{.GoName}} != nil {
+		{{- if .IsTime}}
+		rq.{{.GoName}} = {{.ProtoValue (printf "in.%s" .GoName)}}
+		{{- else if eq .ProtoType "bytes"}}
+		rq.{{.GoName}} = {{.ProtoValue (printf "*in.%s" .GoName)}}
+		{{- else}}
+		rq.{{.GoName}} = ptr({{.ProtoValue (printf "*in.%s" .GoName)}})
+		{{- end}}
+	}
+	{{- end}}
+	{{- range $r.BelongsTo}}
+	if in.{{.ForeignKey}} != nil {
+		rq.{{.ForeignKey}} = ptr(string(*in.{{.ForeignKey}}))
+	}
+	{{- end}}
+	{{- if $r.HasResourceVersion}}
+	if in.ResourceVersion != nil {
+		rq.ResourceVersion = int64(*in.ResourceVersion)
+	}
+	{{- end}}
+	if err := r.val.ValidateUpdate{{$r.SingularName}}Request(ctx, rq); err != nil {
+		return nil, err
+	}
+
+	if _, err := r.biz.{{$r.BusinessFactoryName}}().Update(ctx, rq); err != nil {
+		return nil, err
+	}
+	return r.get{{$r.SingularName}}(ctx, &{{.D.APIAlias}}.Get{{$r.SingularName}}Request{
+		{{$r.IDField}}: rq.{{$r.IDField}},
+		{{- range $r.Parents}}
+		{{.IDField}}: rq.{{.IDField}},
+		{{- end}}
+	})
+}
+
+// Delete{{$r.SingularName}} resolves the delete{{$r.SingularName}} mutation.
+func (r *Resolver) Delete{{$r.SingularName}}(ctx context.Context, args struct {
+	{{$r.IDField}} graphql.ID
+	{{- range $r.Parents}}
+	{{.IDField}} graphql.ID
+	{{- end}}
+}) (bool, error) {
+	rq := &{{.D.APIAlias}}.Delete{{$r.SingularName}}Request{
+		{{$r.IDField}}: string(args.{{$r.IDField}}),
+		{{- range $r.Parents}}
+		{{.IDField}}: string(args.{{.IDField}}),
+		{{- end}}
+	}
+	if err := r.val.ValidateDelete{{$r.SingularName}}Request(ctx, rq); err != nil {
+		return false, err
+	}
+
+	if _, err := r.biz.{{$r.BusinessFactoryName}}().Delete(ctx, rq); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// get{{$r.SingularName}} retrieves a {{$r.SingularLower}} from the biz layer.
+func (r *Resolver) get{{$r.SingularName}}(ctx context.Context, rq *{{.D.APIAlias}}.Get{{$r.SingularName}}Request) (*{{$r.SingularLowerFirst}}Resolver, error) {
+	resp, err := r.biz.{{$r.BusinessFactoryName}}().Get(ctx, rq)
+	if err != nil {
+		return nil, err
+	}
+	return &{{$r.SingularLowerFirst}}Resolver{root: r, msg: resp.Get{{$r.Last.SingularName}}()}, nil
+}
+{{- if not $r.IsNested}}
+
+// batchGet{{$r.SingularName}} retrieves the {{$r.PluralLower}} of the IDs of a batch, for the relations that belong to a {{$r.SingularLower}}.
+func (r *Resolver) batchGet{{$r.SingularName}}(ctx context.Context, ids []string) []*dataloader.Result[*{{.D.APIAlias}}.{{$r.SingularName}}] {
+	return batchGet(ctx, ids, func(ctx context.Context, id string) (*{{.D.APIAlias}}.{{$r.SingularName}}, error) {
+		resp, err := r.biz.{{$r.BusinessFactoryName}}().Get(ctx, &{{.D.APIAlias}}.Get{{$r.SingularName}}Request{ {{$r.IDField}}: id})
+		return resp.Get{{$r.Last.SingularName}}(), err
+	})
+}
+{{- end}}
@@ -0,0 +1,28 @@
+// Package graph implements the GraphQL endpoint of the server. Its schema is generated from the
+// kinds created by `osbuilder create api`, and its resolvers delegate to the biz layer.
+package graph
+
+import (
+	"embed"
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/biz"
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/pkg/validation"
+)
+
+// schemaFS holds the schema of the endpoint: schema.graphql declares the queries and mutations,
+// and every kind declares its types in a file of its own.
+//
+//go:embed schema/*.graphql
+var schemaFS embed.FS
+
+// Resolver is the root resolver of the schema: its methods resolve the fields of the Query and
+// Mutation types. Requests are validated like the handlers validate them, then passed to the biz layer.
+type Resolver struct {
+	biz biz.IBiz
+	val *validation.Validator
+}
+
+// APIVersion resolves the apiVersion query.
+func (r *Resolver) APIVersion() string {
+	return "{{.D.APIVersion}}"
+}
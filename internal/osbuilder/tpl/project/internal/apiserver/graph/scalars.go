@@ -0,0 +1,107 @@
+package graph
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strconv"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Int64 implements the Int64 scalar: an integer that may not fit in the 32 bits of Int. It is
+// serialized as a JSON number.
+type Int64 int64
+
+// ImplementsGraphQLType maps the Go type to the GraphQL scalar.
+func (Int64) ImplementsGraphQLType(name string) bool {
+	return name == "Int64"
+}
+
+// UnmarshalGraphQL decodes an Int64 literal or variable. Integers in strings are accepted as well,
+// since JSON numbers beyond 2^53 are not exact in every client.
+func (i *Int64) UnmarshalGraphQL(input any) error {
+	switch v := input.(type) {
+	case int32:
+		*i = Int64(v)
+	case int64:
+		*i = Int64(v)
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+			return fmt.Errorf("invalid Int64 %v", v)
+		}
+		*i = Int64(v)
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid Int64 %q", v)
+		}
+		*i = Int64(n)
+	default:
+		return fmt.Errorf("wrong type for Int64: %T", input)
+	}
+	return nil
+}
+
+// Bytes implements the Bytes scalar: binary data serialized as a standard base64 string.
+type Bytes []byte
+
+// ImplementsGraphQLType maps the Go type to the GraphQL scalar.
+func (Bytes) ImplementsGraphQLType(name string) bool {
+	return name == "Bytes"
+}
+
+// UnmarshalGraphQL decodes a base64 string.
+func (b *Bytes) UnmarshalGraphQL(input any) error {
+	s, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("wrong type for Bytes: %T", input)
+	}
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("invalid Bytes: %w", err)
+	}
+	*b = data
+	return nil
+}
+
+// timeOf converts a timestamp of the API messages to a Time, or nil if it is not set.
+func timeOf(ts *timestamppb.Timestamp) *graphql.Time {
+	if ts == nil {
+		return nil
+	}
+	return &graphql.Time{Time: ts.AsTime()}
+}
+
+// toIntegers converts the values of a list of Int64 to the integer type of a repeated request field.
+func toIntegers[T ~int64 | ~uint32 | ~uint64](values []Int64) []T {
+	ret := make([]T, len(values))
+	for i, v := range values {
+		ret[i] = T(v)
+	}
+	return ret
+}
+
+// toStrings converts a list of IDs to the identifiers of the API messages.
+func toStrings(ids []graphql.ID) []string {
+	ret := make([]string, len(ids))
+	for i, id := range ids {
+		ret[i] = string(id)
+	}
+	return ret
+}
+
+// ptr returns a pointer to v, to set the optional fields of the API messages.
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// valueOf returns the value of an optional argument, or its zero value if it is not given.
+func valueOf[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"connectrpc.com/connect"
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/biz"
+	{{.Web.APIImportPath}}
+)
+
+// Handler implements the {{.Web.GRPCServiceName}} service served by Connect. Its methods have the signatures of
+// the gRPC server interface, and are mounted as the unary procedures of the service on an HTTP mux,
+// which serves them to Connect, gRPC and gRPC-Web clients.
+type Handler struct {
+	{{.D.APIAlias}}.Unimplemented{{.Web.GRPCServiceName}}Server
+
+	biz biz.IBiz
+}
+
+// Ensure that Handler implements the {{.D.APIVersion}}.{{.Web.GRPCServiceName}}Server interface.
+var _ {{.D.APIVersion}}.{{.Web.GRPCServiceName}}Server = (*Handler)(nil)
+
+// Registrar is the function signature for procedure registration.
+type Registrar func(mux *http.ServeMux, h *Handler, opts ...connect.HandlerOption)
+
+// registrars stores all procedure registration functions.
+var registrars []Registrar
+
+// NewHandler creates a new instance of *Handler.
+func NewHandler(biz biz.IBiz) *Handler {
+	return &Handler{biz: biz}
+}
+
+// Register adds a procedure registration function to the registry.
+func Register(registrar Registrar) {
+	registrars = append(registrars, registrar)
+}
+
+// ApplyTo mounts all registered procedures on the mux, with the handler options, such as the
+// interceptors, applied to each of them.
+func (h *Handler) ApplyTo(mux *http.ServeMux, opts ...connect.HandlerOption) {
+	for _, registrar := range registrars {
+		registrar(mux, h, opts...)
+	}
+}
+
+// handle mounts fn as the unary procedure on the mux. The procedure is the full name of the gRPC
+// method, e.g., "/apiserver.v1.Apiserver/GetUser", which is also the path it is served on.
+func handle[Req, Res any](mux *http.ServeMux, procedure string, fn func(context.Context, *Req) (*Res, error), opts ...connect.HandlerOption) {
+	mux.Handle(procedure, connect.NewUnaryHandlerSimple(procedure, fn, opts...))
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	{{.Web.APIImportPath}}
+)
+
+// Healthz handles service health check requests.
+func (h *Handler) Healthz(ctx context.Context, rq *emptypb.Empty) (*{{.D.APIAlias}}.HealthzResponse, error) {
+	slog.InfoContext(ctx, "health check requested", "status", "healthy")
+	return &{{.D.APIAlias}}.HealthzResponse{
+		Status:    {{.D.APIAlias}}.ServiceStatus_Healthy,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}, nil
+}
+
+func init() {
+	Register(func(mux *http.ServeMux, handler *Handler, opts ...connect.HandlerOption) {
+		handle(mux, {{.D.APIAlias}}.{{.Web.GRPCServiceName}}_Healthz_FullMethodName, handler.Healthz, opts...)
+	})
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"connectrpc.com/connect"
+
+	{{.Web.APIImportPath}}
+)
+
+// Login logs in a user and returns a JWT Token.
+func (h *Handler) Login(ctx context.Context, rq *{{.D.APIAlias}}.LoginRequest) (*{{.D.APIAlias}}.LoginResponse, error) {
+	return h.biz.UserV1().Login(ctx, rq)
+}
+
+// RefreshToken refreshes the JWT Token.
+func (h *Handler) RefreshToken(ctx context.Context, rq *{{.D.APIAlias}}.RefreshTokenRequest) (*{{.D.APIAlias}}.RefreshTokenResponse, error) {
+	return h.biz.UserV1().RefreshToken(ctx, rq)
+}
+
+// ChangePassword changes the user's password.
+func (h *Handler) ChangePassword(ctx context.Context, rq *{{.D.APIAlias}}.ChangePasswordRequest) (*{{.D.APIAlias}}.ChangePasswordResponse, error) {
+	return h.biz.UserV1().ChangePassword(ctx, rq)
+}
+
+// CreateUser handles the creation of a new user.
+func (h *Handler) CreateUser(ctx context.Context, rq *{{.D.APIAlias}}.CreateUserRequest) (*{{.D.APIAlias}}.CreateUserResponse, error) {
+	return h.biz.UserV1().Create(ctx, rq)
+}
+
+// UpdateUser handles updating an existing user's details.
+func (h *Handler) UpdateUser(ctx context.Context, rq *{{.D.APIAlias}}.UpdateUserRequest) (*{{.D.APIAlias}}.UpdateUserResponse, error) {
+	return h.biz.UserV1().Update(ctx, rq)
+}
+
+// DeleteUser handles the deletion of a single user.
+func (h *Handler) DeleteUser(ctx context.Context, rq *{{.D.APIAlias}}.DeleteUserRequest) (*{{.D.APIAlias}}.DeleteUserResponse, error) {
+	return h.biz.UserV1().Delete(ctx, rq)
+}
+
+// GetUser retrieves information about a specific user.
+func (h *Handler) GetUser(ctx context.Context, rq *{{.D.APIAlias}}.GetUserRequest) (*{{.D.APIAlias}}.GetUserResponse, error) {
+	return h.biz.UserV1().Get(ctx, rq)
+}
+
+// ListUser retrieves a list of users based on query parameters.
+func (h *Handler) ListUser(ctx context.Context, rq *{{.D.APIAlias}}.ListUserRequest) (*{{.D.APIAlias}}.ListUserResponse, error) {
+	return h.biz.UserV1().List(ctx, rq)
+}
+
+func init() {
+	Register(func(mux *http.ServeMux, handler *Handler, opts ...connect.HandlerOption) {
+		handle(mux, {{.D.APIAlias}}.{{.Web.GRPCServiceName}}_Login_FullMethodName, handler.Login, opts...)
+		handle(mux, {{.D.APIAlias}}.{{.Web.GRPCServiceName}}_RefreshToken_FullMethodName, handler.RefreshToken, opts...)
+		handle(mux, {{.D.APIAlias}}.{{.Web.GRPCServiceName}}_ChangePassword_FullMethodName, handler.ChangePassword, opts...)
+		handle(mux, {{.D.APIAlias}}.{{.Web.GRPCServiceName}}_CreateUser_FullMethodName, handler.CreateUser, opts...)
+		handle(mux, {{.D.APIAlias}}.{{.Web.GRPCServiceName}}_UpdateUser_FullMethodName, handler.UpdateUser, opts...)
+		handle(mux, {{.D.APIAlias}}.{{.Web.GRPCServiceName}}_DeleteUser_FullMethodName, handler.DeleteUser, opts...)
+		handle(mux, {{.D.APIAlias}}.{{.Web.GRPCServiceName}}_GetUser_FullMethodName, handler.GetUser, opts...)
+		handle(mux, {{.D.APIAlias}}.{{.Web.GRPCServiceName}}_ListUser_FullMethodName, handler.ListUser, opts...)
+	})
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+
+	"{{.D.ModuleName}}/internal/pkg/errno"
+)
+
+// mergePatchPaths returns the fields of the JSON merge patch (RFC 7396) in the request body, which
+// become the update mask of the update: the fields in the patch are set, and a null value clears a
+// field. The body is left in place and is then bound as JSON, whatever its media type.
+func mergePatchPaths(c *gin.Context) ([]string, error) {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, errno.ErrInvalidArgument.WithMessage("failed to read merge patch: %v", err)
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	var patch map[string]json.RawMessage
+	if err := json.Unmarshal(body, &patch); err != nil || patch == nil {
+		return nil, errno.ErrInvalidArgument.WithMessage("merge patch must be a JSON object")
+	}
+
+	paths := make([]string, 0, len(patch))
+	for path := range patch {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
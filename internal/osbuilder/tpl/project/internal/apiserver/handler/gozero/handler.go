@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/onexstack/onexstack/pkg/errorsx"
+	"github.com/zeromicro/go-zero/core/logx"
+	"github.com/zeromicro/go-zero/rest"
+	"github.com/zeromicro/go-zero/rest/httpx"
+	"github.com/zeromicro/go-zero/rest/pathvar"
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/svc"
+	"{{.D.ModuleName}}/internal/pkg/errno"
+)
+
+// Registrar defines a function signature for registering the REST routes of a kind.
+type Registrar func(server *rest.Server, svcCtx *svc.ServiceContext)
+
+var registrars []Registrar
+
+// Register adds a new REST route registrar to the global registry.
+func Register(r Registrar) {
+	registrars = append(registrars, r)
+}
+
+// RegisterHandlers installs the registered REST routes on the go-zero REST server.
+func RegisterHandlers(server *rest.Server, svcCtx *svc.ServiceContext) {
+	{{- if .Web.WithHealthz}}
+	server.AddRoute(rest.Route{Method: http.MethodGet, Path: "/healthz", Handler: healthzHandler(svcCtx)})
+	{{end}}
+	for _, r := range registrars {
+		r(server, svcCtx)
+	}
+
+	logx.Infow("rest api routes installed", logx.Field("count", len(registrars)))
+}
+
+// bindFunc binds a part of an HTTP request to the request message of an API. The request messages
+// carry the `uri` and `form` tags of gin, so they are bound the same way as by the gin servers.
+type bindFunc func(r *http.Request, rq any) error
+
+// bindJSON binds the JSON body of the request.
+func bindJSON(r *http.Request, rq any) error {
+	return binding.JSON.Bind(r, rq)
+}
+
+// bindURI binds the path parameters of the request.
+func bindURI(r *http.Request, rq any) error {
+	params := map[string][]string{}
+	for key, value := range pathvar.Vars(r) {
+		params[key] = []string{value}
+	}
+	return binding.Uri.BindUri(params, rq)
+}
+
+// bindQuery binds the query parameters of the request.
+func bindQuery(r *http.Request, rq any) error {
+	return binding.Query.Bind(r, rq)
+}
+
+// bindAll binds the path and query parameters of the request, and its JSON body if it has one.
+func bindAll(r *http.Request, rq any) error {
+	if err := bindURI(r, rq); err != nil {
+		return err
+	}
+	if err := bindQuery(r, rq); err != nil {
+		return err
+	}
+	if r.ContentLength == 0 {
+		return nil
+	}
+	return bindJSON(r, rq)
+}
+
+// handle binds the request message of an API with bind, passes it to fn and writes the response
+// of fn as JSON.
+func handle[T, R any](w http.ResponseWriter, r *http.Request, bind bindFunc, fn func(*T) (R, error)) {
+	rq := new(T)
+	if err := bind(r, rq); err != nil {
+		writeError(w, r, errno.ErrBind.WithMessage(err.Error()))
+		return
+	}
+
+	resp, err := fn(rq)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	httpx.OkJsonCtx(r.Context(), w, resp)
+}
+
+// writeError writes err with the HTTP status code and the JSON body of its errorsx form, as the
+// other servers of the project do.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	errx := errorsx.FromError(err)
+	httpx.WriteJsonCtx(r.Context(), w, errx.Code, errx)
+}
@@ -0,0 +1,18 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest/httpx"
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/logic"
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/svc"
+)
+
+// healthzHandler handles service health check requests.
+func healthzHandler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp, _ := logic.NewHealthzLogic(r.Context(), svcCtx).Healthz()
+		httpx.OkJsonCtx(r.Context(), w, resp)
+	}
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/zeromicro/go-zero/rest"
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/logic"
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/svc"
+)
+
+// create{{.Web.R.SingularName}}Handler handles the HTTP request to create a new {{.Web.R.SingularLower}}.
+func create{{.Web.R.SingularName}}Handler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handle(w, r, {{if .Web.R.IsNested}}bindAll{{else}}bindJSON{{end}}, logic.New{{.Web.R.SingularName}}Logic(r.Context(), svcCtx).Create)
+	}
+}
+
+// update{{.Web.R.SingularName}}Handler handles the HTTP request to update an existing {{.Web.R.SingularLower}}'s details.
+func update{{.Web.R.SingularName}}Handler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handle(w, r, bindAll, logic.New{{.Web.R.SingularName}}Logic(r.Context(), svcCtx).Update)
+	}
+}
+
+// delete{{.Web.R.SingularName}}Handler handles the HTTP request to delete a single {{.Web.R.SingularLower}} specified by URI parameters.
+func delete{{.Web.R.SingularName}}Handler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handle(w, r, bindURI, logic.New{{.Web.R.SingularName}}Logic(r.Context(), svcCtx).Delete)
+	}
+}
+
+// delete{{.Web.R.PluralName}}Handler handles the HTTP request to delete a collection of {{.Web.R.PluralLower}} specified in the body.
+func delete{{.Web.R.PluralName}}Handler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handle(w, r, {{if .Web.R.IsNested}}bindAll{{else}}bindJSON{{end}}, logic.New{{.Web.R.SingularName}}Logic(r.Context(), svcCtx).DeleteCollection)
+	}
+}
+
+// get{{.Web.R.SingularName}}Handler retrieves details of a specific {{.Web.R.SingularLower}} based on the request parameters.
+func get{{.Web.R.SingularName}}Handler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handle(w, r, {{if .Web.R.IsSoftDelete}}bindAll{{else}}bindURI{{end}}, logic.New{{.Web.R.SingularName}}Logic(r.Context(), svcCtx).Get)
+	}
+}
+
+// list{{.Web.R.SingularName}}Handler retrieves a list of {{.Web.R.PluralLower}} based on query parameters.
+func list{{.Web.R.SingularName}}Handler(svcCtx *svc.ServiceContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		handle(w, r, {{if .Web.R.IsNested}}bindAll{{else}}bindQuery{{end}}, logic.New{{.Web.R.SingularName}}Logic(r.Context(), svcCtx).List)
+	}
+}
+
+func init() {
+	Register(func(server *rest.Server, svcCtx *svc.ServiceContext) {
+		server.AddRoutes([]rest.Route{
+			{Method: http.MethodPost, Path: "{{.Web.R.RoutePath}}", Handler: create{{.Web.R.SingularName}}Handler(svcCtx)},
+			{Method: http.MethodPut, Path: "{{.Web.R.RoutePath}}/:{{.Web.R.IDJSON}}", Handler: update{{.Web.R.SingularName}}Handler(svcCtx)},
+			{Method: http.MethodDelete, Path: "{{.Web.R.RoutePath}}/:{{.Web.R.IDJSON}}", Handler: delete{{.Web.R.SingularName}}Handler(svcCtx)},
+			{Method: http.MethodDelete, Path: "{{.Web.R.RoutePath}}", Handler: delete{{.Web.R.PluralName}}Handler(svcCtx)},
+			{Method: http.MethodGet, Path: "{{.Web.R.RoutePath}}/:{{.Web.R.IDJSON}}", Handler: get{{.Web.R.SingularName}}Handler(svcCtx)},
+			{Method: http.MethodGet, Path: "{{.Web.R.RoutePath}}", Handler: list{{.Web.R.SingularName}}Handler(svcCtx)},
+		}, rest.WithPrefix("/{{.D.APIVersion}}"))
+	})
+}
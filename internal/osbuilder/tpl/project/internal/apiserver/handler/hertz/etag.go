@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"{{.D.ModuleName}}/internal/pkg/errno"
+)
+
+// setETag exposes the resource version of a response as a strong ETag (e.g., "3").
+func setETag(c *app.RequestContext, resourceVersion int64) {
+	c.Header("ETag", strconv.Quote(strconv.FormatInt(resourceVersion, 10)))
+}
+
+// ifMatch returns the resource version an update is conditional on, read from the If-Match header.
+// It returns 0, which makes the update unconditional, if the header is absent or "*".
+func ifMatch(c *app.RequestContext) (int64, error) {
+	value := strings.TrimSpace(string(c.GetHeader("If-Match")))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+
+	resourceVersion, err := strconv.ParseInt(strings.Trim(value, `"`), 10, 64)
+	if err != nil || resourceVersion <= 0 {
+		return 0, errno.ErrInvalidArgument.WithMessage("invalid If-Match header %q: expected an ETag returned by the server", value)
+	}
+	return resourceVersion, nil
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/onexstack/onexstack/pkg/errorsx"
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/biz"
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/pkg/validation"
+	"{{.D.ModuleName}}/internal/pkg/errno"
+)
+
+// Handler manages the business logic for API requests and event processing.
+type Handler struct {
+	biz biz.IBiz
+	val *validation.Validator
+}
+
+// Registrar defines a function signature for registering HTTP routes.
+type Registrar func(v1 *route.RouterGroup, h *Handler, mws ...app.HandlerFunc)
+
+var registrars []Registrar
+
+// NewHandler creates a new instance of Handler.
+func NewHandler(biz biz.IBiz, val *validation.Validator) *Handler {
+	return &Handler{biz: biz, val: val}
+}
+
+// Register adds a new REST route registrar to the global registry.
+func Register(r Registrar) {
+	registrars = append(registrars, r)
+}
+
+// ApplyTo applies the registered REST API registrars to the provided Hertz router group.
+func (h *Handler) ApplyTo(v1 *route.RouterGroup, mws ...app.HandlerFunc) {
+	for _, r := range registrars {
+		r(v1, h, mws...)
+	}
+
+	slog.Info("rest api routes installed", "count", len(registrars))
+}
+
+// bindFunc binds a part of an HTTP request to the request message of an API. The request messages
+// carry the `uri` and `form` tags of gin, so they are bound the same way as by the gin servers.
+type bindFunc func(c *app.RequestContext, rq any) error
+
+// bindJSON binds the JSON body of the request.
+func bindJSON(c *app.RequestContext, rq any) error {
+	return binding.JSON.BindBody(c.Request.Body(), rq)
+}
+
+// bindURI binds the path parameters of the request.
+func bindURI(c *app.RequestContext, rq any) error {
+	params := map[string][]string{}
+	for _, param := range c.Params {
+		params[param.Key] = []string{param.Value}
+	}
+	return binding.Uri.BindUri(params, rq)
+}
+
+// bindQuery binds the query parameters of the request.
+func bindQuery(c *app.RequestContext, rq any) error {
+	values := map[string][]string{}
+	c.QueryArgs().VisitAll(func(key, value []byte) {
+		values[string(key)] = append(values[string(key)], string(value))
+	})
+	return binding.MapFormWithTag(rq, values, "form")
+}
+
+// bindAll binds the path and query parameters of the request, and its JSON body if it has one.
+func bindAll(c *app.RequestContext, rq any) error {
+	if err := bindURI(c, rq); err != nil {
+		return err
+	}
+	if err := bindQuery(c, rq); err != nil {
+		return err
+	}
+	if len(c.Request.Body()) == 0 {
+		return nil
+	}
+	return bindJSON(c, rq)
+}
+
+// handle binds the request message of an API with bind, validates it with validators, passes it
+// to fn and writes the response of fn as JSON, as the Handle*Request functions of the gin servers do.
+func handle[T, R any](ctx context.Context, c *app.RequestContext, bind bindFunc, fn func(context.Context, *T) (R, error), validators ...func(context.Context, *T) error) {
+	rq := new(T)
+	if err := bind(c, rq); err != nil {
+		writeResponse(c, nil, errno.ErrBind.WithMessage("%s", err.Error()))
+		return
+	}
+
+	for _, validate := range validators {
+		if err := validate(ctx, rq); err != nil {
+			writeResponse(c, nil, err)
+			return
+		}
+	}
+
+	resp, err := fn(ctx, rq)
+	writeResponse(c, resp, err)
+}
+
+// writeResponse writes data as JSON, or err with the HTTP status code and the JSON body of its
+// errorsx form if it is not nil.
+func writeResponse(c *app.RequestContext, data any, err error) {
+	if err != nil {
+		errx := errorsx.FromError(err)
+		c.JSON(errx.Code, errx)
+		return
+	}
+	c.JSON(http.StatusOK, data)
+}
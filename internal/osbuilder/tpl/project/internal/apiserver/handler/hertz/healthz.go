@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	{{.Web.APIImportPath}}
+)
+
+// Healthz handles service health check requests.
+func (h *Handler) Healthz(ctx context.Context, c *app.RequestContext) {
+	slog.InfoContext(ctx, "health check requested", "status", "healthy")
+	writeResponse(c, &{{.D.APIAlias}}.HealthzResponse{
+		Status:    {{.D.APIAlias}}.ServiceStatus_Healthy,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}, nil)
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/route"
+	{{- if .Web.WithOTel}}
+	"go.opentelemetry.io/otel"
+	{{- end}}
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	{{if .Web.WithOTel -}}
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/pkg/metrics"
+	{{end -}}
+	{{.Web.APIImportPath}}
+)
+
+// Create{{.Web.R.SingularName}} handles the HTTP request to create a new {{.Web.R.SingularLower}}.
+func (h *Handler) Create{{.Web.R.SingularName}}(ctx context.Context, c *app.RequestContext) {
+	{{- if .Web.WithOTel}}
+	ctx, span := otel.Tracer("handler").Start(ctx, "Handler.Create{{.Web.R.SingularName}}")
+	defer span.End()
+
+	metrics.M.RecordResourceCreate(ctx, "{{.Web.R.SingularLower}}")
+	{{- end}}
+
+	slog.InfoContext(ctx, "processing {{.Web.R.SingularLower}} creation request")
+
+	handle(ctx, c, {{if .Web.R.IsNested}}bindAll{{else}}bindJSON{{end}}, h.biz.{{.Web.R.BusinessFactoryName}}().Create, h.val.ValidateCreate{{.Web.R.SingularName}}Request)
+}
+
+// Update{{.Web.R.SingularName}} handles the HTTP request to update an existing {{.Web.R.SingularLower}}'s details.
+func (h *Handler) Update{{.Web.R.SingularName}}(ctx context.Context, c *app.RequestContext) {
+	h.update{{.Web.R.SingularName}}(ctx, c, nil)
+}
+
+// Patch{{.Web.R.SingularName}} handles a JSON merge patch (RFC 7396) of an existing {{.Web.R.SingularLower}}: only the fields
+// in the patch are updated, and a null value clears a field.
+func (h *Handler) Patch{{.Web.R.SingularName}}(ctx context.Context, c *app.RequestContext) {
+	updateMask, err := mergePatchPaths(c)
+	if err != nil {
+		writeResponse(c, nil, err)
+		return
+	}
+	h.update{{.Web.R.SingularName}}(ctx, c, updateMask)
+}
+
+// update{{.Web.R.SingularName}} updates a {{.Web.R.SingularLower}}, limited to the fields of updateMask if it is not nil.
+{{- if .Web.R.HasResourceVersion}}
+// The update is conditional on the resource version in the If-Match header, and the ETag header
+// of the response holds the version of the updated {{.Web.R.SingularLower}}.
+{{- end}}
+func (h *Handler) update{{.Web.R.SingularName}}(ctx context.Context, c *app.RequestContext, updateMask []string) {
+	{{- if .Web.R.HasResourceVersion}}
+	resourceVersion, err := ifMatch(c)
+	if err != nil {
+		writeResponse(c, nil, err)
+		return
+	}
+	{{- end}}
+
+	handle(ctx, c, bindAll, {{if .Web.R.HasResourceVersion -}}
+	func(ctx context.Context, rq *{{.D.APIAlias}}.Update{{.Web.R.SingularName}}Request) (*{{.D.APIAlias}}.Update{{.Web.R.SingularName}}Response, error) {
+		resp, err := h.biz.{{.Web.R.BusinessFactoryName}}().Update(ctx, rq)
+		if err == nil {
+			setETag(c, resp.GetResourceVersion())
+		}
+		return resp, err
+	}
+	{{- else -}}
+	h.biz.{{.Web.R.BusinessFactoryName}}().Update
+	{{- end}}, func(ctx context.Context, rq *{{.D.APIAlias}}.Update{{.Web.R.SingularName}}Request) error {
+		// The request is completed before it is validated, so that the update mask is validated as well.
+		if updateMask != nil {
+			rq.UpdateMask = &fieldmaskpb.FieldMask{Paths: updateMask}
+		}
+		{{- if .Web.R.HasResourceVersion}}
+		if resourceVersion != 0 {
+			rq.ResourceVersion = resourceVersion
+		}
+		{{- end}}
+		return h.val.ValidateUpdate{{.Web.R.SingularName}}Request(ctx, rq)
+	})
+}
+
+// Delete{{.Web.R.SingularName}} handles the HTTP request to delete a single {{.Web.R.SingularLower}} specified by URI parameters.
+func (h *Handler) Delete{{.Web.R.SingularName}}(ctx context.Context, c *app.RequestContext) {
+	handle(ctx, c, bindURI, h.biz.{{.Web.R.BusinessFactoryName}}().Delete, h.val.ValidateDelete{{.Web.R.SingularName}}Request)
+}
+
+// Delete{{.Web.R.PluralName}} handles the HTTP request to delete a collection of {{.Web.R.PluralLower}} specified in the body.
+func (h *Handler) Delete{{.Web.R.PluralName}}(ctx context.Context, c *app.RequestContext) {
+	handle(ctx, c, {{if .Web.R.IsNested}}bindAll{{else}}bindJSON{{end}}, h.biz.{{.Web.R.BusinessFactoryName}}().DeleteCollection, h.val.ValidateDelete{{.Web.R.PluralName}}Request)
+}
+
+// Get{{.Web.R.SingularName}} retrieves details of a specific {{.Web.R.SingularLower}} based on the request parameters.
+{{- if .Web.R.HasResourceVersion}}
+// The ETag header of the response holds the resource version of the {{.Web.R.SingularLower}}.
+{{- end}}
+func (h *Handler) Get{{.Web.R.SingularName}}(ctx context.Context, c *app.RequestContext) {
+	{{- if .Web.WithOTel}}
+	ctx, span := otel.Tracer("handler").Start(ctx, "Handler.Get{{.Web.R.SingularName}}")
+	defer span.End()
+
+	metrics.M.RecordResourceGet(ctx, "{{.Web.R.SingularLower}}")
+	{{- end}}
+
+	slog.InfoContext(ctx, "processing {{.Web.R.SingularLower}} retrieve request")
+
+	{{if .Web.R.HasResourceVersion -}}
+	handle(ctx, c, {{if .Web.R.IsSoftDelete}}bindAll{{else}}bindURI{{end}}, func(ctx context.Context, rq *{{.D.APIAlias}}.Get{{.Web.R.SingularName}}Request) (*{{.D.APIAlias}}.Get{{.Web.R.SingularName}}Response, error) {
+		resp, err := h.biz.{{.Web.R.BusinessFactoryName}}().Get(ctx, rq)
+		if err == nil {
+			setETag(c, resp.Get{{.Web.R.Last.SingularName}}().GetResourceVersion())
+		}
+		return resp, err
+	}, h.val.ValidateGet{{.Web.R.SingularName}}Request)
+	{{- else -}}
+	handle(ctx, c, {{if .Web.R.IsSoftDelete}}bindAll{{else}}bindURI{{end}}, h.biz.{{.Web.R.BusinessFactoryName}}().Get, h.val.ValidateGet{{.Web.R.SingularName}}Request)
+	{{- end}}
+}
+
+// List{{.Web.R.SingularName}} retrieves a list of {{.Web.R.PluralLower}} based on query parameters.
+func (h *Handler) List{{.Web.R.SingularName}}(ctx context.Context, c *app.RequestContext) {
+	handle(ctx, c, {{if .Web.R.IsNested}}bindAll{{else}}bindQuery{{end}}, h.biz.{{.Web.R.BusinessFactoryName}}().List, h.val.ValidateList{{.Web.R.SingularName}}Request)
+}
+
+func init() {
+	Register(func(v1 *route.RouterGroup, handler *Handler, mws ...app.HandlerFunc) {
+		rg := v1.Group("{{.Web.R.RoutePath}}", mws...)
+		rg.POST("", handler.Create{{.Web.R.SingularName}})
+		rg.PUT(":{{.Web.R.Last.SingularLowerFirst}}ID", handler.Update{{.Web.R.SingularName}})
+		rg.PATCH(":{{.Web.R.Last.SingularLowerFirst}}ID", handler.Patch{{.Web.R.SingularName}})
+		rg.DELETE(":{{.Web.R.Last.SingularLowerFirst}}ID", handler.Delete{{.Web.R.SingularName}})
+		rg.DELETE("", handler.Delete{{.Web.R.PluralName}})
+		rg.GET(":{{.Web.R.Last.SingularLowerFirst}}ID", handler.Get{{.Web.R.SingularName}})
+		rg.GET("", handler.List{{.Web.R.SingularName}})
+	})
+}
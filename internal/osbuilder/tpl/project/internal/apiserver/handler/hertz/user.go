@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/route"
+	{{- if .Web.WithOTel}}
+	"go.opentelemetry.io/otel"
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/pkg/metrics"
+	{{- end}}
+)
+
+// Login logs in a user and returns a JWT Token.
+func (h *Handler) Login(ctx context.Context, c *app.RequestContext) {
+	handle(ctx, c, bindJSON, h.biz.UserV1().Login, h.val.ValidateLoginRequest)
+}
+
+// RefreshToken refreshes the JWT Token.
+func (h *Handler) RefreshToken(ctx context.Context, c *app.RequestContext) {
+	handle(ctx, c, bindJSON, h.biz.UserV1().RefreshToken)
+}
+
+// ChangePassword changes the user's password.
+func (h *Handler) ChangePassword(ctx context.Context, c *app.RequestContext) {
+	handle(ctx, c, bindAll, h.biz.UserV1().ChangePassword, h.val.ValidateChangePasswordRequest)
+}
+
+// CreateUser handles the HTTP request to create a new user.
+func (h *Handler) CreateUser(ctx context.Context, c *app.RequestContext) {
+	{{- if .Web.WithOTel}}
+	ctx, span := otel.Tracer("handler").Start(ctx, "Handler.CreateUser")
+	defer span.End()
+
+	metrics.M.RecordResourceCreate(ctx, "user")
+	{{- end}}
+
+	slog.InfoContext(ctx, "processing user creation request")
+
+	handle(ctx, c, bindJSON, h.biz.UserV1().Create, h.val.ValidateCreateUserRequest)
+}
+
+// UpdateUser handles the HTTP request to update an existing user's details.
+func (h *Handler) UpdateUser(ctx context.Context, c *app.RequestContext) {
+	handle(ctx, c, bindAll, h.biz.UserV1().Update, h.val.ValidateUpdateUserRequest)
+}
+
+// DeleteUser handles the HTTP request to delete a single user specified by URI parameters.
+func (h *Handler) DeleteUser(ctx context.Context, c *app.RequestContext) {
+	handle(ctx, c, bindURI, h.biz.UserV1().Delete, h.val.ValidateDeleteUserRequest)
+}
+
+// GetUser retrieves details of a specific user based on the request parameters.
+func (h *Handler) GetUser(ctx context.Context, c *app.RequestContext) {
+	{{- if .Web.WithOTel}}
+	ctx, span := otel.Tracer("handler").Start(ctx, "Handler.GetUser")
+	defer span.End()
+
+	metrics.M.RecordResourceGet(ctx, "user")
+	{{- end}}
+
+	slog.InfoContext(ctx, "processing user retrieve request", "layer", "handler")
+
+	handle(ctx, c, bindURI, h.biz.UserV1().Get, h.val.ValidateGetUserRequest)
+}
+
+// ListUser retrieves a list of users based on query parameters.
+func (h *Handler) ListUser(ctx context.Context, c *app.RequestContext) {
+	handle(ctx, c, bindQuery, h.biz.UserV1().List, h.val.ValidateListUserRequest)
+}
+
+func init() {
+	Register(func(v1 *route.RouterGroup, handler *Handler, mws ...app.HandlerFunc) {
+		rg := v1.Group("/users")
+		rg.POST("", handler.CreateUser)
+		rg.Use(mws...)
+		rg.PUT(":userID/change-password", handler.ChangePassword)
+		rg.PUT(":userID", handler.UpdateUser)
+		rg.DELETE(":userID", handler.DeleteUser)
+		rg.GET(":userID", handler.GetUser)
+		rg.GET("", handler.ListUser)
+	})
+}
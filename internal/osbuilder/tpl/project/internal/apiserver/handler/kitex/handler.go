@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/biz"
+	{{.Web.APIImportPath}}
+)
+
+// Handler implements the {{.Web.GRPCServiceName}} service served by Kitex. Its methods have the signatures of
+// the gRPC server interface, and are dispatched to by the service info of the kitexsvc package.
+type Handler struct {
+	{{.D.APIAlias}}.Unimplemented{{.Web.GRPCServiceName}}Server
+
+	biz biz.IBiz
+}
+
+// Ensure that Handler implements the {{.D.APIVersion}}.{{.Web.GRPCServiceName}}Server interface.
+var _ {{.D.APIVersion}}.{{.Web.GRPCServiceName}}Server = (*Handler)(nil)
+
+// NewHandler creates a new instance of *Handler.
+func NewHandler(biz biz.IBiz) *Handler {
+	return &Handler{biz: biz}
+}
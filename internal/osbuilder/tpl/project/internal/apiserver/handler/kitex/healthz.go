@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	{{.Web.APIImportPath}}
+)
+
+// Healthz handles service health check requests.
+func (h *Handler) Healthz(ctx context.Context, rq *emptypb.Empty) (*{{.D.APIAlias}}.HealthzResponse, error) {
+	slog.InfoContext(ctx, "health check requested", "status", "healthy")
+	return &{{.D.APIAlias}}.HealthzResponse{
+		Status:    {{.D.APIAlias}}.ServiceStatus_Healthy,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}, nil
+}
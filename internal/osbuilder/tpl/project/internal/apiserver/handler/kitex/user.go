@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"context"
+
+	{{.Web.APIImportPath}}
+)
+
+// Login logs in a user and returns a JWT Token.
+func (h *Handler) Login(ctx context.Context, rq *{{.D.APIAlias}}.LoginRequest) (*{{.D.APIAlias}}.LoginResponse, error) {
+	return h.biz.UserV1().Login(ctx, rq)
+}
+
+// RefreshToken refreshes the JWT Token.
+func (h *Handler) RefreshToken(ctx context.Context, rq *{{.D.APIAlias}}.RefreshTokenRequest) (*{{.D.APIAlias}}.RefreshTokenResponse, error) {
+	return h.biz.UserV1().RefreshToken(ctx, rq)
+}
+
+// ChangePassword changes the user's password.
+func (h *Handler) ChangePassword(ctx context.Context, rq *{{.D.APIAlias}}.ChangePasswordRequest) (*{{.D.APIAlias}}.ChangePasswordResponse, error) {
+	return h.biz.UserV1().ChangePassword(ctx, rq)
+}
+
+// CreateUser handles the creation of a new user.
+func (h *Handler) CreateUser(ctx context.Context, rq *{{.D.APIAlias}}.CreateUserRequest) (*{{.D.APIAlias}}.CreateUserResponse, error) {
+	return h.biz.UserV1().Create(ctx, rq)
+}
+
+// UpdateUser handles updating an existing user's details.
+func (h *Handler) UpdateUser(ctx context.Context, rq *{{.D.APIAlias}}.UpdateUserRequest) (*{{.D.APIAlias}}.UpdateUserResponse, error) {
+	return h.biz.UserV1().Update(ctx, rq)
+}
+
+// DeleteUser handles the deletion of a single user.
+func (h *Handler) DeleteUser(ctx context.Context, rq *{{.D.APIAlias}}.DeleteUserRequest) (*{{.D.APIAlias}}.DeleteUserResponse, error) {
+	return h.biz.UserV1().Delete(ctx, rq)
+}
+
+// GetUser retrieves information about a specific user.
+func (h *Handler) GetUser(ctx context.Context, rq *{{.D.APIAlias}}.GetUserRequest) (*{{.D.APIAlias}}.GetUserResponse, error) {
+	return h.biz.UserV1().Get(ctx, rq)
+}
+
+// ListUser retrieves a list of users based on query parameters.
+func (h *Handler) ListUser(ctx context.Context, rq *{{.D.APIAlias}}.ListUserRequest) (*{{.D.APIAlias}}.ListUserResponse, error) {
+	return h.biz.UserV1().List(ctx, rq)
+}
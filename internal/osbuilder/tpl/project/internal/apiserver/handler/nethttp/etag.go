@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"{{.D.ModuleName}}/internal/pkg/errno"
+)
+
+// setETag exposes the resource version of a response as a strong ETag (e.g., "3").
+func setETag(w http.ResponseWriter, resourceVersion int64) {
+	w.Header().Set("ETag", strconv.Quote(strconv.FormatInt(resourceVersion, 10)))
+}
+
+// ifMatch returns the resource version an update is conditional on, read from the If-Match header.
+// It returns 0, which makes the update unconditional, if the header is absent or "*".
+func ifMatch(r *http.Request) (int64, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+
+	resourceVersion, err := strconv.ParseInt(strings.Trim(value, `"`), 10, 64)
+	if err != nil || resourceVersion <= 0 {
+		return 0, errno.ErrInvalidArgument.WithMessage("invalid If-Match header %q: expected an ETag returned by the server", value)
+	}
+	return resourceVersion, nil
+}
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/onexstack/onexstack/pkg/errorsx"
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/biz"
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/pkg/validation"
+	"{{.D.ModuleName}}/internal/pkg/errno"
+)
+
+// Handler manages the business logic for API requests and event processing.
+type Handler struct {
+	biz biz.IBiz
+	val *validation.Validator
+}
+
+// Registrar defines a function signature for registering HTTP routes.
+type Registrar func(v1 *RouterGroup, h *Handler, mws ...Middleware)
+
+var registrars []Registrar
+
+// NewHandler creates a new instance of Handler.
+func NewHandler(biz biz.IBiz, val *validation.Validator) *Handler {
+	return &Handler{biz: biz, val: val}
+}
+
+// Register adds a new REST route registrar to the global registry.
+func Register(r Registrar) {
+	registrars = append(registrars, r)
+}
+
+// ApplyTo applies the registered REST API registrars to the provided router group.
+func (h *Handler) ApplyTo(v1 *RouterGroup, mws ...Middleware) {
+	for _, r := range registrars {
+		r(v1, h, mws...)
+	}
+
+	slog.Info("rest api routes installed", "count", len(registrars))
+}
+
+// bindFunc binds a part of an HTTP request to the request message of an API. The request messages
+// carry the `uri` and `form` tags of gin, so they are bound the same way as by the gin servers.
+type bindFunc func(r *http.Request, rq any) error
+
+// bindJSON binds the JSON body of the request.
+func bindJSON(r *http.Request, rq any) error {
+	return json.NewDecoder(r.Body).Decode(rq)
+}
+
+// bindURI binds the path parameters of the request.
+func bindURI(r *http.Request, rq any) error {
+	return bindValues(rq, "uri", func(name string) []string {
+		if value := r.PathValue(name); value != "" {
+			return []string{value}
+		}
+		return nil
+	})
+}
+
+// bindQuery binds the query parameters of the request.
+func bindQuery(r *http.Request, rq any) error {
+	query := r.URL.Query()
+	return bindValues(rq, "form", func(name string) []string {
+		return query[name]
+	})
+}
+
+// bindAll binds the path and query parameters of the request, and its JSON body if it has one.
+func bindAll(r *http.Request, rq any) error {
+	if err := bindURI(r, rq); err != nil {
+		return err
+	}
+	if err := bindQuery(r, rq); err != nil {
+		return err
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil || len(body) == 0 {
+		return err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return bindJSON(r, rq)
+}
+
+// bindValues sets the fields of rq that carry tag to the values returned by lookup for the tag.
+func bindValues(rq any, tag string, lookup func(name string) []string) error {
+	v := reflect.ValueOf(rq).Elem()
+	for i := range v.NumField() {
+		name := v.Type().Field(i).Tag.Get(tag)
+		if name == "" {
+			continue
+		}
+		values := lookup(name)
+		if len(values) == 0 {
+			continue
+		}
+		if err := setField(v.Field(i), values); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", tag, name, err)
+		}
+	}
+	return nil
+}
+
+// setField sets a singular, optional (pointer) or repeated (slice) field from its values.
+func setField(field reflect.Value, values []string) error {
+	switch field.Kind() {
+	case reflect.Slice:
+		slice := reflect.MakeSlice(field.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setScalar(slice.Index(i), value); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+	case reflect.Pointer:
+		ptr := reflect.New(field.Type().Elem())
+		if err := setScalar(ptr.Elem(), values[0]); err != nil {
+			return err
+		}
+		field.Set(ptr)
+	default:
+		return setScalar(field, values[0])
+	}
+	return nil
+}
+
+// setScalar sets a string, boolean or numeric field (including enums) from its value.
+func setScalar(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
+
+// handle binds the request message of an API with bind, validates it with validators, passes it
+// to fn and writes the response of fn as JSON, as the Handle*Request functions of the gin servers do.
+func handle[T, R any](w http.ResponseWriter, r *http.Request, bind bindFunc, fn func(context.Context, *T) (R, error), validators ...func(context.Context, *T) error) {
+	rq := new(T)
+	if err := bind(r, rq); err != nil {
+		writeResponse(w, nil, errno.ErrBind.WithMessage("%s", err.Error()))
+		return
+	}
+
+	for _, validate := range validators {
+		if err := validate(r.Context(), rq); err != nil {
+			writeResponse(w, nil, err)
+			return
+		}
+	}
+
+	resp, err := fn(r.Context(), rq)
+	writeResponse(w, resp, err)
+}
+
+// writeResponse writes data as JSON, or err with the HTTP status code and the JSON body of its
+// errorsx form if it is not nil.
+func writeResponse(w http.ResponseWriter, data any, err error) {
+	status := http.StatusOK
+	if err != nil {
+		errx := errorsx.FromError(err)
+		status, data = errx.Code, errx
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(data)
+}
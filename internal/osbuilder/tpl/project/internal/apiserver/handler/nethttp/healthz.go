@@ -0,0 +1,18 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	{{.Web.APIImportPath}}
+)
+
+// Healthz handles service health check requests.
+func (h *Handler) Healthz(w http.ResponseWriter, r *http.Request) {
+	slog.InfoContext(r.Context(), "health check requested", "status", "healthy")
+	writeResponse(w, &{{.D.APIAlias}}.HealthzResponse{
+		Status:    {{.D.APIAlias}}.ServiceStatus_Healthy,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}, nil)
+}
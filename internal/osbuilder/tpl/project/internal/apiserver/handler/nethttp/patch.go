@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+
+	"{{.D.ModuleName}}/internal/pkg/errno"
+)
+
+// mergePatchPaths returns the fields of the JSON merge patch (RFC 7396) in the request body, which
+// become the update mask of the update: the fields in the patch are set, and a null value clears a
+// field. The body is left in place and is then bound as JSON, whatever its media type.
+func mergePatchPaths(r *http.Request) ([]string, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, errno.ErrInvalidArgument.WithMessage("failed to read merge patch: %v", err)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	var patch map[string]json.RawMessage
+	if err := json.Unmarshal(body, &patch); err != nil || patch == nil {
+		return nil, errno.ErrInvalidArgument.WithMessage("merge patch must be a JSON object")
+	}
+
+	paths := make([]string, 0, len(patch))
+	for path := range patch {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
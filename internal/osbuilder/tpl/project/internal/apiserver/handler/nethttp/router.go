@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"net/http"
+	"slices"
+)
+
+// Middleware wraps an http.Handler with logic that runs around it.
+type Middleware = func(http.Handler) http.Handler
+
+// RouterGroup registers routes on an http.ServeMux under a common path prefix and a common chain
+// of middlewares, in the way of the router groups of gin. The routes are registered as Go 1.22
+// ServeMux patterns, e.g. "GET /v1/posts/{postID}".
+type RouterGroup struct {
+	mux    *http.ServeMux
+	prefix string
+	mws    []Middleware
+}
+
+// NewRouterGroup creates a router group that registers routes under prefix on mux.
+func NewRouterGroup(mux *http.ServeMux, prefix string, mws ...Middleware) *RouterGroup {
+	return &RouterGroup{mux: mux, prefix: prefix, mws: mws}
+}
+
+// Group creates a child group whose routes are under the path of the group followed by prefix,
+// and which runs mws after the middlewares of the group.
+func (g *RouterGroup) Group(prefix string, mws ...Middleware) *RouterGroup {
+	return &RouterGroup{mux: g.mux, prefix: g.prefix + prefix, mws: append(slices.Clone(g.mws), mws...)}
+}
+
+// Use adds mws to the middlewares of the routes registered on the group from now on.
+func (g *RouterGroup) Use(mws ...Middleware) {
+	g.mws = append(g.mws, mws...)
+}
+
+// Handle registers handler for the requests with method whose path is the path of the group
+// followed by path, e.g. "/{postID}".
+func (g *RouterGroup) Handle(method, path string, handler http.HandlerFunc) {
+	var h http.Handler = handler
+	for i := len(g.mws) - 1; i >= 0; i-- {
+		h = g.mws[i](h)
+	}
+	g.mux.Handle(method+" "+g.prefix+path, h)
+}
+
+// POST is a shortcut for g.Handle(http.MethodPost, path, handler).
+func (g *RouterGroup) POST(path string, handler http.HandlerFunc) {
+	g.Handle(http.MethodPost, path, handler)
+}
+
+// PUT is a shortcut for g.Handle(http.MethodPut, path, handler).
+func (g *RouterGroup) PUT(path string, handler http.HandlerFunc) {
+	g.Handle(http.MethodPut, path, handler)
+}
+
+// PATCH is a shortcut for g.Handle(http.MethodPatch, path, handler).
+func (g *RouterGroup) PATCH(path string, handler http.HandlerFunc) {
+	g.Handle(http.MethodPatch, path, handler)
+}
+
+// DELETE is a shortcut for g.Handle(http.MethodDelete, path, handler).
+func (g *RouterGroup) DELETE(path string, handler http.HandlerFunc) {
+	g.Handle(http.MethodDelete, path, handler)
+}
+
+// GET is a shortcut for g.Handle(http.MethodGet, path, handler).
+func (g *RouterGroup) GET(path string, handler http.HandlerFunc) {
+	g.Handle(http.MethodGet, path, handler)
+}
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	{{- if .Web.WithOTel}}
+
+	"go.opentelemetry.io/otel"
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/pkg/metrics"
+	{{- end}}
+)
+
+// Login logs in a user and returns a JWT Token.
+func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
+	handle(w, r, bindJSON, h.biz.UserV1().Login, h.val.ValidateLoginRequest)
+}
+
+// RefreshToken refreshes the JWT Token.
+func (h *Handler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	handle(w, r, bindJSON, h.biz.UserV1().RefreshToken)
+}
+
+// ChangePassword changes the user's password.
+func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	handle(w, r, bindAll, h.biz.UserV1().ChangePassword, h.val.ValidateChangePasswordRequest)
+}
+
+// CreateUser handles the HTTP request to create a new user.
+func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	{{- if .Web.WithOTel}}
+	ctx, span := otel.Tracer("handler").Start(r.Context(), "Handler.CreateUser")
+	defer span.End()
+
+	r = r.WithContext(ctx)
+
+	metrics.M.RecordResourceCreate(ctx, "user")
+	{{- end}}
+
+	slog.InfoContext(r.Context(), "processing user creation request")
+
+	handle(w, r, bindJSON, h.biz.UserV1().Create, h.val.ValidateCreateUserRequest)
+}
+
+// UpdateUser handles the HTTP request to update an existing user's details.
+func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	handle(w, r, bindAll, h.biz.UserV1().Update, h.val.ValidateUpdateUserRequest)
+}
+
+// DeleteUser handles the HTTP request to delete a single user specified by URI parameters.
+func (h *Handler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	handle(w, r, bindURI, h.biz.UserV1().Delete, h.val.ValidateDeleteUserRequest)
+}
+
+// GetUser retrieves details of a specific user based on the request parameters.
+func (h *Handler) GetUser(w http.ResponseWriter, r *http.Request) {
+	{{- if .Web.WithOTel}}
+	ctx, span := otel.Tracer("handler").Start(r.Context(), "Handler.GetUser")
+	defer span.End()
+
+	r = r.WithContext(ctx)
+
+	metrics.M.RecordResourceGet(ctx, "user")
+	{{- end}}
+
+	slog.InfoContext(r.Context(), "processing user retrieve request", "layer", "handler")
+
+	handle(w, r, bindURI, h.biz.UserV1().Get, h.val.ValidateGetUserRequest)
+}
+
+// ListUser retrieves a list of users based on query parameters.
+func (h *Handler) ListUser(w http.ResponseWriter, r *http.Request) {
+	handle(w, r, bindQuery, h.biz.UserV1().List, h.val.ValidateListUserRequest)
+}
+
+func init() {
+	Register(func(v1 *RouterGroup, handler *Handler, mws ...Middleware) {
+		rg := v1.Group("/users")
+		rg.POST("", handler.CreateUser)
+		rg.Use(mws...)
+		rg.PUT("/{userID}/change-password", handler.ChangePassword)
+		rg.PUT("/{userID}", handler.UpdateUser)
+		rg.DELETE("/{userID}", handler.DeleteUser)
+		rg.GET("/{userID}", handler.GetUser)
+		rg.GET("", handler.ListUser)
+	})
+}
@@ -0,0 +1,135 @@
+package {{.Web.Name}}
+
+import (
+	{{- if .Web.WithOTel}}
+	"bytes"
+	{{- end}}
+	"context"
+	"log/slog"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/app/middlewares/server/recovery"
+	hertzserver "github.com/cloudwego/hertz/pkg/app/server"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	{{- if .Web.WithOTel}}
+	"github.com/cloudwego/hertz/pkg/common/adaptor"
+	"github.com/hertz-contrib/obs-opentelemetry/tracing"
+	{{- end}}
+	"github.com/hertz-contrib/pprof"
+	"github.com/onexstack/onexstack/pkg/errorsx"
+	"github.com/onexstack/onexstack/pkg/server"
+	{{- if .Web.WithOTel}}
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	{{- end}}
+
+	"{{.D.ModuleName}}/internal/pkg/errno"
+	mw "{{.D.ModuleName}}/internal/pkg/middleware/hertz"
+)
+
+// hertzServer implements the server.Server interface using the Hertz framework.
+type hertzServer struct {
+	h *hertzserver.Hertz
+}
+
+// Ensure *hertzServer implements the server.Server interface.
+var _ server.Server = (*hertzServer)(nil)
+
+// NewHertzServer initializes and returns a new HTTP server based on Hertz.
+// It returns the server.Server interface to abstract implementation details.
+func (c *ServerConfig) NewHertzServer() (server.Server, error) {
+	opts := []config.Option{
+		hertzserver.WithHostPorts(c.HTTPOptions.Addr),
+		hertzserver.WithDisablePrintRoute(true),
+	}
+	if c.TLSOptions != nil && c.TLSOptions.UseTLS {
+		opts = append(opts, hertzserver.WithTLS(c.TLSOptions.MustTLSConfig()))
+	}
+	{{- if .Web.WithOTel}}
+	// The tracer starts before the request is routed, so it must not parse the URI of the request:
+	// Hertz rejects requests whose URI is parsed before they are handled.
+	tracer, tracingCfg := tracing.NewServerTracer(tracing.WithShouldIgnore(func(_ context.Context, c *app.RequestContext) bool {
+		return bytes.HasPrefix(c.Request.Header.RequestURI(), []byte("/metrics"))
+	}))
+	opts = append(opts, tracer)
+	{{- end}}
+
+	h := hertzserver.New(opts...)
+
+	h.Use(
+		recovery.Recovery(),
+		mw.RequestIDMiddleware(),
+		mw.NoCache,
+		mw.Cors(mw.DefaultCorsConfig()),
+		mw.Secure,
+		{{- if .Web.WithOTel}}
+		tracing.ServerMiddleware(tracingCfg),
+		mw.Context(),
+		{{- end}}
+	)
+
+	c.InstallRESTAPI(h)
+
+	return &hertzServer{h: h}, nil
+}
+
+// InstallRESTAPI registers all RESTful API routes to the server.
+func (c *ServerConfig) InstallRESTAPI(h *hertzserver.Hertz) {
+	InstallGenericAPI(h)
+
+	{{- if .Web.WithHealthz}}
+	h.GET("/healthz", c.Handler.Healthz)
+	{{- end}}
+
+	{{.D.APIVersion}} := h.Group("/{{.D.APIVersion}}")
+
+	{{- if .Web.WithUser}}
+	// Register user login and token refresh interfaces.
+	// These two interfaces are relatively simple, so there is no API version.
+	h.POST("/login", c.Handler.Login)
+	// Note: The authentication middleware should be loaded before c.Handler.RefreshToken.
+	h.PUT("/refresh-token", mw.AuthnMiddleware(c.Retriever), c.Handler.RefreshToken)
+
+	authMiddlewares := []app.HandlerFunc{mw.AuthnMiddleware(c.Retriever), mw.AuthzMiddleware(c.Authz)}
+	c.Handler.ApplyTo({{.D.APIVersion}}, authMiddlewares...)
+	{{- else}}
+	c.Handler.ApplyTo({{.D.APIVersion}})
+	{{- end}}
+}
+
+// InstallGenericAPI registers non-business logic routes such as pprof, metrics, and 404 handlers.
+func InstallGenericAPI(h *hertzserver.Hertz) {
+	pprof.Register(h)
+	{{- if .Web.WithOTel}}
+
+	// Expose /metrics endpoint for Prometheus scraping.
+	h.GET("/metrics", adaptor.HertzHandler(promhttp.HandlerFor(
+		prometheus.DefaultGatherer,
+		promhttp.HandlerOpts{
+			EnableOpenMetrics: true,
+		},
+	)))
+	{{- end}}
+
+	// Register 404 handler.
+	h.NoRoute(func(ctx context.Context, c *app.RequestContext) {
+		errx := errorsx.FromError(errno.ErrPageNotFound)
+		c.JSON(errx.Code, errx)
+	})
+}
+
+// RunOrDie starts the Hertz server and panics if startup fails.
+func (s *hertzServer) RunOrDie(ctx context.Context) {
+	slog.InfoContext(ctx, "start to listening the incoming requests", "protocol", "http")
+	if err := s.h.Run(); err != nil {
+		slog.ErrorContext(ctx, "failed to start hertz server", "error", err)
+		panic(err)
+	}
+}
+
+// GracefulStop gracefully shuts down the server.
+func (s *hertzServer) GracefulStop(ctx context.Context) {
+	if err := s.h.Shutdown(ctx); err != nil {
+		slog.ErrorContext(ctx, "failed to gracefully shutdown hertz server", "error", err)
+	}
+}
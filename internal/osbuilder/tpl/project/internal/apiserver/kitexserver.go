@@ -0,0 +1,160 @@
+package {{.Web.Name}}
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	{{- if .Web.WithOTel}}
+	"errors"
+	"net/http"
+	{{- end}}
+	{{- if .Web.WithUser}}
+
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+	{{- end}}
+	kitexserver "github.com/cloudwego/kitex/server"
+	{{- if .Web.WithOTel}}
+	"github.com/kitex-contrib/obs-opentelemetry/tracing"
+	{{- end}}
+	"github.com/onexstack/onexstack/pkg/server"
+	genericvalidation "github.com/onexstack/onexstack/pkg/validation"
+	{{- if .Web.WithOTel}}
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	{{- end}}
+
+	"{{.D.ModuleName}}/internal/pkg/kitexsvc"
+	mw "{{.D.ModuleName}}/internal/pkg/middleware/kitex"
+	{{.D.APIAlias}} "{{.D.ModuleName}}/pkg/api/{{.Web.Name}}/{{.D.APIVersion}}"
+)
+
+// kitexServer implements the server.Server interface using the Kitex framework.
+type kitexServer struct {
+	srv kitexserver.Server
+	{{- if .Web.WithOTel}}
+	// metrics serves the Prometheus /metrics endpoint.
+	metrics *http.Server
+	{{- end}}
+}
+
+// Ensure that *kitexServer implements the server.Server interface.
+var _ server.Server = (*kitexServer)(nil)
+
+// NewKitexServer creates and initializes a Kitex server, which serves the {{.Web.GRPCServiceName}} service to
+// Kitex and gRPC clients.
+func (c *ServerConfig) NewKitexServer() (server.Server, error) {
+	addr, err := net.ResolveTCPAddr("tcp", c.GRPCOptions.Addr)
+	if err != nil {
+		return nil, err
+	}
+
+	svcInfo, err := kitexsvc.NewServiceInfo({{.D.APIAlias}}.{{.Web.GRPCServiceName}}_ServiceDesc.ServiceName, c.Handler)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []kitexserver.Option{
+		kitexserver.WithServiceAddr(addr),
+		// Pass the decoded requests and responses of the unary methods to the middlewares, like
+		// the servers generated by the kitex tool.
+		kitexserver.WithCompatibleMiddlewareForUnary(),
+		{{- if .Web.WithOTel}}
+		kitexserver.WithSuite(tracing.NewServerSuite()),
+		{{- end}}
+		// Note the order of middlewares! The first one is the outermost.
+		kitexserver.WithMiddleware(mw.StatusMiddleware()),
+		// Request ID middleware.
+		kitexserver.WithMiddleware(mw.RequestIDMiddleware()),
+		{{- if .Web.WithUser}}
+		// Authentication middleware.
+		kitexserver.WithMiddleware(mw.Selector(mw.AuthnMiddleware(c.Retriever), NewAuthnWhiteListMatcher())),
+		// Authorization middleware.
+		kitexserver.WithMiddleware(mw.Selector(mw.AuthzMiddleware(c.Authz), NewAuthzWhiteListMatcher())),
+		{{- end}}
+		// Default value setting middleware.
+		kitexserver.WithMiddleware(mw.DefaulterMiddleware()),
+		// Request validation middleware.
+		kitexserver.WithMiddleware(mw.ValidatorMiddleware(genericvalidation.NewValidator(c.Validator))),
+		{{- if .Web.WithOTel}}
+		kitexserver.WithMiddleware(mw.Context()),
+		{{- end}}
+	}
+
+	srv := kitexserver.NewServer(opts...)
+	if err := srv.RegisterService(svcInfo, c.Handler); err != nil {
+		return nil, err
+	}
+
+	{{- if .Web.WithOTel}}
+
+	// Serve the Prometheus metrics on a separate HTTP server.
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(
+		prometheus.DefaultGatherer,
+		promhttp.HandlerOpts{
+			EnableOpenMetrics: true,
+		},
+	))
+
+	return &kitexServer{srv: srv, metrics: &http.Server{Addr: c.MetricsAddr, Handler: mux}}, nil
+	{{- else}}
+
+	return &kitexServer{srv: srv}, nil
+	{{- end}}
+}
+
+// RunOrDie starts the Kitex server and panics if startup fails.
+func (s *kitexServer) RunOrDie(ctx context.Context) {
+	{{- if .Web.WithOTel}}
+	go func() {
+		slog.InfoContext(ctx, "start metrics server", "addr", s.metrics.Addr)
+		if err := s.metrics.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.ErrorContext(ctx, "failed to start metrics server", "error", err)
+		}
+	}()
+
+	{{end -}}
+	slog.InfoContext(ctx, "start to listening the incoming requests", "protocol", "kitex")
+	if err := s.srv.Run(); err != nil {
+		slog.ErrorContext(ctx, "failed to start kitex server", "error", err)
+		panic(err)
+	}
+}
+
+// GracefulStop gracefully stops the Kitex server.
+func (s *kitexServer) GracefulStop(ctx context.Context) {
+	{{- if .Web.WithOTel}}
+	_ = s.metrics.Shutdown(ctx)
+	{{- end}}
+	if err := s.srv.Stop(); err != nil {
+		slog.ErrorContext(ctx, "failed to gracefully stop kitex server", "error", err)
+	}
+}
+
+{{- if .Web.WithUser}}
+
+// authWhiteList lists the methods that are called without authentication and authorization.
+var authWhiteList = map[string]struct{}{
+	{{- if .Web.WithHealthz}}
+	"Healthz":    {},
+	{{- end}}
+	"CreateUser": {},
+	"Login":      {},
+}
+
+// NewAuthnWhiteListMatcher creates an authentication whitelist matcher.
+func NewAuthnWhiteListMatcher() mw.MatchFunc {
+	return func(ctx context.Context) bool {
+		_, ok := authWhiteList[rpcinfo.GetRPCInfo(ctx).Invocation().MethodName()]
+		return !ok
+	}
+}
+
+// NewAuthzWhiteListMatcher creates an authorization whitelist matcher.
+func NewAuthzWhiteListMatcher() mw.MatchFunc {
+	return func(ctx context.Context) bool {
+		_, ok := authWhiteList[rpcinfo.GetRPCInfo(ctx).Invocation().MethodName()]
+		return !ok
+	}
+}
+{{- end}}
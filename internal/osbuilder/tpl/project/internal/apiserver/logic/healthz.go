@@ -0,0 +1,36 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/svc"
+	{{.Web.APIImportPath}}
+)
+
+// HealthzLogic reports the health of the service.
+type HealthzLogic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// NewHealthzLogic creates a new instance of *HealthzLogic.
+func NewHealthzLogic(ctx context.Context, svcCtx *svc.ServiceContext) *HealthzLogic {
+	return &HealthzLogic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// Healthz returns the health status of the service.
+func (l *HealthzLogic) Healthz() (*{{.D.APIAlias}}.HealthzResponse, error) {
+	l.Infow("health check requested", logx.Field("status", "healthy"))
+	return &{{.D.APIAlias}}.HealthzResponse{
+		Status:    {{.D.APIAlias}}.ServiceStatus_Healthy,
+		Timestamp: time.Now().Format(time.RFC3339),
+	}, nil
+}
@@ -0,0 +1,13 @@
+package logic
+
+import "context"
+
+// validate applies the defaults of the request message, if it has any, and then checks it with
+// the given validation function of the validation layer. The REST and RPC servers share the logics,
+// so the requests of both are validated the same way.
+func validate[T any](ctx context.Context, rq *T, fn func(context.Context, *T) error) error {
+	if defaulter, ok := any(rq).(interface{ Default() }); ok {
+		defaulter.Default()
+	}
+	return fn(ctx, rq)
+}
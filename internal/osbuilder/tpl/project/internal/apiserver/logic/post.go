@@ -0,0 +1,77 @@
+package logic
+
+import (
+	"context"
+
+	"github.com/zeromicro/go-zero/core/logx"
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/svc"
+	{{.Web.APIImportPath}}
+)
+
+// {{.Web.R.SingularName}}Logic implements the {{.Web.R.SingularLower}} API of the REST and RPC servers: it validates the
+// requests and delegates them to the biz layer.
+type {{.Web.R.SingularName}}Logic struct {
+	logx.Logger
+	ctx    context.Context
+	svcCtx *svc.ServiceContext
+}
+
+// New{{.Web.R.SingularName}}Logic creates a new instance of *{{.Web.R.SingularName}}Logic.
+func New{{.Web.R.SingularName}}Logic(ctx context.Context, svcCtx *svc.ServiceContext) *{{.Web.R.SingularName}}Logic {
+	return &{{.Web.R.SingularName}}Logic{
+		Logger: logx.WithContext(ctx),
+		ctx:    ctx,
+		svcCtx: svcCtx,
+	}
+}
+
+// Create creates a new {{.Web.R.SingularLower}}.
+func (l *{{.Web.R.SingularName}}Logic) Create(rq *{{.D.APIAlias}}.Create{{.Web.R.SingularName}}Request) (*{{.D.APIAlias}}.Create{{.Web.R.SingularName}}Response, error) {
+	l.Infow("processing {{.Web.R.SingularLower}} creation request")
+	if err := validate(l.ctx, rq, l.svcCtx.Validator.ValidateCreate{{.Web.R.SingularName}}Request); err != nil {
+		return nil, err
+	}
+	return l.svcCtx.Biz.{{.Web.R.BusinessFactoryName}}().Create(l.ctx, rq)
+}
+
+// Update updates an existing {{.Web.R.SingularLower}}'s details.
+func (l *{{.Web.R.SingularName}}Logic) Update(rq *{{.D.APIAlias}}.Update{{.Web.R.SingularName}}Request) (*{{.D.APIAlias}}.Update{{.Web.R.SingularName}}Response, error) {
+	if err := validate(l.ctx, rq, l.svcCtx.Validator.ValidateUpdate{{.Web.R.SingularName}}Request); err != nil {
+		return nil, err
+	}
+	return l.svcCtx.Biz.{{.Web.R.BusinessFactoryName}}().Update(l.ctx, rq)
+}
+
+// Delete deletes a single {{.Web.R.SingularLower}}.
+func (l *{{.Web.R.SingularName}}Logic) Delete(rq *{{.D.APIAlias}}.Delete{{.Web.R.SingularName}}Request) (*{{.D.APIAlias}}.Delete{{.Web.R.SingularName}}Response, error) {
+	if err := validate(l.ctx, rq, l.svcCtx.Validator.ValidateDelete{{.Web.R.SingularName}}Request); err != nil {
+		return nil, err
+	}
+	return l.svcCtx.Biz.{{.Web.R.BusinessFactoryName}}().Delete(l.ctx, rq)
+}
+
+// DeleteCollection deletes one or more {{.Web.R.PluralLower}}.
+func (l *{{.Web.R.SingularName}}Logic) DeleteCollection(rq *{{.D.APIAlias}}.Delete{{.Web.R.PluralName}}Request) (*{{.D.APIAlias}}.Delete{{.Web.R.PluralName}}Response, error) {
+	if err := validate(l.ctx, rq, l.svcCtx.Validator.ValidateDelete{{.Web.R.PluralName}}Request); err != nil {
+		return nil, err
+	}
+	return l.svcCtx.Biz.{{.Web.R.BusinessFactoryName}}().DeleteCollection(l.ctx, rq)
+}
+
+// Get retrieves the details of a specific {{.Web.R.SingularLower}}.
+func (l *{{.Web.R.SingularName}}Logic) Get(rq *{{.D.APIAlias}}.Get{{.Web.R.SingularName}}Request) (*{{.D.APIAlias}}.Get{{.Web.R.SingularName}}Response, error) {
+	l.Infow("processing {{.Web.R.SingularLower}} retrieve request")
+	if err := validate(l.ctx, rq, l.svcCtx.Validator.ValidateGet{{.Web.R.SingularName}}Request); err != nil {
+		return nil, err
+	}
+	return l.svcCtx.Biz.{{.Web.R.BusinessFactoryName}}().Get(l.ctx, rq)
+}
+
+// List retrieves a list of {{.Web.R.PluralLower}} based on query parameters.
+func (l *{{.Web.R.SingularName}}Logic) List(rq *{{.D.APIAlias}}.List{{.Web.R.SingularName}}Request) (*{{.D.APIAlias}}.List{{.Web.R.SingularName}}Response, error) {
+	if err := validate(l.ctx, rq, l.svcCtx.Validator.ValidateList{{.Web.R.SingularName}}Request); err != nil {
+		return nil, err
+	}
+	return l.svcCtx.Biz.{{.Web.R.BusinessFactoryName}}().List(l.ctx, rq)
+}
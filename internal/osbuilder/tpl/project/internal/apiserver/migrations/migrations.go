@@ -0,0 +1,79 @@
+// Package migrations holds the versioned schema migrations of {{.Web.BinaryName}}, with one
+// directory of migrations per SQL dialect. `osbuilder create api` adds the migrations of new
+// kinds and field changes, and `{{.Web.BinaryName}} migrate create` adds hand-written ones.
+{{- if .Web.Datasources}}
+// The migrations of the tables of the kinds stored in a named datasource are in the directories
+// of datasources/<name>, and are applied to that datasource only.
+{{- end}}
+package migrations
+
+import (
+	"context"
+	"embed"
+	"io/fs"
+	"log/slog"
+	"path"
+
+	"gorm.io/gorm"
+
+	"{{.D.ModuleName}}/internal/pkg/migrate"
+)
+
+//go:embed all:mysql all:postgres all:sqlite{{if .Web.Datasources}} all:datasources{{end}}
+var files embed.FS
+
+// NewMigrator returns a migrator of the migrations written for the dialect of db.
+func NewMigrator(db *gorm.DB) (*migrate.Migrator, error) {
+	return newMigrator(db, ".")
+}
+{{- if .Web.Datasources}}
+
+// NewDatasourceMigrator returns a migrator of the migrations of the named datasource, which
+// create and alter the tables of the kinds stored in the datasource, written for the dialect of db.
+func NewDatasourceMigrator(db *gorm.DB, name string) (*migrate.Migrator, error) {
+	return newMigrator(db, path.Join("datasources", name))
+}
+{{- end}}
+
+// newMigrator returns a migrator of the migrations of dir written for the dialect of db.
+func newMigrator(db *gorm.DB, dir string) (*migrate.Migrator, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	dialect := db.Dialector.Name()
+	fsys, err := fs.Sub(files, path.Join(dir, dialect))
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(sqlDB, dialect, fsys)
+}
+
+// Up applies the pending migrations to db.
+func Up(ctx context.Context, db *gorm.DB) error {
+	mg, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	done, err := mg.Up(ctx, 0)
+	for _, m := range done {
+		slog.Info("applied database migration", "migration", m.String())
+	}
+	return err
+}
+{{- if .Web.Datasources}}
+
+// UpDatasource applies the pending migrations of the named datasource to db, the primary database
+// of the datasource.
+func UpDatasource(ctx context.Context, db *gorm.DB, name string) error {
+	mg, err := NewDatasourceMigrator(db, name)
+	if err != nil {
+		return err
+	}
+	done, err := mg.Up(ctx, 0)
+	for _, m := range done {
+		slog.Info("applied database migration", "datasource", name, "migration", m.String())
+	}
+	return err
+}
+{{- end}}
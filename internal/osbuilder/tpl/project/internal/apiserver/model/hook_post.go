@@ -2,7 +2,6 @@ package model
 
 import (
 	"github.com/onexstack/onexstack/pkg/rid"
-	"github.com/onexstack/onexstack/pkg/store/registry"
 	"gorm.io/gorm"
 )
 
@@ -16,6 +15,11 @@ func (m *{{.Web.R.GORMModel}}) AfterCreate(tx *gorm.DB) error {
 	return tx.Model(m).UpdateColumn("{{.Web.R.Last.SingularLower}}_id", m.{{.Web.R.Last.SingularName}}ID).Error
 }
 
-func init() {
-	registry.Register(&{{.Web.R.GORMModel}}{})
+{{- if .Web.R.HasResourceVersion}}
+
+// BeforeCreate starts the resource version of a new {{.Web.R.Last.SingularLower}} at 1.
+func (m *{{.Web.R.GORMModel}}) BeforeCreate(tx *gorm.DB) error {
+	m.ResourceVersion = 1
+	return nil
 }
+{{- end}}
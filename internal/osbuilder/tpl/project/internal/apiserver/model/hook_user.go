@@ -3,7 +3,6 @@ package model
 import (
 	"github.com/onexstack/onexstack/pkg/authn"
 	"github.com/onexstack/onexstack/pkg/rid"
-	"github.com/onexstack/onexstack/pkg/store/registry"
 	"gorm.io/gorm"
 )
 
@@ -25,7 +24,3 @@ func (m *UserM) AfterCreate(tx *gorm.DB) error {
 
 	return tx.Save(m).Error
 }
-
-func init() {
-	registry.Register(&UserM{})
-}
@@ -2,13 +2,19 @@ package model
 
 import (
 	"time"
+	{{- if .Web.R.IsSoftDelete}}
+
+	"gorm.io/gorm"
+	{{- end}}
 )
+{{- $table := printf "%s_%s" (extractProjectPrefix .Web.BinaryName) .Web.R.SingularLower}}
+{{- with .Web.R.Table}}{{$table = .}}{{end}}
 
 // TableName{{.Web.R.SingularName}} defines the physical table name for the {{.Web.R.GORMModel}} model.
-const TableName{{.Web.R.SingularName}} = "{{.Web.BinaryName | extractProjectPrefix}}_{{.Web.R.SingularLower}}"
+const TableName{{.Web.R.SingularName}} = "{{$table}}"
 
 // {{.Web.R.GORMModel}} represents the data model for the {{.Web.R.SingularLower}} resource.
-// It maps to the "{{.Web.BinaryName | extractProjectPrefix}}_{{.Web.R.SingularLower}}" table in the database.
+// It maps to the "{{$table}}" table in the database.
 type {{.Web.R.GORMModel}} struct {
     // ID is the primary key of the record.
     ID int64 `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
@@ -21,6 +27,40 @@ type {{.Web.R.GORMModel}} struct {
 
     // UpdatedAt is the timestamp when the resource was last modified.
     UpdatedAt time.Time `gorm:"column:updated_at;not null;default:current_timestamp;comment:Last modification timestamp" json:"updatedAt"`
+    {{- if .Web.R.IsSoftDelete}}
+
+    // DeletedAt is the timestamp when the resource was soft deleted, NULL while it is not deleted.
+    // GORM excludes soft deleted records from queries unless they are Unscoped.
+    DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index;comment:Soft deletion timestamp" json:"deletedAt"`
+    {{- end}}
+    {{- if .Web.R.HasResourceVersion}}
+
+    // ResourceVersion is incremented by every update and makes updates conditional on the version that was read.
+    ResourceVersion int64 `gorm:"column:resource_version;not null;default:1;comment:Optimistic concurrency version" json:"resourceVersion"`
+    {{- end}}
+    {{- range .Web.R.Parents}}
+
+    // {{.IDField}} is the identifier of the parent {{.SingularLower}}.
+    {{.IDField}} string `gorm:"column:{{.IDColumn}};not null;index;comment:Parent resource ID" json:"{{.IDJSON}}"`
+    {{- end}}
+    {{- range .Web.R.Fields}}
+
+    // {{.GoName}} is the {{.Label}} of the resource.
+    {{.GoName}} {{.GoType}} `gorm:"column:{{.Column}}{{if .GORMType}};type:{{.GORMType}}{{end}}" json:"{{.JSONName}}"`
+    {{- end}}
+    {{- range .Web.R.BelongsTo}}
+
+    // {{.ForeignKey}} references the {{.Ref.SingularLower}} this resource belongs to.
+    // Referential integrity is enforced by the biz layer rather than by a database constraint.
+    {{.ForeignKey}} string `gorm:"column:{{.ForeignKeyColumn}};index" json:"{{.ForeignKeyJSON}}"`
+    // {{.GoName}} is the associated {{.Ref.SingularLower}}, loaded on demand via preload.
+    {{.GoName}} *{{.Ref.GORMModel}} `gorm:"foreignKey:{{.ForeignKey}};references:{{.RefKey}};constraint:-" json:"{{.JSONName}},omitempty"`
+    {{- end}}
+    {{- range .Web.R.HasMany}}
+
+    // {{.GoName}} are the associated {{.Ref.PluralLower}}, loaded on demand via preload.
+    {{.GoName}} []*{{.Ref.GORMModel}} `gorm:"foreignKey:{{.Inverse.ForeignKey}};references:{{$.Web.R.Last.SingularName}}ID;constraint:-" json:"{{.JSONName}},omitempty"`
+    {{- end}}
 }
 
 // TableName returns the physical table name for the {{.Web.R.GORMModel}} model.
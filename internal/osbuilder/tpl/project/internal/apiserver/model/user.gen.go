@@ -16,10 +16,10 @@ type UserM struct {
 	ID int64 `gorm:"column:id;primaryKey;autoIncrement:true" json:"id"`
 
     // UserID is the unique identifier for the resource.
-    UserID string `gorm:"column:user_id;not null;uniqueIndex:idx_user_user_id;comment:Unique user ID" json:"user_id"`
+    UserID string `gorm:"column:user_id;not null;uniqueIndex:idx_{{.Web.BinaryName | extractProjectPrefix}}_user_user_id;comment:Unique user ID" json:"user_id"`
 
     // Username is the unique username for the user.
-    Username string `gorm:"column:username;not null;uniqueIndex:idx_user_username;comment:Username (unique)" json:"username"`
+    Username string `gorm:"column:username;not null;uniqueIndex:idx_{{.Web.BinaryName | extractProjectPrefix}}_user_username;comment:Username (unique)" json:"username"`
 
     // Password is the hashed password for the user.
     Password string `gorm:"column:password;not null;comment:User password (hashed)" json:"password"`
@@ -31,7 +31,7 @@ type UserM struct {
     Email string `gorm:"column:email;not null;comment:User email address" json:"email"`
 
     // Phone is the user's phone number.
-    Phone string `gorm:"column:phone;not null;uniqueIndex:idx_user_phone;comment:User phone number" json:"phone"`
+    Phone string `gorm:"column:phone;not null;uniqueIndex:idx_{{.Web.BinaryName | extractProjectPrefix}}_user_phone;comment:User phone number" json:"phone"`
 
     // CreatedAt is the timestamp when the resource was created.
     CreatedAt time.Time `gorm:"column:created_at;not null;default:current_timestamp;comment:User creation time" json:"created_at"`
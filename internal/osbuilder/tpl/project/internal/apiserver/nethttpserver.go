@@ -0,0 +1,138 @@
+package {{.Web.Name}}
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/onexstack/onexstack/pkg/errorsx"
+	"github.com/onexstack/onexstack/pkg/server"
+	{{- if .Web.WithOTel}}
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	{{- end}}
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/handler"
+	"{{.D.ModuleName}}/internal/pkg/errno"
+	mw "{{.D.ModuleName}}/internal/pkg/middleware/nethttp"
+)
+
+// netHTTPServer implements the server.Server interface using the net/http package of the standard library.
+type netHTTPServer struct {
+	srv server.Server
+}
+
+// Ensure *netHTTPServer implements the server.Server interface.
+var _ server.Server = (*netHTTPServer)(nil)
+
+// NewNetHTTPServer initializes and returns a new HTTP server based on http.ServeMux.
+// It returns the server.Server interface to abstract implementation details.
+func (c *ServerConfig) NewNetHTTPServer() (server.Server, error) {
+	mux := http.NewServeMux()
+
+	c.InstallRESTAPI(mux)
+
+	// The middlewares wrap the mux, so they run before the request is routed, as the global
+	// middlewares of gin do. The first middleware is the outermost one.
+	mws := []handler.Middleware{
+		mw.Recovery,
+		mw.RequestIDMiddleware(),
+		mw.NoCache,
+		mw.Cors(mw.DefaultCorsConfig()),
+		mw.Secure,
+		{{- if .Web.WithOTel}}
+		otelhttp.NewMiddleware(serviceName, otelhttp.WithFilter(shouldRecordTelemetry)),
+		mw.Context(),
+		{{- end}}
+	}
+	var h http.Handler = mux
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+
+	httpsrv := server.NewHTTPServer(c.HTTPOptions, c.TLSOptions, h)
+
+	return &netHTTPServer{srv: httpsrv}, nil
+}
+
+// InstallRESTAPI registers all RESTful API routes to the mux.
+func (c *ServerConfig) InstallRESTAPI(mux *http.ServeMux) {
+	InstallGenericAPI(mux)
+
+	{{- if .Web.WithHealthz}}
+	mux.HandleFunc("GET /healthz", c.Handler.Healthz)
+	{{- end}}
+
+	{{.D.APIVersion}} := handler.NewRouterGroup(mux, "/{{.D.APIVersion}}")
+
+	{{- if .Web.WithUser}}
+	// Register user login and token refresh interfaces.
+	// These two interfaces are relatively simple, so there is no API version.
+	root := handler.NewRouterGroup(mux, "")
+	root.POST("/login", c.Handler.Login)
+	// Note: The authentication middleware should be loaded before c.Handler.RefreshToken.
+	root.Use(mw.AuthnMiddleware(c.Retriever))
+	root.PUT("/refresh-token", c.Handler.RefreshToken)
+
+	authMiddlewares := []handler.Middleware{mw.AuthnMiddleware(c.Retriever), mw.AuthzMiddleware(c.Authz)}
+	c.Handler.ApplyTo({{.D.APIVersion}}, authMiddlewares...)
+	{{- if .Web.WithGraphQL}}
+
+	// Register the GraphQL endpoint, which is protected as the RESTful APIs are.
+	handler.NewRouterGroup(mux, "", authMiddlewares...).POST("/graphql", c.GraphQL.ServeHTTP)
+	{{- end}}
+	{{- else}}
+	c.Handler.ApplyTo({{.D.APIVersion}})
+	{{- if .Web.WithGraphQL}}
+
+	// Register the GraphQL endpoint.
+	mux.Handle("POST /graphql", c.GraphQL)
+	{{- end}}
+	{{- end}}
+}
+
+// InstallGenericAPI registers non-business logic routes such as pprof, metrics, and 404 handlers.
+func InstallGenericAPI(mux *http.ServeMux) {
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	{{- if .Web.WithOTel}}
+
+	// Expose /metrics endpoint for Prometheus scraping.
+	mux.Handle("GET /metrics", promhttp.HandlerFor(
+		prometheus.DefaultGatherer,
+		promhttp.HandlerOpts{
+			EnableOpenMetrics: true,
+		},
+	))
+	{{- end}}
+
+	// Register 404 handler: "/" matches every request that no other pattern matches.
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		errx := errorsx.FromError(errno.ErrPageNotFound)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(errx.Code)
+		_ = json.NewEncoder(w).Encode(errx)
+	})
+}
+
+// RunOrDie starts the net/http server and panics if startup fails.
+func (s *netHTTPServer) RunOrDie(ctx context.Context) {
+	s.srv.RunOrDie(ctx)
+}
+
+// GracefulStop gracefully shuts down the server.
+func (s *netHTTPServer) GracefulStop(ctx context.Context) {
+	s.srv.GracefulStop(ctx)
+}
+
+{{if .Web.WithOTel}}
+// shouldRecordTelemetry filters out paths that shouldn't generate traces.
+func shouldRecordTelemetry(r *http.Request) bool {
+	return r.URL.Path != "/metrics"
+}
+{{- end}}
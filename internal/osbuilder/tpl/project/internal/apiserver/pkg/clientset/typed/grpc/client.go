@@ -0,0 +1,116 @@
+{{- $c := .Web.TypedClient -}}
+package {{$c.PackageName}}
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	{{- if .Web.WithOTel }}
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	{{- end}}
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"{{.D.ModuleName}}/internal/pkg/contextx"
+	{{- if $c.Discovery }}
+	"{{.D.ModuleName}}/internal/pkg/discovery"
+	{{- end}}
+	"{{.D.ModuleName}}/internal/pkg/known"
+	{{.D.APIAlias}} "{{.D.ModuleName}}/pkg/api/{{$c.ServerName}}/{{.D.APIVersion}}"
+)
+
+// Interface defines the operations of the {{$c.Name}} client, which are the methods of the
+// {{$c.ServiceName}} service of {{$c.Param "server"}}.
+type Interface interface {
+	{{.D.APIAlias}}.{{$c.ServiceName}}Client
+	// Close closes the connection to the server.
+	Close() error
+}
+
+// Client implements the Interface over a gRPC connection.
+type Client struct {
+	{{.D.APIAlias}}.{{$c.ServiceName}}Client
+	conn *grpc.ClientConn
+}
+
+// NewForConfig creates a new Client instance configured with the provided options. The
+// connection is established lazily by the first call.
+func NewForConfig(opts *Options) (*Client, error) {
+	target := opts.Addr
+	dialOpts := DialOptions(opts)
+	{{- if $c.Discovery }}
+	if opts.Registry.Enabled() {
+		// The calls are balanced between the instances of {{$c.Server.BinaryName}} resolved from Polaris.
+		resolver, err := discovery.NewResolver(opts.Registry)
+		if err != nil {
+			return nil, err
+		}
+		target = "polaris:///" + opts.Registry.Service
+		dialOpts = append(dialOpts,
+			grpc.WithResolvers(resolver.GRPCBuilder(10*time.Second)),
+			grpc.WithDefaultServiceConfig(`{"loadBalancingConfig": [{"round_robin": {}}]}`),
+		)
+	}
+	{{- end}}
+
+	conn, err := grpc.NewClient(target, dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewForConn(conn), nil
+}
+
+// DialOptions returns the options of the connections configured with opts, whose unary calls
+// are bounded by Timeout and send the request ID of their context in their metadata.
+func DialOptions(opts *Options) []grpc.DialOption {
+	creds := insecure.NewCredentials()
+	if opts.UseTLS {
+		creds = credentials.NewTLS(&tls.Config{})
+	}
+
+	return []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		{{- if .Web.WithOTel }}
+		// Every call is traced as a span of the context of the call.
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		{{- end}}
+		grpc.WithChainUnaryInterceptor(timeoutInterceptor(opts.Timeout), requestIDInterceptor()),
+	}
+}
+
+// NewForConn creates a new Client instance that calls the server over conn, and closes conn
+// when it is closed.
+func NewForConn(conn *grpc.ClientConn) *Client {
+	return &Client{ {{- .D.APIAlias}}.New{{$c.ServiceName}}Client(conn), conn}
+}
+
+// Close closes the connection to the server.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// timeoutInterceptor bounds the unary calls whose context has no deadline by timeout.
+func timeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok && timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// requestIDInterceptor sends the request ID of the context of the unary calls in their
+// x-request-id metadata.
+func requestIDInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if requestID := contextx.RequestID(ctx); requestID != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, string(known.XRequestID), requestID)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
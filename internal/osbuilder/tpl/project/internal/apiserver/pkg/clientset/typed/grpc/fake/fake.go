@@ -0,0 +1,43 @@
+{{- $c := .Web.TypedClient -}}
+// Package fake provides the {{$c.Name}} client for tests, whose calls are served in memory.
+package fake
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+
+	{{.D.APIAlias}} "{{.D.ModuleName}}/pkg/api/{{$c.ServerName}}/{{.D.APIVersion}}"
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/pkg/clientset/typed/{{$c.PackageName}}"
+)
+
+// NewClient returns the {{$c.Name}} client whose calls are served in memory by srv, for example
+// an implementation that embeds {{.D.APIAlias}}.Unimplemented{{$c.ServiceName}}Server and returns canned
+// responses. The server and the client are stopped when the test ends.
+func NewClient(tb testing.TB, srv {{.D.APIAlias}}.{{$c.ServiceName}}Server) *{{$c.PackageName}}.Client {
+	tb.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	{{.D.APIAlias}}.Register{{$c.ServiceName}}Server(s, srv)
+	go func() { _ = s.Serve(lis) }()
+	tb.Cleanup(s.Stop)
+
+	// The calls go through the interceptors of the real client.
+	opts := {{$c.PackageName}}.DialOptions({{$c.PackageName}}.NewOptions())
+	conn, err := grpc.NewClient("passthrough:///{{$c.ConfigKey}}.fake", append(opts,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+	)...)
+	if err != nil {
+		tb.Fatalf("failed to create the fake {{$c.Name}} client: %v", err)
+	}
+
+	client := {{$c.PackageName}}.NewForConn(conn)
+	tb.Cleanup(func() { _ = client.Close() })
+	return client
+}
@@ -0,0 +1,68 @@
+{{- $c := .Web.TypedClient -}}
+package {{$c.PackageName}}
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/pflag"
+	{{- if $c.Discovery }}
+
+	"{{.D.ModuleName}}/internal/pkg/discovery"
+	{{- end}}
+)
+
+// Options contains the configuration of the {{$c.Name}} client.
+type Options struct {
+	// Addr is the gRPC address of {{$c.Param "server"}}.
+	Addr string `json:"addr" mapstructure:"addr"`
+	// Timeout bounds the calls whose context has no deadline.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+	// UseTLS connects to the server over TLS, verified with the system roots.
+	UseTLS bool `json:"use-tls" mapstructure:"use-tls"`
+	{{- if $c.Discovery }}
+	// Registry resolves the instances of {{$c.Server.BinaryName}} from Polaris instead of using Addr.
+	// Empty Registry.Addr disables the resolution.
+	Registry *discovery.PolarisOptions `json:"registry" mapstructure:"registry"`
+	{{- end}}
+}
+
+// NewOptions returns Options with default values.
+func NewOptions() *Options {
+	return &Options{
+		Addr:    {{$c.GoValue "addr"}},
+		Timeout: {{$c.GoValue "timeout"}},
+		{{- if $c.Discovery }}
+		Registry: &discovery.PolarisOptions{
+			Addr:      "127.0.0.1:8091",
+			Namespace: "{{.D.ProjectName}}",
+			Service:   "{{$c.Server.BinaryName}}",
+		},
+		{{- end}}
+	}
+}
+
+// AddFlags adds the flags of the options to fs, prefixed with prefix (e.g., "{{$c.ConfigKey}}").
+func (o *Options) AddFlags(fs *pflag.FlagSet, prefix string) {
+	fs.StringVar(&o.Addr, prefix+".addr", o.Addr, "gRPC address of the server of the {{$c.Name}} client.")
+	fs.DurationVar(&o.Timeout, prefix+".timeout", o.Timeout, "Timeout of the calls of the {{$c.Name}} client.")
+	fs.BoolVar(&o.UseTLS, prefix+".use-tls", o.UseTLS, "Connect to the server of the {{$c.Name}} client over TLS.")
+	{{- if $c.Discovery }}
+	o.Registry.AddFlags(fs, prefix+".registry")
+	{{- end}}
+}
+
+// Validate checks whether the options are valid.
+func (o *Options) Validate() []error {
+	var errs []error
+	if o.Addr == "" {
+		errs = append(errs, errors.New("{{$c.ConfigKey}}.addr must be set"))
+	}
+	if o.Timeout < 0 {
+		errs = append(errs, errors.New("{{$c.ConfigKey}}.timeout must not be negative"))
+	}
+	{{- if $c.Discovery }}
+	errs = append(errs, o.Registry.Validate()...)
+	{{- end}}
+	return errs
+}
@@ -0,0 +1,107 @@
+{{- $c := .Web.TypedClient -}}
+package {{$c.PackageName}}
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	{{- if .Web.WithOTel }}
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	{{- end}}
+	"resty.dev/v3"
+
+	"{{.D.ModuleName}}/internal/pkg/contextx"
+	{{- if $c.Discovery }}
+	"{{.D.ModuleName}}/internal/pkg/discovery"
+	{{- end}}
+	"{{.D.ModuleName}}/internal/pkg/known"
+)
+
+// Interface defines the basic operations of the {{$c.Name}} client.
+type Interface interface {
+	// C returns the underlying resty client instance.
+	C() *resty.Client
+	// R creates and returns a new resty request instance.
+	R(ctx context.Context) *resty.Request
+	// Healthz performs a health check against the client's configured endpoint.
+	Healthz(ctx context.Context) error
+}
+
+// Client implements the Interface for HTTP operations using resty.
+type Client struct {
+	client *resty.Client
+}
+
+// NewForConfig creates a new Client instance configured with the provided options.
+func NewForConfig(opts *Options) (*Client, error) {
+	var transport http.RoundTripper = http.DefaultTransport
+	{{- if $c.Discovery }}
+	if opts.Registry.Enabled() {
+		// Every request is sent to an instance of {{$c.Server.BinaryName}} resolved from Polaris.
+		resolver, err := discovery.NewResolver(opts.Registry)
+		if err != nil {
+			return nil, err
+		}
+		transport = resolver.Transport(transport)
+	}
+	{{- end}}
+	{{- if .Web.WithOTel }}
+	// Every request is traced as a span of the context of the request.
+	transport = otelhttp.NewTransport(transport)
+	{{- end}}
+	return NewWithTransport(opts, transport), nil
+}
+
+// NewWithTransport creates a new Client instance that sends its requests through transport.
+// The requests that fail with a temporary network error or a 429 or 5xx status code are
+// retried RetryCount times with an exponential backoff, if their method is idempotent.
+// The request ID of the context of a request is sent in its X-Request-ID header.
+func NewWithTransport(opts *Options, transport http.RoundTripper) *Client {
+	client := resty.NewWithClient(&http.Client{Transport: transport}).
+		SetBaseURL(opts.Endpoint).
+		SetHeader("User-Agent", opts.UserAgent).
+		SetTimeout(opts.Timeout).
+		SetDebug(opts.Debug).
+		SetRetryCount(opts.RetryCount).
+		SetRetryWaitTime(opts.RetryWaitTime).
+		SetRetryMaxWaitTime(opts.RetryMaxWaitTime).
+		AddRequestMiddleware(func(_ *resty.Client, r *resty.Request) error {
+			if requestID := contextx.RequestID(r.Context()); requestID != "" {
+				r.SetHeader(string(known.XRequestID), requestID)
+			}
+			return nil
+		})
+	return &Client{client: client}
+}
+
+// C returns the underlying resty client instance.
+func (c *Client) C() *resty.Client {
+	return c.client
+}
+
+// R creates and returns a new resty request instance.
+func (c *Client) R(ctx context.Context) *resty.Request {
+	return c.client.R().SetContext(ctx)
+}
+
+// Healthz performs a health check by sending a GET request to the /healthz endpoint.
+func (c *Client) Healthz(ctx context.Context) error {
+	resp, err := c.R(ctx).Get("/healthz")
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to send health check request", "error", err)
+		return fmt.Errorf("failed to send health check request: %w", err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		slog.ErrorContext(ctx, "health check failed with non-200 status code",
+			"status_code", resp.StatusCode(),
+			"response_body", resp.String(),
+		)
+		return fmt.Errorf("health check failed with status code %d", resp.StatusCode())
+	}
+
+	slog.InfoContext(ctx, "health check successful", "status_code", resp.StatusCode())
+	return nil
+}
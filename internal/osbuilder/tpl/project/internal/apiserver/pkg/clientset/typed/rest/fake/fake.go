@@ -0,0 +1,31 @@
+{{- $c := .Web.TypedClient -}}
+// Package fake provides the {{$c.Name}} client for tests, whose requests are served in memory.
+package fake
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/pkg/clientset/typed/{{$c.PackageName}}"
+)
+
+// NewClient returns the {{$c.Name}} client whose requests are served by handler in memory, for
+// example an http.ServeMux that returns canned responses. Requests are not retried.
+func NewClient(handler http.Handler) *{{$c.PackageName}}.Client {
+	opts := {{$c.PackageName}}.NewOptions()
+	opts.Endpoint = "http://{{$c.ConfigKey}}.fake"
+	opts.RetryCount = 0
+	return {{$c.PackageName}}.NewWithTransport(opts, roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, r)
+		return rec.Result(), nil
+	}))
+}
+
+// roundTripperFunc adapts a function to the http.RoundTripper interface.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper.
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,88 @@
+{{- $c := .Web.TypedClient -}}
+package {{$c.PackageName}}
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/spf13/pflag"
+	{{- if $c.Discovery }}
+
+	"{{.D.ModuleName}}/internal/pkg/discovery"
+	{{- end}}
+)
+
+// Options contains the configuration of the {{$c.Name}} client.
+type Options struct {
+	// Endpoint is the base URL of the requests (e.g., "http://127.0.0.1:9999").
+	Endpoint string `json:"endpoint" mapstructure:"endpoint"`
+	// UserAgent is the User-Agent header of the requests.
+	UserAgent string `json:"user-agent" mapstructure:"user-agent"`
+	// Timeout bounds every attempt of a request whose context has no deadline.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+	// RetryCount is the number of retries of the failed requests. Zero disables retries.
+	RetryCount int `json:"retry-count" mapstructure:"retry-count"`
+	// RetryWaitTime is the initial wait time before a retry, doubled by every retry.
+	RetryWaitTime time.Duration `json:"retry-wait-time" mapstructure:"retry-wait-time"`
+	// RetryMaxWaitTime is the maximum wait time before a retry.
+	RetryMaxWaitTime time.Duration `json:"retry-max-wait-time" mapstructure:"retry-max-wait-time"`
+	// Debug logs the requests and the responses.
+	Debug bool `json:"debug" mapstructure:"debug"`
+	{{- if $c.Discovery }}
+	// Registry resolves the instances of {{$c.Server.BinaryName}}, which replace the host of Endpoint,
+	// from Polaris. Empty Registry.Addr disables the resolution.
+	Registry *discovery.PolarisOptions `json:"registry" mapstructure:"registry"`
+	{{- end}}
+}
+
+// NewOptions returns Options with default values.
+func NewOptions() *Options {
+	return &Options{
+		Endpoint:         {{$c.GoValue "endpoint"}},
+		UserAgent:        "{{.Web.BinaryName}}",
+		Timeout:          {{$c.GoValue "timeout"}},
+		RetryCount:       {{$c.GoValue "retry-count"}},
+		RetryWaitTime:    100 * time.Millisecond,
+		RetryMaxWaitTime: 2 * time.Second,
+		{{- if $c.Discovery }}
+		Registry: &discovery.PolarisOptions{
+			Addr:      "127.0.0.1:8091",
+			Namespace: "{{.D.ProjectName}}",
+			Service:   "{{$c.Server.BinaryName}}",
+		},
+		{{- end}}
+	}
+}
+
+// AddFlags adds the flags of the options to fs, prefixed with prefix (e.g., "{{$c.ConfigKey}}").
+func (o *Options) AddFlags(fs *pflag.FlagSet, prefix string) {
+	fs.StringVar(&o.Endpoint, prefix+".endpoint", o.Endpoint, "Base URL of the requests of the {{$c.Name}} client.")
+	fs.StringVar(&o.UserAgent, prefix+".user-agent", o.UserAgent, "User-Agent header of the requests of the {{$c.Name}} client.")
+	fs.DurationVar(&o.Timeout, prefix+".timeout", o.Timeout, "Timeout of every attempt of a request of the {{$c.Name}} client.")
+	fs.IntVar(&o.RetryCount, prefix+".retry-count", o.RetryCount, "Number of retries of the failed requests of the {{$c.Name}} client.")
+	fs.DurationVar(&o.RetryWaitTime, prefix+".retry-wait-time", o.RetryWaitTime, "Initial wait time before a retry of the {{$c.Name}} client.")
+	fs.DurationVar(&o.RetryMaxWaitTime, prefix+".retry-max-wait-time", o.RetryMaxWaitTime, "Maximum wait time before a retry of the {{$c.Name}} client.")
+	fs.BoolVar(&o.Debug, prefix+".debug", o.Debug, "Log the requests and the responses of the {{$c.Name}} client.")
+	{{- if $c.Discovery }}
+	o.Registry.AddFlags(fs, prefix+".registry")
+	{{- end}}
+}
+
+// Validate checks whether the options are valid.
+func (o *Options) Validate() []error {
+	var errs []error
+	if u, err := url.Parse(o.Endpoint); err != nil || u.Scheme == "" || u.Host == "" {
+		errs = append(errs, errors.New("{{$c.ConfigKey}}.endpoint must be an absolute URL"))
+	}
+	if o.Timeout <= 0 {
+		errs = append(errs, errors.New("{{$c.ConfigKey}}.timeout must be positive"))
+	}
+	if o.RetryCount < 0 {
+		errs = append(errs, errors.New("{{$c.ConfigKey}}.retry-count cannot be negative"))
+	}
+	{{- if $c.Discovery }}
+	errs = append(errs, o.Registry.Validate()...)
+	{{- end}}
+	return errs
+}
@@ -0,0 +1,108 @@
+{{- $c := .Web.TypedClient -}}
+package {{$c.PackageName}}
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	{{- if .Web.WithOTel }}
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	{{- end}}
+)
+
+// ErrObjectNotFound is returned when the requested object does not exist in the bucket.
+var ErrObjectNotFound = errors.New("object not found")
+
+// Interface defines the operations on the objects of the bucket of the {{$c.Name}} client.
+type Interface interface {
+	// PutObject uploads the content of r as the object key. size is the length of the content,
+	// or -1 if it is unknown.
+	PutObject(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// GetObject returns the content of the object key, which the caller must close.
+	GetObject(ctx context.Context, key string) (io.ReadCloser, error)
+	// RemoveObject removes the object key. Removing an object that does not exist is not an error.
+	RemoveObject(ctx context.Context, key string) error
+	// PresignGetObject returns a URL that downloads the object key without credentials until
+	// it expires.
+	PresignGetObject(ctx context.Context, key string, expires time.Duration) (*url.URL, error)
+}
+
+// Client implements the Interface for an S3-compatible object storage using minio-go.
+type Client struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewForConfig creates a new Client instance configured with the provided options.
+func NewForConfig(opts *Options) (*Client, error) {
+	transport, err := minio.DefaultTransport(opts.UseSSL)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := minio.New(opts.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(opts.AccessKeyID, opts.SecretAccessKey, opts.SessionToken),
+		Secure: opts.UseSSL,
+		Region: opts.Region,
+		{{- if .Web.WithOTel }}
+		// Every request is traced as a span of the context of the request.
+		Transport: otelhttp.NewTransport(transport),
+		{{- else}}
+		Transport: transport,
+		{{- end}}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create {{$c.Name}} client: %w", err)
+	}
+	return &Client{client: client, bucket: opts.Bucket}, nil
+}
+
+// C returns the underlying minio client instance, for the operations that the Interface
+// does not provide.
+func (c *Client) C() *minio.Client {
+	return c.client
+}
+
+// PutObject uploads the content of r as the object key.
+func (c *Client) PutObject(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	_, err := c.client.PutObject(ctx, c.bucket, key, r, size, minio.PutObjectOptions{ContentType: contentType})
+	return err
+}
+
+// GetObject returns the content of the object key, which the caller must close.
+func (c *Client) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	obj, err := c.client.GetObject(ctx, c.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, convertError(err)
+	}
+	// The object is only requested when it is first read, so it is checked for existence first.
+	if _, err := obj.Stat(); err != nil {
+		_ = obj.Close()
+		return nil, convertError(err)
+	}
+	return obj, nil
+}
+
+// RemoveObject removes the object key.
+func (c *Client) RemoveObject(ctx context.Context, key string) error {
+	return c.client.RemoveObject(ctx, c.bucket, key, minio.RemoveObjectOptions{})
+}
+
+// PresignGetObject returns a URL that downloads the object key until it expires.
+func (c *Client) PresignGetObject(ctx context.Context, key string, expires time.Duration) (*url.URL, error) {
+	return c.client.PresignedGetObject(ctx, c.bucket, key, expires, nil)
+}
+
+// convertError converts the errors of missing objects to ErrObjectNotFound.
+func convertError(err error) error {
+	if resp := minio.ToErrorResponse(err); resp.Code == minio.NoSuchKey {
+		return fmt.Errorf("%w: %s", ErrObjectNotFound, resp.Key)
+	}
+	return err
+}
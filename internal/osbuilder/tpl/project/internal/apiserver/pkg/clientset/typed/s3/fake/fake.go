@@ -0,0 +1,96 @@
+{{- $c := .Web.TypedClient -}}
+// Package fake provides the {{$c.Name}} client for tests, which stores the objects in memory.
+package fake
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/pkg/clientset/typed/{{$c.PackageName}}"
+)
+
+// Object is an object stored by the fake client.
+type Object struct {
+	// Content is the content of the object.
+	Content []byte
+	// ContentType is the content type the object was uploaded with.
+	ContentType string
+}
+
+// Client implements the {{$c.PackageName}}.Interface in memory.
+type Client struct {
+	mu      sync.Mutex
+	objects map[string]*Object
+}
+
+// Ensure *Client implements the {{$c.PackageName}}.Interface.
+var _ {{$c.PackageName}}.Interface = (*Client)(nil)
+
+// NewClient returns a fake client that stores the given objects.
+func NewClient(objects map[string]*Object) *Client {
+	c := &Client{objects: make(map[string]*Object, len(objects))}
+	for key, obj := range objects {
+		c.objects[key] = obj
+	}
+	return c
+}
+
+// PutObject stores the content of r as the object key.
+func (c *Client) PutObject(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.objects[key] = &Object{Content: content, ContentType: contentType}
+	return nil
+}
+
+// GetObject returns the content of the object key.
+func (c *Client) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	obj, ok := c.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", {{$c.PackageName}}.ErrObjectNotFound, key)
+	}
+	return io.NopCloser(bytes.NewReader(obj.Content)), nil
+}
+
+// RemoveObject removes the object key.
+func (c *Client) RemoveObject(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.objects, key)
+	return nil
+}
+
+// PresignGetObject returns a fake URL of the object key, which encodes its expiration.
+func (c *Client) PresignGetObject(ctx context.Context, key string, expires time.Duration) (*url.URL, error) {
+	return &url.URL{
+		Scheme:   "https",
+		Host:     "{{$c.ConfigKey}}.fake",
+		Path:     "/" + key,
+		RawQuery: url.Values{"expires": {time.Now().Add(expires).UTC().Format(time.RFC3339)}}.Encode(),
+	}, nil
+}
+
+// Keys returns the sorted keys of the stored objects.
+func (c *Client) Keys() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]string, 0, len(c.objects))
+	for key := range c.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
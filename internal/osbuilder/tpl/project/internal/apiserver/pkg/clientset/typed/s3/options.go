@@ -0,0 +1,59 @@
+{{- $c := .Web.TypedClient -}}
+package {{$c.PackageName}}
+
+import (
+	"errors"
+
+	"github.com/spf13/pflag"
+)
+
+// Options contains the configuration of the {{$c.Name}} client.
+type Options struct {
+	// Endpoint is the host and port of the object storage, without scheme (e.g., "s3.amazonaws.com").
+	Endpoint string `json:"endpoint" mapstructure:"endpoint"`
+	// AccessKeyID is the access key of the credentials.
+	AccessKeyID string `json:"access-key-id" mapstructure:"access-key-id"`
+	// SecretAccessKey is the secret key of the credentials.
+	SecretAccessKey string `json:"secret-access-key" mapstructure:"secret-access-key"`
+	// SessionToken is the session token of temporary credentials, empty for permanent ones.
+	SessionToken string `json:"session-token" mapstructure:"session-token"`
+	// Bucket is the bucket of the objects.
+	Bucket string `json:"bucket" mapstructure:"bucket"`
+	// Region is the region of the bucket.
+	Region string `json:"region" mapstructure:"region"`
+	// UseSSL connects to the object storage over HTTPS.
+	UseSSL bool `json:"use-ssl" mapstructure:"use-ssl"`
+}
+
+// NewOptions returns Options with default values.
+func NewOptions() *Options {
+	return &Options{
+		Endpoint: {{$c.GoValue "endpoint"}},
+		Bucket:   {{$c.GoValue "bucket"}},
+		Region:   {{$c.GoValue "region"}},
+		UseSSL:   {{$c.GoValue "use-ssl"}},
+	}
+}
+
+// AddFlags adds the flags of the options to fs, prefixed with prefix (e.g., "{{$c.ConfigKey}}").
+func (o *Options) AddFlags(fs *pflag.FlagSet, prefix string) {
+	fs.StringVar(&o.Endpoint, prefix+".endpoint", o.Endpoint, "Host and port of the object storage of the {{$c.Name}} client.")
+	fs.StringVar(&o.AccessKeyID, prefix+".access-key-id", o.AccessKeyID, "Access key of the credentials of the {{$c.Name}} client.")
+	fs.StringVar(&o.SecretAccessKey, prefix+".secret-access-key", o.SecretAccessKey, "Secret key of the credentials of the {{$c.Name}} client.")
+	fs.StringVar(&o.SessionToken, prefix+".session-token", o.SessionToken, "Session token of the temporary credentials of the {{$c.Name}} client.")
+	fs.StringVar(&o.Bucket, prefix+".bucket", o.Bucket, "Bucket of the objects of the {{$c.Name}} client.")
+	fs.StringVar(&o.Region, prefix+".region", o.Region, "Region of the bucket of the {{$c.Name}} client.")
+	fs.BoolVar(&o.UseSSL, prefix+".use-ssl", o.UseSSL, "Connect to the object storage of the {{$c.Name}} client over HTTPS.")
+}
+
+// Validate checks whether the options are valid.
+func (o *Options) Validate() []error {
+	var errs []error
+	if o.Endpoint == "" {
+		errs = append(errs, errors.New("{{$c.ConfigKey}}.endpoint must be set"))
+	}
+	if o.Bucket == "" {
+		errs = append(errs, errors.New("{{$c.ConfigKey}}.bucket must be set"))
+	}
+	return errs
+}
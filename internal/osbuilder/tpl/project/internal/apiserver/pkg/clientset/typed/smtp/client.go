@@ -0,0 +1,188 @@
+{{- $c := .Web.TypedClient -}}
+package {{$c.PackageName}}
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Message is a mail sent by the {{$c.Name}} client.
+type Message struct {
+	// To lists the addresses of the recipients (e.g., "Colin <colin@example.com>").
+	To []string
+	// Cc lists the addresses of the recipients of copies.
+	Cc []string
+	// Subject is the subject of the mail.
+	Subject string
+	// Body is the content of the mail.
+	Body string
+	// HTML marks Body as HTML instead of plain text.
+	HTML bool
+}
+
+// Interface defines the operations of the {{$c.Name}} client.
+type Interface interface {
+	// Send sends msg from the sender of the client.
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Client implements the Interface using an SMTP server.
+type Client struct {
+	opts *Options
+	from *mail.Address
+}
+
+// NewForConfig creates a new Client instance configured with the provided options.
+func NewForConfig(opts *Options) (*Client, error) {
+	from, err := mail.ParseAddress(opts.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender %q: %w", opts.From, err)
+	}
+	return &Client{opts: opts, from: from}, nil
+}
+
+// Send sends msg through a new connection to the SMTP server. The connection is upgraded to TLS
+// if the server supports STARTTLS, and authenticated if a username is configured.
+func (c *Client) Send(ctx context.Context, msg *Message) error {
+	recipients, err := parseAddresses(append(append([]string{}, msg.To...), msg.Cc...))
+	if err != nil {
+		return err
+	}
+	if len(recipients) == 0 {
+		return errors.New("the message has no recipient")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.opts.Timeout)
+	defer cancel()
+
+	client, err := c.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to the SMTP server: %w", err)
+	}
+	defer client.Close()
+
+	if err := client.Mail(c.from.Address); err != nil {
+		return err
+	}
+	for _, rcpt := range recipients {
+		if err := client.Rcpt(rcpt.Address); err != nil {
+			return fmt.Errorf("recipient %s: %w", rcpt.Address, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(c.encode(msg)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// dial connects and authenticates to the SMTP server before the deadline of ctx.
+func (c *Client) dial(ctx context.Context) (*smtp.Client, error) {
+	addr := net.JoinHostPort(c.opts.Host, strconv.Itoa(c.opts.Port))
+	tlsConfig := &tls.Config{ServerName: c.opts.Host}
+
+	var conn net.Conn
+	var err error
+	if c.opts.ImplicitTLS {
+		conn, err = (&tls.Dialer{Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The SMTP commands do not take a context, so the deadline bounds the connection instead.
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, c.opts.Host)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	if ok, _ := client.Extension("STARTTLS"); ok && !c.opts.ImplicitTLS {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			_ = client.Close()
+			return nil, err
+		}
+	}
+	if c.opts.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", c.opts.Username, c.opts.Password, c.opts.Host)); err != nil {
+			_ = client.Close()
+			return nil, err
+		}
+	}
+	return client, nil
+}
+
+// encode returns msg in the Internet Message Format, with a quoted-printable UTF-8 body.
+func (c *Client) encode(msg *Message) []byte {
+	contentType := "text/plain"
+	if msg.HTML {
+		contentType = "text/html"
+	}
+
+	var buf bytes.Buffer
+	header := func(key, value string) {
+		if value != "" {
+			fmt.Fprintf(&buf, "%s: %s\r\n", key, value)
+		}
+	}
+	header("From", c.from.String())
+	header("To", formatAddresses(msg.To))
+	header("Cc", formatAddresses(msg.Cc))
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", contentType+"; charset=UTF-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	buf.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+	_, _ = w.Write([]byte(msg.Body))
+	_ = w.Close()
+	return buf.Bytes()
+}
+
+// parseAddresses parses the addresses of the recipients of a message.
+func parseAddresses(list []string) ([]*mail.Address, error) {
+	addrs := make([]*mail.Address, 0, len(list))
+	for _, s := range list {
+		addr, err := mail.ParseAddress(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient %q: %w", s, err)
+		}
+		addrs = append(addrs, addr)
+	}
+	return addrs, nil
+}
+
+// formatAddresses formats the addresses of a header, which were checked by parseAddresses.
+func formatAddresses(list []string) string {
+	formatted := make([]string, 0, len(list))
+	for _, s := range list {
+		if addr, err := mail.ParseAddress(s); err == nil {
+			formatted = append(formatted, addr.String())
+		}
+	}
+	return strings.Join(formatted, ", ")
+}
@@ -0,0 +1,47 @@
+{{- $c := .Web.TypedClient -}}
+// Package fake provides the {{$c.Name}} client for tests, which records the mails instead of sending
+// them.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/pkg/clientset/typed/{{$c.PackageName}}"
+)
+
+// Client implements the {{$c.PackageName}}.Interface by recording the sent messages.
+type Client struct {
+	mu   sync.Mutex
+	sent []*{{$c.PackageName}}.Message
+	err  error
+}
+
+// Ensure *Client implements the {{$c.PackageName}}.Interface.
+var _ {{$c.PackageName}}.Interface = (*Client)(nil)
+
+// NewClient returns a fake client that records the sent messages. If err is not nil, Send fails
+// with err instead.
+func NewClient(err error) *Client {
+	return &Client{err: err}
+}
+
+// Send records msg.
+func (c *Client) Send(ctx context.Context, msg *{{$c.PackageName}}.Message) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	sent := *msg
+	c.sent = append(c.sent, &sent)
+	return nil
+}
+
+// Sent returns the messages sent so far.
+func (c *Client) Sent() []*{{$c.PackageName}}.Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*{{$c.PackageName}}.Message(nil), c.sent...)
+}
@@ -0,0 +1,68 @@
+{{- $c := .Web.TypedClient -}}
+package {{$c.PackageName}}
+
+import (
+	"errors"
+	"net/mail"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Options contains the configuration of the {{$c.Name}} client.
+type Options struct {
+	// Host is the host of the SMTP server.
+	Host string `json:"host" mapstructure:"host"`
+	// Port is the port of the SMTP server, usually 25 or 587, or 465 with ImplicitTLS.
+	Port int `json:"port" mapstructure:"port"`
+	// Username is the user that the client authenticates as. Empty disables authentication.
+	Username string `json:"username" mapstructure:"username"`
+	// Password is the password of the user.
+	Password string `json:"password" mapstructure:"password"`
+	// From is the sender of the mails (e.g., "Support <support@example.com>").
+	From string `json:"from" mapstructure:"from"`
+	// ImplicitTLS connects to the SMTP server over TLS instead of upgrading the connection with
+	// STARTTLS.
+	ImplicitTLS bool `json:"implicit-tls" mapstructure:"implicit-tls"`
+	// Timeout bounds the sending of a mail.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+}
+
+// NewOptions returns Options with default values.
+func NewOptions() *Options {
+	return &Options{
+		Host:    {{$c.GoValue "host"}},
+		Port:    {{$c.GoValue "port"}},
+		From:    {{$c.GoValue "from"}},
+		Timeout: 10 * time.Second,
+	}
+}
+
+// AddFlags adds the flags of the options to fs, prefixed with prefix (e.g., "{{$c.ConfigKey}}").
+func (o *Options) AddFlags(fs *pflag.FlagSet, prefix string) {
+	fs.StringVar(&o.Host, prefix+".host", o.Host, "Host of the SMTP server of the {{$c.Name}} client.")
+	fs.IntVar(&o.Port, prefix+".port", o.Port, "Port of the SMTP server of the {{$c.Name}} client.")
+	fs.StringVar(&o.Username, prefix+".username", o.Username, "User that the {{$c.Name}} client authenticates as.")
+	fs.StringVar(&o.Password, prefix+".password", o.Password, "Password of the user of the {{$c.Name}} client.")
+	fs.StringVar(&o.From, prefix+".from", o.From, "Sender of the mails of the {{$c.Name}} client.")
+	fs.BoolVar(&o.ImplicitTLS, prefix+".implicit-tls", o.ImplicitTLS, "Connect to the SMTP server of the {{$c.Name}} client over TLS instead of STARTTLS.")
+	fs.DurationVar(&o.Timeout, prefix+".timeout", o.Timeout, "Timeout of the sending of a mail of the {{$c.Name}} client.")
+}
+
+// Validate checks whether the options are valid.
+func (o *Options) Validate() []error {
+	var errs []error
+	if o.Host == "" {
+		errs = append(errs, errors.New("{{$c.ConfigKey}}.host must be set"))
+	}
+	if o.Port <= 0 || o.Port > 65535 {
+		errs = append(errs, errors.New("{{$c.ConfigKey}}.port must be between 1 and 65535"))
+	}
+	if _, err := mail.ParseAddress(o.From); err != nil {
+		errs = append(errs, errors.New("{{$c.ConfigKey}}.from must be a mail address"))
+	}
+	if o.Timeout <= 0 {
+		errs = append(errs, errors.New("{{$c.ConfigKey}}.timeout must be positive"))
+	}
+	return errs
+}
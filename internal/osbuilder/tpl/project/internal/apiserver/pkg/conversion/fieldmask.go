@@ -0,0 +1,41 @@
+package conversion
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// CheckFieldMask reports an error if a path of the field mask is not a field of msg.
+// Paths are the JSON names of the fields (e.g., "createdAt").
+func CheckFieldMask(msg proto.Message, paths []string) error {
+	fields := msg.ProtoReflect().Descriptor().Fields()
+	for _, path := range paths {
+		if fields.ByJSONName(path) == nil {
+			return fmt.Errorf("unknown field %q in field mask", path)
+		}
+	}
+	return nil
+}
+
+// ApplyFieldMask clears the fields of msg that are not listed in paths.
+// An empty field mask keeps all fields.
+func ApplyFieldMask(msg proto.Message, paths []string) {
+	if len(paths) == 0 {
+		return
+	}
+
+	keep := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		keep[path] = true
+	}
+
+	m := msg.ProtoReflect()
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if !keep[fd.JSONName()] {
+			m.Clear(fd)
+		}
+		return true
+	})
+}
@@ -2,6 +2,10 @@ package conversion
 
 import (
 	"github.com/onexstack/onexstack/pkg/core"
+	{{- if .Web.R.IsSoftDelete}}
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+	{{- end}}
 
 	"{{.D.ModuleName}}/internal/{{.Web.Name}}/model"
 	{{.Web.APIImportPath}}
@@ -12,6 +16,13 @@ import (
 func {{.Web.R.MapModelToAPIFunc}}({{.Web.R.Last.SingularLowerFirst}}M *model.{{.Web.R.GORMModel}}) *{{.D.APIAlias}}.{{.Web.R.SingularName}} {
 	var {{.Web.R.Last.SingularLowerFirst}} {{.D.APIAlias}}.{{.Web.R.SingularName}}
 	_ = core.CopyWithConverters(&{{.Web.R.Last.SingularLowerFirst}}, {{.Web.R.Last.SingularLowerFirst}}M)
+	{{- if .Web.R.IsSoftDelete}}
+	// gorm.DeletedAt is a nullable time, which is converted explicitly.
+	{{.Web.R.Last.SingularLowerFirst}}.DeletedAt = nil
+	if {{.Web.R.Last.SingularLowerFirst}}M.DeletedAt.Valid {
+		{{.Web.R.Last.SingularLowerFirst}}.DeletedAt = timestamppb.New({{.Web.R.Last.SingularLowerFirst}}M.DeletedAt.Time)
+	}
+	{{- end}}
 	return &{{.Web.R.Last.SingularLowerFirst}}
 }
 
@@ -20,6 +31,12 @@ func {{.Web.R.MapModelToAPIFunc}}({{.Web.R.Last.SingularLowerFirst}}M *model.{{.
 func {{.Web.R.MapAPIToModelFunc}}({{.Web.R.Last.SingularLowerFirst}} *{{.D.APIAlias}}.{{.Web.R.SingularName}}) *model.{{.Web.R.GORMModel}} {
 	var {{.Web.R.Last.SingularLowerFirst}}M model.{{.Web.R.GORMModel}}
 	_ = core.CopyWithConverters(&{{.Web.R.Last.SingularLowerFirst}}M, {{.Web.R.Last.SingularLowerFirst}})
+	{{- if .Web.R.IsSoftDelete}}
+	{{.Web.R.Last.SingularLowerFirst}}M.DeletedAt = gorm.DeletedAt{}
+	if {{.Web.R.Last.SingularLowerFirst}}.DeletedAt != nil {
+		{{.Web.R.Last.SingularLowerFirst}}M.DeletedAt = gorm.DeletedAt{Time: {{.Web.R.Last.SingularLowerFirst}}.DeletedAt.AsTime(), Valid: true}
+	}
+	{{- end}}
 	return &{{.Web.R.Last.SingularLowerFirst}}M
 }
 
@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	// cacheResultCounter counts the lookups of the read-through caches of the stores.
+	cacheResultCounter metric.Int64Counter
+	// cacheOnce ensures the counter is created only once.
+	cacheOnce sync.Once
+)
+
+// RecordCacheResult increments the counter of the lookups of the read-through cache of a resource
+// by their result: hit, negative_hit or miss. It does nothing before Init is called.
+func RecordCacheResult(ctx context.Context, resource string, result string) {
+	if M == nil {
+		return
+	}
+	cacheOnce.Do(func() {
+		cacheResultCounter, _ = M.Meter.Int64Counter(
+			"{{.D.ProjectName | underscore}}_{{.Web.Name}}_store_cache_lookups_total",
+			metric.WithDescription("Total number of lookups of the read-through caches of the stores, by result"),
+		)
+	})
+	if cacheResultCounter == nil {
+		return
+	}
+
+	attrs := []attribute.KeyValue{attribute.String("resource", resource), attribute.String("result", result)}
+	cacheResultCounter.Add(ctx, 1, metric.WithAttributes(attrs...))
+}
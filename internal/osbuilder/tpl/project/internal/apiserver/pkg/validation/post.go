@@ -2,15 +2,30 @@ package validation
 
 import (
 	"context"
+	{{- if .Web.R.HasTimeRangeFilter}}
+	"time"
+	{{- end}}
 
 	genericvalidation "github.com/onexstack/onexstack/pkg/validation"
 
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/pkg/conversion"
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/store"
+	"{{.D.ModuleName}}/internal/pkg/errno"
 	{{.Web.APIImportPath}}
 )
 
 // Validate{{.Web.R.SingularName}}Rules returns a set of validation rules for {{.Web.R.SingularLower}}-related requests.
 func (v *Validator) Validate{{.Web.R.SingularName}}Rules() genericvalidation.Rules {
-	return genericvalidation.Rules{}
+	return genericvalidation.Rules{
+		{{- range .Web.R.Parents}}
+		"{{.IDField}}": func(value any) error {
+			if value.(string) == "" {
+				return errno.ErrInvalidArgument.WithMessage("{{.IDJSON}} cannot be empty")
+			}
+			return nil
+		},
+		{{- end}}
+	}
 }
 
 // ValidateCreate{{.Web.R.SingularName}}Request validates the fields of a Create{{.Web.R.SingularName}}Request.
@@ -19,7 +34,13 @@ func (v *Validator) ValidateCreate{{.Web.R.SingularName}}Request(ctx context.Con
 }
 
 // ValidateUpdate{{.Web.R.SingularName}}Request validates the fields of an Update{{.Web.R.SingularName}}Request.
+// The paths of the update mask must be fields that can be updated.
 func (v *Validator) ValidateUpdate{{.Web.R.SingularName}}Request(ctx context.Context, rq *{{.D.APIAlias}}.Update{{.Web.R.SingularName}}Request) error {
+	for _, path := range rq.GetUpdateMask().GetPaths() {
+		if _, ok := store.{{.Web.R.SingularName}}UpdateColumns[path]; !ok {
+			return errno.ErrInvalidArgument.WithMessage("unknown field %q in update mask", path)
+		}
+	}
 	return genericvalidation.ValidateAllFields(rq, v.Validate{{.Web.R.SingularName}}Rules())
 }
 
@@ -35,10 +56,68 @@ func (v *Validator) ValidateDelete{{.Web.R.PluralName}}Request(ctx context.Conte
 
 // ValidateGet{{.Web.R.SingularName}}Request validates the fields of a Get{{.Web.R.SingularName}}Request.
 func (v *Validator) ValidateGet{{.Web.R.SingularName}}Request(ctx context.Context, rq *{{.D.APIAlias}}.Get{{.Web.R.SingularName}}Request) error {
+	{{- if and .Web.R.IsSoftDelete .Web.WithUser}}
+	if rq.GetIncludeDeleted() {
+		if err := v.authorizeDeleted(ctx, "{{.Web.R.SingularLower}}", ActionReadDeleted); err != nil {
+			return err
+		}
+	}
+	{{- end}}
 	return genericvalidation.ValidateAllFields(rq, v.Validate{{.Web.R.SingularName}}Rules())
 }
 
-// ValidateList{{.Web.R.SingularName}}Request validates the fields of a List{{.Web.R.SingularName}}Request, focusing on selected fields ("Offset" and "Limit").
+// ValidateList{{.Web.R.SingularName}}Request validates the fields of a List{{.Web.R.SingularName}}Request, focusing on selected fields ({{if not .Web.R.IsCursorPaginated}}"Offset" and {{end}}"Limit") and the typed filters.
 func (v *Validator) ValidateList{{.Web.R.SingularName}}Request(ctx context.Context, rq *{{.D.APIAlias}}.List{{.Web.R.SingularName}}Request) error {
-	return genericvalidation.ValidateSelectedFields(rq, v.Validate{{.Web.R.SingularName}}Rules(), "Offset", "Limit")
+	if err := genericvalidation.ValidateSelectedFields(rq, v.Validate{{.Web.R.SingularName}}Rules(), {{if not .Web.R.IsCursorPaginated}}"Offset", {{end}}"Limit"{{range .Web.R.Parents}}, "{{.IDField}}"{{end}}); err != nil {
+		return err
+	}
+	{{- if and .Web.R.IsSoftDelete .Web.WithUser}}
+	if rq.GetIncludeDeleted() {
+		if err := v.authorizeDeleted(ctx, "{{.Web.R.SingularLower}}", ActionReadDeleted); err != nil {
+			return err
+		}
+	}
+	{{- end}}
+	{{- range .Web.R.ListFilters}}
+	{{- if .IsRepeated}}
+	if len(rq.{{.GoName}}) > store.MaxFilterValues {
+		return errno.ErrInvalidArgument.WithMessage("{{.Name}} accepts at most %d values", store.MaxFilterValues)
+	}
+	{{- else if .IsTimeRange}}
+	if rq.{{.GoName}} != nil {
+		if _, err := time.Parse(time.RFC3339, rq.Get{{.GoName}}()); err != nil {
+			return errno.ErrInvalidArgument.WithMessage("{{.Name}} must be an RFC 3339 timestamp")
+		}
+	}
+	{{- end}}
+	{{- end}}
+	if _, {{if .Web.R.IsCursorPaginated}}_, err := store.CursorOrder{{else}}err := store.OrderBy{{end}}(rq.GetOrderBy(), store.{{.Web.R.SingularName}}SortColumns); err != nil {
+		return errno.ErrInvalidArgument.WithMessage("%s", err.Error())
+	}
+	if err := conversion.CheckFieldMask(&{{.D.APIAlias}}.{{.Web.R.SingularName}}{}, rq.GetFieldMask()); err != nil {
+		return errno.ErrInvalidArgument.WithMessage("%s", err.Error())
+	}
+	return nil
 }
+{{- if .Web.R.IsSoftDelete}}
+
+// ValidateRestore{{.Web.R.SingularName}}Request validates the fields of a Restore{{.Web.R.SingularName}}Request.
+func (v *Validator) ValidateRestore{{.Web.R.SingularName}}Request(ctx context.Context, rq *{{.D.APIAlias}}.Restore{{.Web.R.SingularName}}Request) error {
+	{{- if .Web.WithUser}}
+	if err := v.authorizeDeleted(ctx, "{{.Web.R.SingularLower}}", ActionRestore); err != nil {
+		return err
+	}
+	{{- end}}
+	return genericvalidation.ValidateAllFields(rq, v.Validate{{.Web.R.SingularName}}Rules())
+}
+
+// ValidatePurge{{.Web.R.SingularName}}Request validates the fields of a Purge{{.Web.R.SingularName}}Request.
+func (v *Validator) ValidatePurge{{.Web.R.SingularName}}Request(ctx context.Context, rq *{{.D.APIAlias}}.Purge{{.Web.R.SingularName}}Request) error {
+	{{- if .Web.WithUser}}
+	if err := v.authorizeDeleted(ctx, "{{.Web.R.SingularLower}}", ActionPurge); err != nil {
+		return err
+	}
+	{{- end}}
+	return genericvalidation.ValidateAllFields(rq, v.Validate{{.Web.R.SingularName}}Rules())
+}
+{{- end}}
@@ -0,0 +1,14 @@
+package validation
+
+import (
+	"context"
+
+	genericvalidation "github.com/onexstack/onexstack/pkg/validation"
+
+	{{.Web.APIImportPath}}
+)
+
+// Validate{{.Web.R.Verb.GoName}}{{.Web.R.SingularName}}Request validates the fields of a {{.Web.R.Verb.GoName}}{{.Web.R.SingularName}}Request.
+func (v *Validator) Validate{{.Web.R.Verb.GoName}}{{.Web.R.SingularName}}Request(ctx context.Context, rq *{{.D.APIAlias}}.{{.Web.R.Verb.GoName}}{{.Web.R.SingularName}}Request) error {
+	return genericvalidation.ValidateAllFields(rq, v.Validate{{.Web.R.SingularName}}Rules())
+}
@@ -0,0 +1,34 @@
+package validation
+
+import (
+	"context"
+
+	"{{.D.ModuleName}}/internal/pkg/contextx"
+	"{{.D.ModuleName}}/internal/pkg/errno"
+)
+
+// Actions on the records of soft deleted kinds. They are authorized by the casbin policy with the
+// kind as the object, e.g., "p, role::user, post, purge, deny".
+const (
+	// ActionReadDeleted reads deleted records by setting includeDeleted on Get and List requests.
+	ActionReadDeleted = "read-deleted"
+	// ActionRestore undeletes a deleted record.
+	ActionRestore = "restore"
+	// ActionPurge permanently removes a record, whether it is deleted or not.
+	ActionPurge = "purge"
+)
+
+// authorizeDeleted checks that the user of the request may perform action on the deleted records of kind.
+func (v *Validator) authorizeDeleted(ctx context.Context, kind string, action string) error {
+	subject := contextx.UserID(ctx)
+	if allowed, err := v.authz.Authorize(subject, kind, action); err != nil || !allowed {
+		return errno.ErrPermissionDenied.WithMessage(
+			"access denied: subject=%s, object=%s, action=%s, reason=%v",
+			subject,
+			kind,
+			action,
+			err,
+		)
+	}
+	return nil
+}
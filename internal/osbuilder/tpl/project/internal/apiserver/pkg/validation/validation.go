@@ -6,6 +6,9 @@ import (
 
 	{{- end}}
 	"github.com/google/wire"
+	{{- if .Web.WithUser }}
+	"github.com/onexstack/onexstack/pkg/authz"
+	{{- end}}
 
 	"{{.D.ModuleName}}/internal/{{.Web.Name}}/store"
 	{{- if .Web.WithUser }}
@@ -20,6 +23,11 @@ type Validator struct {
 	// This is just an example. If validation requires other dependencies 
 	// like clients, services, resources, etc., they can all be injected here.
 	store store.IStore
+	{{- if .Web.WithUser }}
+
+	// authz checks the actions that are not bound to a route, such as reading deleted records.
+	authz *authz.Authz
+	{{- end}}
 }
 
 {{- if .Web.WithUser }}
@@ -38,8 +46,8 @@ var (
 var ProviderSet = wire.NewSet(New, wire.Bind(new(any), new(*Validator)))
 
 // New creates and initializes a new Validator instance with the required dependencies.
-func New(ds store.IStore) *Validator {
-	return &Validator{store: ds}
+func New(ds store.IStore{{- if .Web.WithUser }}, authz *authz.Authz{{- end}}) *Validator {
+	return &Validator{store: ds{{- if .Web.WithUser }}, authz: authz{{- end}}}
 }
 
 {{- if .Web.WithUser }}
@@ -5,12 +5,8 @@ import (
 	"log/slog"
     "time"
 
-    {{- if eq .Web.StorageType "memory" }}
-	"github.com/onexstack/onexstack/pkg/db"
-	{{- end}}
 	genericoptions "github.com/onexstack/onexstack/pkg/options"
 	"github.com/onexstack/onexstack/pkg/server"
-	"github.com/onexstack/onexstack/pkg/store/registry"
 	"gorm.io/gorm"
     {{- if .Web.WithUser}}
 	"github.com/onexstack/onexstack/pkg/authz"
@@ -18,12 +14,25 @@ import (
 	"github.com/onexstack/onexstack/pkg/token"
 	{{- end}}
 
+	{{- if .Web.WithGraphQL}}
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/graph"
+	{{- end}}
 	"{{.D.ModuleName}}/internal/{{.Web.Name}}/handler"
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/migrations"
+	{{- if .Web.Cache}}
+	"{{.D.ModuleName}}/internal/pkg/cache"
+	{{- end}}
+	{{- if .Web.Broker}}
+	"{{.D.ModuleName}}/internal/pkg/mq"
+	{{- end}}
+	{{- if .Web.Outbox}}
+	"{{.D.ModuleName}}/internal/pkg/outbox"
+	{{- end}}
     {{- if .Web.WithPreloader}}
 	"{{.D.ModuleName}}/internal/{{.Web.Name}}/pkg/asyncstore"
 	{{- end}}
-	{{- range .Web.Clients }}
-	"{{$.D.ModuleName}}/internal/{{$.Web.Name}}/pkg/clientset/typed/{{. | lowerkind}}"
+	{{- range .Web.AllClients }}
+	"{{$.D.ModuleName}}/internal/{{$.Web.Name}}/pkg/clientset/typed/{{.PackageName}}"
 	{{- end}}
     {{- if .Web.WithUser}}
 	"{{.D.ModuleName}}/internal/pkg/contextx"
@@ -32,10 +41,23 @@ import (
 	mw "{{.D.ModuleName}}/internal/pkg/middleware/gin"
 	{{- else if eq .Web.WebFramework "grpc"}}
 	mw "{{.D.ModuleName}}/internal/pkg/middleware/grpc"
+	{{- else if eq .Web.WebFramework "hertz"}}
+	mw "{{.D.ModuleName}}/internal/pkg/middleware/hertz"
+	{{- else if eq .Web.WebFramework "kitex"}}
+	mw "{{.D.ModuleName}}/internal/pkg/middleware/kitex"
+	{{- else if eq .Web.WebFramework "nethttp"}}
+	mw "{{.D.ModuleName}}/internal/pkg/middleware/nethttp"
+	{{- else if eq .Web.WebFramework "connect"}}
+	mw "{{.D.ModuleName}}/internal/pkg/middleware/connect"
 	{{- end}}
-	"{{.D.ModuleName}}/internal/{{.Web.Name}}/store"
 	"{{.D.ModuleName}}/internal/{{.Web.Name}}/model"
 	{{- end}}
+	{{- if or .Web.WithUser .Web.Datasources}}
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/store"
+	{{- end}}
+	{{- if or (eq .Web.WebFramework "kitex") (eq .Web.WebFramework "connect")}}
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/pkg/validation"
+	{{- end}}
 	{{- if .Web.WithOTel}}
     "{{.D.ModuleName}}/internal/{{.Web.Name}}/pkg/metrics"
     {{- end}}
@@ -54,10 +76,10 @@ type Config struct {
     Expiration        time.Duration
     {{- end}}
 	TLSOptions        *genericoptions.TLSOptions
-	{{- if or (eq .Web.WebFramework "gin") (eq .Web.WebFramework "grpc-gateway")}}
+	{{- if or (eq .Web.WebFramework "gin") (eq .Web.WebFramework "hertz") (eq .Web.WebFramework "nethttp") (eq .Web.WebFramework "grpc-gateway")}}
 	HTTPOptions       *genericoptions.HTTPOptions
 	{{- end}}
-	{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "grpc-gateway")}}
+	{{- if or (eq .Web.WebFramework "grpc") (eq .Web.WebFramework "kitex") (eq .Web.WebFramework "connect") (eq .Web.WebFramework "grpc-gateway")}}
 	GRPCOptions       *genericoptions.GRPCOptions
     {{- if .Web.WithOTel}}
 	MetricsAddr string
@@ -72,11 +94,26 @@ type Config struct {
 	{{- if eq .Web.StorageType "sqlite" }}
 	SQLiteOptions *genericoptions.SQLiteOptions
 	{{- end}}
+	{{- if .Web.ReadReplicas}}
+	ReplicaOptions []*genericoptions.{{.Web.DBOptions}}
+	{{- end}}
+	{{- if .Web.Datasources}}
+	DatasourceOptions map[string]*DatasourceOptions
+	{{- end}}
 	{{- if eq .Web.ServiceRegistry "polaris" }}
     PolarisOptions *genericoptions.PolarisOptions
 	{{- end}}
-	{{- range .Web.Clients }}
-	{{. | kind}}Options *genericoptions.RestyOptions	
+	{{- range .Web.AllClients }}
+	{{.GoName}}Options *{{.PackageName}}.Options
+	{{- end}}
+	{{- if .Web.Cache}}
+	CacheOptions *cache.Options
+	{{- end}}
+	{{- if .Web.Broker}}
+	MQOptions *mq.Options
+	{{- end}}
+	{{- if .Web.Outbox}}
+	OutboxOptions *outbox.Options
 	{{- end}}
 }
 
@@ -84,6 +121,9 @@ type Config struct {
 type Server struct {
     cfg         *ServerConfig
     srv         server.Server
+    {{- if .Web.Outbox}}
+    relay       *outbox.Relay
+    {{- end}}
 }
 
 // ServerConfig contains the core dependencies and configurations of the server.
@@ -91,6 +131,12 @@ type ServerConfig struct {
 	*Config
     Dependencies *Dependencies
     Handler      *handler.Handler
+    {{- if .Web.WithGraphQL}}
+    GraphQL      *graph.Handler
+    {{- end}}
+    {{- if or (eq .Web.WebFramework "kitex") (eq .Web.WebFramework "connect")}}
+    Validator    *validation.Validator
+    {{- end}}
     {{- if .Web.WithUser}}
     Retriever mw.UserRetriever
     Authz     *authz.Authz 
@@ -131,6 +177,15 @@ func (s *Server) Prepare(ctx context.Context) (*Server, error) {
 func (s *Server) Run(ctx context.Context) error {
 	// Start the HTTP/gRPC server in a background goroutine.
 	go s.srv.RunOrDie(ctx)
+	{{- if .Web.Outbox}}
+
+	// Publish the events written into the outbox until the context is canceled.
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		s.relay.Run(ctx)
+	}()
+	{{- end}}
 
 	{{- if eq .Web.ServiceRegistry "polaris" }}
 	if err := s.cfg.PolarisOptions.Register(); err != nil {
@@ -157,43 +212,64 @@ func (s *Server) Run(ctx context.Context) error {
 
     // Trigger graceful shutdown for all components.
 	s.srv.GracefulStop(shutdownCtx)
+	{{- if .Web.Outbox}}
+
+	// The messages of the batch being published when the relay stopped are published again on restart.
+	<-relayDone
+	if err := s.relay.Close(); err != nil {
+		slog.Error("failed to close the producer of the outbox relay", "error", err)
+	}
+	{{- end}}
 
 	slog.Info("server exited successfully")
 
 	return nil
 }
 
-// NewDB creates and returns a *gorm.DB instance for database operations.
+// NewDB creates and returns a *gorm.DB instance for database operations, with the schema migrated
+// to the current version.
 func (cfg *Config) NewDB() (*gorm.DB, error) {
-	slog.Info("initializing database connection", "type", "{{.Web.StorageType}}")
-
-	{{- if eq .Web.StorageType "mariadb" }}
-	dbInstance, err := cfg.MySQLOptions.NewDB()
-	{{- end}}
-	{{- if eq .Web.StorageType "postgresql" }}
-	dbInstance, err := cfg.PostgreSQLOptions.NewDB()
-	{{- end}}
-	{{- if eq .Web.StorageType "sqlite" }}
-	dbInstance, err := cfg.SQLiteOptions.NewDB()
-	{{- end}}
-	{{- if eq .Web.StorageType "memory" }}
-	// TODO: Retrieve the database path from configuration instead of hardcoding.
-	dbInstance, err := db.NewInMemorySQLite("/tmp/{{.Web.BinaryName}}.db")
-	{{- end}}
+	dbInstance, err := cfg.OpenDB()
 	if err != nil {
-		slog.Error("failed to create database connection", "error", err)
 		return nil, err
 	}
 
-	// Automatically migrate database schema
-	if err := registry.Migrate(dbInstance); err != nil {
-		slog.Error("failed to migrate database schema", "error", err)
+	// Apply the pending versioned migrations, which own the tables of the user and of the kinds
+	if err := migrations.Up(context.Background(), dbInstance); err != nil {
+		slog.Error("failed to apply database migrations", "error", err)
 		return nil, err
 	}
+	{{- if .Web.Datasources}}
+
+	// The named datasources have the tables of the kinds stored in them only
+	for name := range store.DatasourceModels() {
+		if err := cfg.migrateDatasource(name); err != nil {
+			slog.Error("failed to apply database migrations", "datasource", name, "error", err)
+			return nil, err
+		}
+	}
+	{{- end}}
 
 	return dbInstance, nil
 }
 
+{{- if .Web.Datasources}}
+
+// migrateDatasource applies the pending versioned migrations of the named datasource to its primary database.
+func (cfg *Config) migrateDatasource(name string) error {
+	db, err := cfg.OpenDatasource(name)
+	if err != nil {
+		return err
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close()
+	return migrations.UpDatasource(context.Background(), db, name)
+}
+{{- end}}
+
 {{- if .Web.WithUser}}
 // UserRetriever defines a user data retriever. It is used to get user information.
 type UserRetriever struct {
@@ -210,11 +286,38 @@ func (r *UserRetriever) GetUser(ctx context.Context, userID string) (*model.User
 func ProvideDB(cfg *Config) (*gorm.DB, error) {
 	return cfg.NewDB()
 }
+{{- if .Web.Cache}}
+
+// ProvideCache provides the backend of the read-through caches of the stores.
+func ProvideCache({{if eq .Web.Cache "redis"}}ctx{{else}}_{{end}} context.Context, cfg *Config) (cache.Cache, error) {
+	{{- if eq .Web.Cache "redis"}}
+	return cache.NewRedisFromOptions(ctx, cfg.CacheOptions.Redis)
+	{{- else}}
+	return cache.NewLRU(cfg.CacheOptions.Size), nil
+	{{- end}}
+}
+{{- end}}
+
+{{- if .Web.Broker}}
+
+// ProvideMQProducer provides the producer of the message broker of the server.
+func ProvideMQProducer(cfg *Config) (mq.Producer, error) {
+	return mq.NewProducer(cfg.MQOptions)
+}
+{{- end}}
+
+{{- if .Web.Outbox}}
+
+// ProvideOutboxRelay provides the relay that publishes the events of the outbox with producer.
+func ProvideOutboxRelay(db *gorm.DB, producer mq.Producer, cfg *Config) *outbox.Relay {
+	return outbox.NewRelay(db, producer, cfg.OutboxOptions)
+}
+{{- end}}
 
-{{- range .Web.Clients }}
-// Provide{{. | kind}}Client creates and returns a {{. | lowerkind}} client instance using the provided configuration.
-func Provide{{. | kind}}Client(cfg *Config) {{. | lowerkind}}.Interface {
-    return {{. | lowerkind}}.NewForConfig(cfg.{{. | kind}}Options)
+{{- range .Web.AllClients }}
+// Provide{{.GoName}}Client creates and returns the {{.Name}} client using the provided configuration.
+func Provide{{.GoName}}Client(cfg *Config) ({{.PackageName}}.Interface, error) {
+	return {{.PackageName}}.NewForConfig(cfg.{{.GoName}}Options)
 }
 {{- end}}
 
@@ -247,5 +350,13 @@ func NewWebServer(serverConfig *ServerConfig) (server.Server, error) {
 	{{- end}}
 	{{else if eq .Web.WebFramework "gin"}}
     return serverConfig.NewGinServer()
+	{{- else if eq .Web.WebFramework "hertz"}}
+    return serverConfig.NewHertzServer()
+	{{- else if eq .Web.WebFramework "kitex"}}
+    return serverConfig.NewKitexServer()
+	{{- else if eq .Web.WebFramework "nethttp"}}
+    return serverConfig.NewNetHTTPServer()
+	{{- else if eq .Web.WebFramework "connect"}}
+    return serverConfig.NewConnectServer()
 	{{- end -}}
 }
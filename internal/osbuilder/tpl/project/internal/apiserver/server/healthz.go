@@ -0,0 +1,15 @@
+package server
+
+import (
+	"context"
+
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/logic"
+	{{.Web.APIImportPath}}
+)
+
+// Healthz handles service health check requests.
+func (s *Server) Healthz(ctx context.Context, _ *emptypb.Empty) (*{{.D.APIAlias}}.HealthzResponse, error) {
+	return logic.NewHealthzLogic(ctx, s.svcCtx).Healthz()
+}
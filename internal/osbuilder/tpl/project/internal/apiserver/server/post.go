@@ -0,0 +1,38 @@
+package server
+
+import (
+	"context"
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/logic"
+	{{.Web.APIImportPath}}
+)
+
+// Create{{.Web.R.SingularName}} handles the creation of a new {{.Web.R.SingularLower}}.
+func (s *Server) Create{{.Web.R.SingularName}}(ctx context.Context, rq *{{.D.APIAlias}}.Create{{.Web.R.SingularName}}Request) (*{{.D.APIAlias}}.Create{{.Web.R.SingularName}}Response, error) {
+	return logic.New{{.Web.R.SingularName}}Logic(ctx, s.svcCtx).Create(rq)
+}
+
+// Update{{.Web.R.SingularName}} handles updating an existing {{.Web.R.SingularLower}}'s details.
+func (s *Server) Update{{.Web.R.SingularName}}(ctx context.Context, rq *{{.D.APIAlias}}.Update{{.Web.R.SingularName}}Request) (*{{.D.APIAlias}}.Update{{.Web.R.SingularName}}Response, error) {
+	return logic.New{{.Web.R.SingularName}}Logic(ctx, s.svcCtx).Update(rq)
+}
+
+// Delete{{.Web.R.SingularName}} handles the deletion of a single {{.Web.R.SingularLower}}.
+func (s *Server) Delete{{.Web.R.SingularName}}(ctx context.Context, rq *{{.D.APIAlias}}.Delete{{.Web.R.SingularName}}Request) (*{{.D.APIAlias}}.Delete{{.Web.R.SingularName}}Response, error) {
+	return logic.New{{.Web.R.SingularName}}Logic(ctx, s.svcCtx).Delete(rq)
+}
+
+// Delete{{.Web.R.PluralName}} deletes one or more {{.Web.R.PluralLower}}.
+func (s *Server) Delete{{.Web.R.PluralName}}(ctx context.Context, rq *{{.D.APIAlias}}.Delete{{.Web.R.PluralName}}Request) (*{{.D.APIAlias}}.Delete{{.Web.R.PluralName}}Response, error) {
+	return logic.New{{.Web.R.SingularName}}Logic(ctx, s.svcCtx).DeleteCollection(rq)
+}
+
+// Get{{.Web.R.SingularName}} retrieves information about a specific {{.Web.R.SingularLower}}.
+func (s *Server) Get{{.Web.R.SingularName}}(ctx context.Context, rq *{{.D.APIAlias}}.Get{{.Web.R.SingularName}}Request) (*{{.D.APIAlias}}.Get{{.Web.R.SingularName}}Response, error) {
+	return logic.New{{.Web.R.SingularName}}Logic(ctx, s.svcCtx).Get(rq)
+}
+
+// List{{.Web.R.SingularName}} retrieves a list of {{.Web.R.PluralLower}} based on query parameters.
+func (s *Server) List{{.Web.R.SingularName}}(ctx context.Context, rq *{{.D.APIAlias}}.List{{.Web.R.SingularName}}Request) (*{{.D.APIAlias}}.List{{.Web.R.SingularName}}Response, error) {
+	return logic.New{{.Web.R.SingularName}}Logic(ctx, s.svcCtx).List(rq)
+}
@@ -0,0 +1,22 @@
+package server
+
+import (
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/svc"
+	{{.Web.APIImportPath}}
+)
+
+// Server implements the {{.Web.GRPCServiceName}} gRPC service served by zrpc. Its methods delegate to the
+// same logics as the REST handlers.
+type Server struct {
+	{{.D.APIAlias}}.Unimplemented{{.Web.GRPCServiceName}}Server
+
+	svcCtx *svc.ServiceContext
+}
+
+// Ensure that Server implements the {{.D.APIAlias}}.{{.Web.GRPCServiceName}}Server interface.
+var _ {{.D.APIAlias}}.{{.Web.GRPCServiceName}}Server = (*Server)(nil)
+
+// NewServer creates a new instance of *Server.
+func NewServer(svcCtx *svc.ServiceContext) *Server {
+	return &Server{svcCtx: svcCtx}
+}
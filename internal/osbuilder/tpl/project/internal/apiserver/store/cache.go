@@ -0,0 +1,110 @@
+package store
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"gorm.io/gorm"
+
+	"{{.D.ModuleName}}/internal/pkg/cache"
+)
+
+// loaderOf returns the read-through loader of the records of a kind. It is shared by the stores
+// of the kind, so that concurrent misses of a record are collapsed into a single query.
+func loaderOf[T any](s *store, kind string, onResult func(ctx context.Context, result cache.Result)) *cache.Loader[T] {
+	s.loadersMu.Lock()
+	defer s.loadersMu.Unlock()
+
+	if l, ok := s.loaders[kind]; ok {
+		return l.(*cache.Loader[T])
+	}
+	l := cache.NewLoader[T](s.cache, kind, s.cacheOptions, gorm.ErrRecordNotFound)
+	l.OnResult = onResult
+	if s.loaders == nil {
+		s.loaders = map[string]any{}
+	}
+	s.loaders[kind] = l
+	return l
+}
+
+// recordKey returns the cache key of a record from its key columns and their values, given as
+// alternating column and value arguments (e.g., "post_id", "post-abc123").
+func recordKey(columnValues ...string) string {
+	parts := make([]string, 0, len(columnValues)/2)
+	for i := 0; i+1 < len(columnValues); i += 2 {
+		parts = append(parts, columnValues[i]+"="+columnValues[i+1])
+	}
+	return cache.Key(parts...)
+}
+
+// cacheKeyOf returns the cache key of the record matched by opts. Only the queries that filter
+// by exactly the key columns of a kind, with string values, are cached: any other query goes to
+// the database, since its result cannot be invalidated by key.
+func cacheKeyOf(opts *where.Options, columns ...string) (string, bool) {
+	if opts == nil || opts.Offset != 0 || len(opts.Clauses) > 0 || len(opts.Filters) != len(columns) {
+		return "", false
+	}
+	columnValues := make([]string, 0, 2*len(columns))
+	for _, column := range columns {
+		value, ok := opts.Filters[column].(string)
+		if !ok {
+			return "", false
+		}
+		columnValues = append(columnValues, column, value)
+	}
+	return recordKey(columnValues...), true
+}
+
+// inTransaction reports whether ctx carries a transaction. Reads in a transaction bypass the
+// cache, so that they see the uncommitted writes of the transaction.
+func inTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return ok
+}
+
+// afterCommitKey is the context key of the invalidations of a transaction.
+type afterCommitKey struct{}
+
+// afterCommit collects the invalidations of the records written in a transaction, which run
+// once the transaction has committed (see store.TX).
+type afterCommit struct {
+	mu  sync.Mutex
+	fns []func(ctx context.Context)
+}
+
+// withAfterCommit returns a context whose transaction defers its invalidations to the returned
+// afterCommit.
+func withAfterCommit(ctx context.Context) (context.Context, *afterCommit) {
+	a := &afterCommit{}
+	return context.WithValue(ctx, afterCommitKey{}, a), a
+}
+
+// run runs the collected invalidations with ctx, which carries no transaction.
+func (a *afterCommit) run(ctx context.Context) {
+	a.mu.Lock()
+	fns := a.fns
+	a.fns = nil
+	a.mu.Unlock()
+
+	for _, fn := range fns {
+		fn(ctx)
+	}
+}
+
+// invalidate removes the cached records of keys. In a transaction the records are removed after
+// it commits, since a read of the records before the commit would cache them again. A failure is
+// logged rather than returned, since the write that caused it has succeeded; the stale records
+// expire after their TTL.
+func invalidate[T any](ctx context.Context, l *cache.Loader[T], keys ...string) {
+	if a, ok := ctx.Value(afterCommitKey{}).(*afterCommit); ok && inTransaction(ctx) {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		a.fns = append(a.fns, func(ctx context.Context) { invalidate(ctx, l, keys...) })
+		return
+	}
+	if err := l.Invalidate(ctx, keys...); err != nil {
+		slog.ErrorContext(ctx, "failed to invalidate cached records", "keys", keys, "error", err)
+	}
+}
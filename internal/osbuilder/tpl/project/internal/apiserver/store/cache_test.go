@@ -0,0 +1,88 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"{{.D.ModuleName}}/internal/pkg/cache"
+)
+
+type cachedNote struct {
+	ID   int64
+	Body string
+}
+
+// newCachedNotes returns a store with an in-memory cache, whose database holds the note 1 with
+// the body "old", and the loader of the notes.
+func newCachedNotes(t *testing.T) (*store, *cache.Loader[cachedNote]) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "cache.db")), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&cachedNote{}))
+	require.NoError(t, db.Create(&cachedNote{ID: 1, Body: "old"}).Error)
+
+	s := &store{db: db, cache: cache.NewLRU(16), cacheOptions: cache.NewOptions()}
+	return s, loaderOf[cachedNote](s, "note", nil)
+}
+
+// readNote reads the note 1 through the cache, outside of any transaction.
+func readNote(t *testing.T, s *store, l *cache.Loader[cachedNote]) string {
+	t.Helper()
+	note, err := l.Get(context.Background(), recordKey("id", "1"), func(ctx context.Context) (*cachedNote, error) {
+		var note cachedNote
+		return &note, s.db.WithContext(ctx).First(&note, 1).Error
+	})
+	require.NoError(t, err)
+	return note.Body
+}
+
+// updateNote updates the note 1 and invalidates its cached record, as the cached stores do.
+func updateNote(ctx context.Context, s *store, l *cache.Loader[cachedNote], body string) error {
+	if err := s.DB(ctx).Model(&cachedNote{}).Where("id = ?", 1).Update("body", body).Error; err != nil {
+		return err
+	}
+	invalidate(ctx, l, recordKey("id", "1"))
+	return nil
+}
+
+func TestTXInvalidatesAfterCommit(t *testing.T) {
+	s, l := newCachedNotes(t)
+	assert.Equal(t, "old", readNote(t, s, l))
+
+	err := s.TX(context.Background(), func(ctx context.Context) error {
+		require.NoError(t, updateNote(ctx, s, l, "new"))
+		// A read during the transaction does not see the update, and caches the old record.
+		assert.Equal(t, "old", readNote(t, s, l))
+		return nil
+	})
+	require.NoError(t, err)
+
+	// The commit has invalidated the record cached during the transaction.
+	assert.Equal(t, "new", readNote(t, s, l))
+
+	// Outside of a transaction the record is invalidated at once.
+	require.NoError(t, updateNote(context.Background(), s, l, "newer"))
+	assert.Equal(t, "newer", readNote(t, s, l))
+}
+
+func TestTXRollbackKeepsCache(t *testing.T) {
+	s, l := newCachedNotes(t)
+	assert.Equal(t, "old", readNote(t, s, l))
+
+	errAbort := errors.New("abort")
+	err := s.TX(context.Background(), func(ctx context.Context) error {
+		require.NoError(t, updateNote(ctx, s, l, "new"))
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+
+	assert.Equal(t, "old", readNote(t, s, l))
+}
@@ -0,0 +1,233 @@
+package store
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// DefaultPageSize is the number of records returned by a cursor paginated List when no limit is given.
+const DefaultPageSize = 20
+
+// ErrInvalidPageToken is returned when a page token is malformed or was issued for another order.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// PageQuery describes a page of a cursor (keyset) paginated List.
+type PageQuery struct {
+	// PageToken is the token returned with the previous page, empty for the first page.
+	PageToken string
+	// Limit is the maximum number of records to return.
+	Limit int
+	// OrderBy orders the records by at most one field (see OrderBy). Records are always
+	// ordered by ID as well, so that the ordering key is unique.
+	OrderBy string
+	// Columns maps the fields that may be ordered by to their columns.
+	Columns map[string]string
+	// SkipTotal skips counting the records that match the query.
+	SkipTotal bool
+}
+
+// cursor is the position of the last record of a page. It is encoded into an opaque page token.
+type cursor struct {
+	// OrderBy is the order expression the token was issued for.
+	OrderBy string `json:"o,omitempty"`
+	// Value is the value of the ordering column of the last record.
+	Value json.RawMessage `json:"v,omitempty"`
+	// ID is the primary key of the last record.
+	ID json.RawMessage `json:"id"`
+}
+
+// CursorOrder parses the order expression of a cursor paginated List, which accepts at most one field.
+// It returns the column to order by, or an empty column to order by ID only.
+func CursorOrder(expr string, columns map[string]string) (column string, desc bool, err error) {
+	orderBy, err := OrderBy(expr, columns)
+	if err != nil {
+		return "", false, err
+	}
+	switch len(orderBy.Columns) {
+	case 0:
+		// Newest first, like the offset paginated List.
+		return "", true, nil
+	case 1:
+		return orderBy.Columns[0].Column.Name, orderBy.Columns[0].Desc, nil
+	default:
+		return "", false, fmt.Errorf("cursor pagination supports ordering by a single field")
+	}
+}
+
+// ListByCursor retrieves a page of T records from db, which already holds the filters of the query.
+// The page starts after the record encoded in q.PageToken and is ordered by (column, id), so that a
+// page is found with an index range scan instead of skipping the records of all previous pages.
+// A NULL of the ordering column is ordered as the zero value of its field (see sortKey).
+// nextPageToken is empty on the last page, and total is 0 if q.SkipTotal is set.
+func ListByCursor[T any](ctx context.Context, db *gorm.DB, q *PageQuery) (total int64, ret []*T, nextPageToken string, err error) {
+	column, desc, err := CursorOrder(q.OrderBy, q.Columns)
+	if err != nil {
+		return 0, nil, "", err
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+
+	// Reuse the filtered statement for both the count and the page query.
+	db = db.WithContext(ctx).Model(new(T)).Session(&gorm.Session{})
+	if !q.SkipTotal {
+		if err := db.Offset(-1).Limit(-1).Count(&total).Error; err != nil {
+			return 0, nil, "", err
+		}
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return 0, nil, "", err
+	}
+	idField := stmt.Schema.PrioritizedPrimaryField
+	if idField == nil {
+		return 0, nil, "", fmt.Errorf("%s has no primary key", stmt.Schema.Name)
+	}
+	sortField := idField
+	if column != "" {
+		if sortField = stmt.Schema.LookUpField(column); sortField == nil {
+			return 0, nil, "", fmt.Errorf("%s has no column %q", stmt.Schema.Name, column)
+		}
+	}
+
+	// key is the ordering key before the ID, nil to order by ID only.
+	var key any
+	if sortField != idField {
+		key = sortKey(sortField)
+	}
+
+	page := db.Offset(-1).Limit(limit + 1)
+	if q.PageToken != "" {
+		after, err := decodeCursor(q.PageToken, q.OrderBy, sortField, idField)
+		if err != nil {
+			return 0, nil, "", err
+		}
+		page = page.Where(after.condition(key, idField.DBName, desc))
+	}
+
+	dir := ""
+	if desc {
+		dir = " DESC"
+	}
+	orderBy := clause.Expr{SQL: "?" + dir, Vars: []any{clause.Column{Name: idField.DBName}}}
+	if key != nil {
+		orderBy = clause.Expr{SQL: "?" + dir + ", ?" + dir, Vars: []any{key, clause.Column{Name: idField.DBName}}}
+	}
+	if err := page.Clauses(clause.OrderBy{Expression: orderBy}).Find(&ret).Error; err != nil {
+		return 0, nil, "", err
+	}
+
+	if len(ret) > limit {
+		ret = ret[:limit]
+		if nextPageToken, err = encodeCursor(ctx, ret[limit-1], q.OrderBy, sortField, idField); err != nil {
+			return 0, nil, "", err
+		}
+	}
+	return total, ret, nextPageToken, nil
+}
+
+// sortKey returns the ordering key of a column. A NULL is read into the zero value of the field,
+// which is the value encoded into the page token, so a nullable column is ordered by its value
+// with NULL replaced by that zero value. This keeps the order consistent with the page tokens on
+// every database, whichever end the database sorts NULLs to. NOT NULL columns are ordered as is,
+// so that the page is found with an index range scan.
+func sortKey(field *schema.Field) any {
+	column := clause.Column{Name: field.DBName}
+	if field.NotNull || field.PrimaryKey {
+		return column
+	}
+	return clause.Expr{SQL: "COALESCE(?, ?)", Vars: []any{column, reflect.Zero(field.IndirectFieldType).Interface()}}
+}
+
+// position is a decoded cursor: the ordering key of the last record of the previous page.
+type position struct {
+	value any
+	id    any
+}
+
+// condition returns the keyset condition that selects the records after the position, where key
+// is the ordering key (see sortKey), or nil when records are ordered by ID only.
+func (p *position) condition(key any, idColumn string, desc bool) clause.Expression {
+	cmp := func(key any, value any) clause.Expression {
+		if desc {
+			return clause.Lt{Column: key, Value: value}
+		}
+		return clause.Gt{Column: key, Value: value}
+	}
+
+	id := clause.Column{Name: idColumn}
+	if key == nil {
+		return cmp(id, p.id)
+	}
+	// (key, id) > (value, id), written without row values, which not every database supports.
+	return clause.Or(
+		cmp(key, p.value),
+		clause.And(clause.Eq{Column: key, Value: p.value}, cmp(id, p.id)),
+	)
+}
+
+func encodeCursor(ctx context.Context, last any, orderBy string, sortField, idField *schema.Field) (string, error) {
+	rv := reflect.ValueOf(last)
+	c := cursor{OrderBy: orderBy}
+
+	id, _ := idField.ValueOf(ctx, rv)
+	var err error
+	if c.ID, err = json.Marshal(id); err != nil {
+		return "", err
+	}
+	if sortField != idField {
+		value, _ := sortField.ValueOf(ctx, rv)
+		if c.Value, err = json.Marshal(value); err != nil {
+			return "", err
+		}
+	}
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeCursor(token string, orderBy string, sortField, idField *schema.Field) (*position, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.OrderBy != orderBy {
+		return nil, ErrInvalidPageToken
+	}
+
+	p := &position{}
+	if p.id, err = decodeValue(c.ID, idField.FieldType); err != nil {
+		return nil, ErrInvalidPageToken
+	}
+	if sortField != idField {
+		// A NULL of a pointer field is encoded as null, which decodes to the zero value of sortKey.
+		if p.value, err = decodeValue(c.Value, sortField.IndirectFieldType); err != nil {
+			return nil, ErrInvalidPageToken
+		}
+	}
+	return p, nil
+}
+
+// decodeValue decodes raw into a value of type t, so that the value is bound with the type of the
+// column (e.g., time.Time instead of its JSON string).
+func decodeValue(raw json.RawMessage, t reflect.Type) (any, error) {
+	v := reflect.New(t)
+	if err := json.Unmarshal(raw, v.Interface()); err != nil {
+		return nil, err
+	}
+	return v.Elem().Interface(), nil
+}
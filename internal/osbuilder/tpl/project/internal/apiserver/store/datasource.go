@@ -0,0 +1,31 @@
+package store
+
+import "sync"
+
+var (
+	datasourceMu sync.Mutex
+	// datasourceModels holds the models of the kinds routed to each named datasource.
+	datasourceModels = map[string][]any{}
+)
+
+// routeToDatasource routes the queries of the tables of the given models to the named datasource.
+// It is called by the init functions of the stores of the kinds created with
+// `osbuilder create api --datasource`.
+func routeToDatasource(name string, models ...any) {
+	datasourceMu.Lock()
+	defer datasourceMu.Unlock()
+	datasourceModels[name] = append(datasourceModels[name], models...)
+}
+
+// DatasourceModels returns the models of the kinds routed to each named datasource. Their tables
+// live in the datasource instead of the primary database, so a transaction of the store, which
+// runs on the primary database, must not read nor write them.
+func DatasourceModels() map[string][]any {
+	datasourceMu.Lock()
+	defer datasourceMu.Unlock()
+	models := make(map[string][]any, len(datasourceModels))
+	for name, ms := range datasourceModels {
+		models[name] = append([]any(nil), ms...)
+	}
+	return models
+}
@@ -0,0 +1,66 @@
+package store
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/plugin/dbresolver"
+)
+
+// archivedEvent is stored in the test datasource, and note in the primary database.
+type archivedEvent struct {
+	ID   int64
+	Name string
+}
+
+type note struct {
+	ID   int64
+	Body string
+}
+
+func openTestDB(t *testing.T, name string) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), name+".db")), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	return db
+}
+
+func TestRouteToDatasource(t *testing.T) {
+	routeToDatasource("test-archive", &archivedEvent{})
+
+	models := DatasourceModels()
+	require.Len(t, models["test-archive"], 1)
+	assert.IsType(t, &archivedEvent{}, models["test-archive"][0])
+
+	// The models are copied: changing them does not change the routing.
+	models["test-archive"] = nil
+	assert.Len(t, DatasourceModels()["test-archive"], 1)
+
+	// The resolver sends the queries of the routed models to the datasource, and the others to
+	// the primary database.
+	primary, archive := openTestDB(t, "primary"), openTestDB(t, "archive")
+	require.NoError(t, primary.AutoMigrate(&note{}))
+	require.NoError(t, archive.AutoMigrate(&archivedEvent{}))
+	resolver := &dbresolver.DBResolver{}
+	resolver = resolver.Register(dbresolver.Config{Sources: []gorm.Dialector{archive.Dialector}}, DatasourceModels()["test-archive"]...)
+	require.NoError(t, primary.Use(resolver))
+
+	require.NoError(t, primary.Create(&archivedEvent{Name: "created"}).Error)
+	require.NoError(t, primary.Create(&note{Body: "hello"}).Error)
+
+	var count int64
+	require.NoError(t, archive.Model(&archivedEvent{}).Count(&count).Error)
+	assert.EqualValues(t, 1, count)
+	require.NoError(t, primary.Model(&note{}).Count(&count).Error)
+	assert.EqualValues(t, 1, count)
+	assert.False(t, archive.Migrator().HasTable(&note{}))
+
+	var got archivedEvent
+	require.NoError(t, primary.First(&got).Error)
+	assert.Equal(t, "created", got.Name)
+}
@@ -0,0 +1,96 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm/clause"
+)
+
+// MaxFilterValues is the maximum number of values accepted by an "in" filter of a List request.
+const MaxFilterValues = 100
+
+// likeEscaper escapes the LIKE wildcards with '!', which, unlike '\', has no special meaning
+// in the string literals of MySQL, PostgreSQL or SQLite.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// In returns a clause that matches records whose column is one of values.
+func In[T any](column string, values []T) clause.Expression {
+	vars := make([]any, 0, len(values))
+	for _, v := range values {
+		vars = append(vars, v)
+	}
+	return clause.IN{Column: clause.Column{Name: column}, Values: vars}
+}
+
+// HasPrefix returns a clause that matches records whose column starts with prefix.
+// LIKE wildcards in prefix are matched literally.
+func HasPrefix(column string, prefix string) clause.Expression {
+	return clause.Expr{
+		SQL:  "? LIKE ? ESCAPE '!'",
+		Vars: []any{clause.Column{Name: column}, likeEscaper.Replace(prefix) + "%"},
+	}
+}
+
+// AtLeast returns a clause that matches records whose column is greater than or equal to value.
+func AtLeast[T any](column string, value T) clause.Expression {
+	return clause.Gte{Column: clause.Column{Name: column}, Value: value}
+}
+
+// AtMost returns a clause that matches records whose column is less than or equal to value.
+func AtMost[T any](column string, value T) clause.Expression {
+	return clause.Lte{Column: clause.Column{Name: column}, Value: value}
+}
+
+// Since returns a clause that matches records whose column is at or after the RFC 3339 timestamp value.
+func Since(column string, value string) (clause.Expression, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamp %q: %w", value, err)
+	}
+	return clause.Gte{Column: clause.Column{Name: column}, Value: t}, nil
+}
+
+// Until returns a clause that matches records whose column is before the RFC 3339 timestamp value.
+func Until(column string, value string) (clause.Expression, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamp %q: %w", value, err)
+	}
+	return clause.Lt{Column: clause.Column{Name: column}, Value: t}, nil
+}
+
+// OrderBy parses an order expression such as "createdAt desc, title" into an ORDER BY clause.
+// Only the fields in columns, which maps field names to database columns, may be ordered by,
+// so user input never reaches the SQL statement.
+func OrderBy(expr string, columns map[string]string) (clause.OrderBy, error) {
+	var orderBy clause.OrderBy
+	for _, item := range strings.Split(expr, ",") {
+		parts := strings.Fields(item)
+		if len(parts) == 0 {
+			continue
+		}
+		if len(parts) > 2 {
+			return orderBy, fmt.Errorf("invalid orderBy item %q: expected \"<field> [asc|desc]\"", item)
+		}
+
+		column, ok := columns[parts[0]]
+		if !ok {
+			return orderBy, fmt.Errorf("cannot order by %q", parts[0])
+		}
+
+		desc := false
+		if len(parts) == 2 {
+			switch strings.ToLower(parts[1]) {
+			case "asc":
+			case "desc":
+				desc = true
+			default:
+				return orderBy, fmt.Errorf("invalid order direction %q: expected asc or desc", parts[1])
+			}
+		}
+		orderBy.Columns = append(orderBy.Columns, clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: desc})
+	}
+	return orderBy, nil
+}
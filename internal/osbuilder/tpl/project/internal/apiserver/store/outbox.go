@@ -0,0 +1,32 @@
+package store
+
+import (
+	"context"
+
+	"{{.D.ModuleName}}/internal/pkg/outbox"
+)
+
+// OutboxStore writes the events of the kinds created with `osbuilder create api --outbox` into the
+// outbox table, from which the outbox relay publishes them.
+type OutboxStore interface {
+	// Create writes a message into the outbox, in the transaction of ctx if any.
+	Create(ctx context.Context, msg *outbox.Message) error
+}
+
+// outboxStore implements the OutboxStore interface.
+type outboxStore struct {
+	store *store
+}
+
+// Ensure outboxStore implements the OutboxStore interface at compile time.
+var _ OutboxStore = (*outboxStore)(nil)
+
+// Outbox returns an instance that implements the OutboxStore interface.
+func (s *store) Outbox() OutboxStore {
+	return &outboxStore{store: s}
+}
+
+// Create writes a message into the outbox, in the transaction of ctx if any.
+func (s *outboxStore) Create(ctx context.Context, msg *outbox.Message) error {
+	return s.store.DB(ctx).Create(msg).Error
+}
@@ -7,6 +7,9 @@ import (
 	storelogger "github.com/onexstack/onexstack/pkg/logger/slog/store"
 	genericstore "github.com/onexstack/onexstack/pkg/store"
 	"github.com/onexstack/onexstack/pkg/store/where"
+	{{- if .Web.R.IsSoftDelete}}
+	"gorm.io/gorm"
+	{{- end}}
 
 	"{{.D.ModuleName}}/internal/{{.Web.Name}}/model"
 )
@@ -19,6 +22,9 @@ type {{.Web.R.SingularName}}Store interface {
 	// Update modifies an existing {{.Web.R.SingularLower}} record.
 	Update(ctx context.Context, obj *model.{{.Web.R.GORMModel}}) error
 
+	// UpdateColumns modifies only the given columns of an existing {{.Web.R.SingularLower}} record.
+	UpdateColumns(ctx context.Context, obj *model.{{.Web.R.GORMModel}}, columns ...string) error
+
 	// Delete removes {{.Web.R.SingularLower}} records matching the specified criteria.
 	Delete(ctx context.Context, opts *where.Options) error
 
@@ -27,6 +33,30 @@ type {{.Web.R.SingularName}}Store interface {
 
 	// List retrieves a list of {{.Web.R.SingularLower}} records and the total count matching the criteria.
 	List(ctx context.Context, opts *where.Options) (int64, []*model.{{.Web.R.GORMModel}}, error)
+	{{- if .Web.R.HasRelations}}
+
+	// GetWithPreload retrieves a single {{.Web.R.SingularLower}} record and eagerly loads the given associations.
+	GetWithPreload(ctx context.Context, opts *where.Options, preloads ...string) (*model.{{.Web.R.GORMModel}}, error)
+
+	// ListWithPreload retrieves a list of {{.Web.R.SingularLower}} records and eagerly loads the given associations.
+	ListWithPreload(ctx context.Context, opts *where.Options, preloads ...string) (int64, []*model.{{.Web.R.GORMModel}}, error)
+	{{- end}}
+	{{- if .Web.R.IsCursorPaginated}}
+
+	// ListByCursor retrieves a page of {{.Web.R.SingularLower}} records after the page token of the query.
+	ListByCursor(ctx context.Context, opts *where.Options, q *PageQuery) (int64, []*model.{{.Web.R.GORMModel}}, string, error)
+	{{- end}}
+	{{- if .Web.R.IsSoftDelete}}
+
+	// Unscoped returns a {{.Web.R.SingularLower}} store whose queries also match soft deleted records.
+	Unscoped() {{.Web.R.SingularName}}Store
+
+	// Restore undeletes the soft deleted {{.Web.R.SingularLower}} records matching the specified criteria.
+	Restore(ctx context.Context, opts *where.Options) error
+
+	// Purge permanently removes {{.Web.R.SingularLower}} records matching the specified criteria, whether they are deleted or not.
+	Purge(ctx context.Context, opts *where.Options) error
+	{{- end}}
 
 	// {{.Web.R.SingularName}}Expansion defines custom methods for the {{.Web.R.SingularLower}} store outside the generic CRUD operations.
 	{{.Web.R.SingularName}}Expansion
@@ -35,17 +65,193 @@ type {{.Web.R.SingularName}}Store interface {
 // {{.Web.R.SingularName}}Expansion is an extension interface for {{.Web.R.SingularName}}Store.
 type {{.Web.R.SingularName}}Expansion interface{}
 
+// {{.Web.R.SingularName}}SortColumns maps the fields that List requests may order {{.Web.R.PluralLower}} by to their columns.
+var {{.Web.R.SingularName}}SortColumns = map[string]string{
+	{{- range .Web.R.SortKeys}}
+	"{{.Name}}": "{{.Column}}",
+	{{- end}}
+}
+
+// {{.Web.R.SingularName}}UpdateColumns maps the fields that the update mask of Update requests may list to their columns.
+var {{.Web.R.SingularName}}UpdateColumns = map[string]string{
+	{{- range .Web.R.UpdatePaths}}
+	"{{.Name}}": "{{.Column}}",
+	{{- end}}
+}
+{{- if .Web.R.IsNested}}
+
+// {{.Web.R.SingularName}}Scope returns query options that scope {{.Web.R.SingularLower}} records to their parent resources.
+// Every query on a nested resource must start from this scope.
+func {{.Web.R.SingularName}}Scope({{range $i, $p := .Web.R.Parents}}{{if $i}}, {{end}}{{$p.IDJSON}}{{end}} string) *where.Options {
+	return where.F(
+		{{- range .Web.R.Parents}}
+		"{{.IDColumn}}", {{.IDJSON}},
+		{{- end}}
+	)
+}
+{{- end}}
+{{- if .Web.R.HasRelations}}
+
+// Associations of {{.Web.R.GORMModel}} that can be passed to GetWithPreload and ListWithPreload.
+const (
+	{{- range .Web.R.BelongsTo}}
+	// {{$.Web.R.SingularName}}Preload{{.GoName}} loads the {{.Ref.SingularLower}} the {{$.Web.R.SingularLower}} belongs to.
+	{{$.Web.R.SingularName}}Preload{{.GoName}} = "{{.GoName}}"
+	{{- end}}
+	{{- range .Web.R.HasMany}}
+	// {{$.Web.R.SingularName}}Preload{{.GoName}} loads the {{.Ref.PluralLower}} that belong to the {{$.Web.R.SingularLower}}.
+	{{$.Web.R.SingularName}}Preload{{.GoName}} = "{{.GoName}}"
+	{{- end}}
+)
+{{- end}}
+
 // {{.Web.R.SingularLower}}Store implements the {{.Web.R.SingularName}}Store interface using a generic store implementation.
 type {{.Web.R.SingularLower}}Store struct {
 	*genericstore.Store[model.{{.Web.R.GORMModel}}]
+
+	store *store
+	{{- if .Web.R.IsSoftDelete}}
+	// unscoped is set on the store returned by Unscoped.
+	unscoped bool
+	{{- end}}
 }
 
 // Ensure {{.Web.R.SingularLower}}Store implements {{.Web.R.SingularName}}Store at compile time.
 var _ {{.Web.R.SingularName}}Store = (*{{.Web.R.SingularLower}}Store)(nil)
+{{- if .Web.R.Datasource}}
+
+func init() {
+	// The {{.Web.R.PluralLower}} are stored in the {{.Web.R.Datasource}} datasource instead of the primary database.
+	routeToDatasource("{{.Web.R.Datasource}}", &model.{{.Web.R.GORMModel}}{})
+}
+{{- end}}
+
+{{- if .Web.R.IsCached}}
+
+// new{{.Web.R.SingularName}}Store returns a new instance of {{.Web.R.SingularName}}Store, whose Get requests
+// are served through the read-through cache of the store.
+func new{{.Web.R.SingularName}}Store(s *store) {{.Web.R.SingularName}}Store {
+	return newCached{{.Web.R.SingularName}}Store(s, &{{.Web.R.SingularLower}}Store{
+		Store: genericstore.NewStore[model.{{.Web.R.GORMModel}}](s, storelogger.NewLogger()),
+		store: s,
+	})
+}
+{{- else}}
 
 // new{{.Web.R.SingularName}}Store returns a new instance of {{.Web.R.SingularName}}Store.
 func new{{.Web.R.SingularName}}Store(s *store) *{{.Web.R.SingularLower}}Store {
 	return &{{.Web.R.SingularLower}}Store{
 		Store: genericstore.NewStore[model.{{.Web.R.GORMModel}}](s, storelogger.NewLogger()),
+		store: s,
+	}
+}
+{{- end}}
+{{- if .Web.R.HasResourceVersion}}
+
+// Update modifies an existing {{.Web.R.SingularLower}} record if it still has the resource version it was read with,
+// and increments the version. It returns ErrResourceVersionConflict if the record was modified in between.
+func (s *{{.Web.R.SingularLower}}Store) Update(ctx context.Context, obj *model.{{.Web.R.GORMModel}}) error {
+	return s.UpdateColumns(ctx, obj, "*")
+}
+
+// UpdateColumns modifies only the given columns of an existing {{.Web.R.SingularLower}} record, with the same
+// resource version check as Update.
+func (s *{{.Web.R.SingularLower}}Store) UpdateColumns(ctx context.Context, obj *model.{{.Web.R.GORMModel}}, columns ...string) error {
+	version := obj.ResourceVersion
+	obj.ResourceVersion++
+
+	db := s.store.DB(ctx).Model(obj).Where("resource_version = ?", version).
+		Select(append([]string{"resource_version"}, columns...)).Updates(obj)
+	err := db.Error
+	if err == nil && db.RowsAffected == 0 {
+		err = ErrResourceVersionConflict
 	}
+	if err != nil {
+		obj.ResourceVersion = version
+	}
+	return err
+}
+{{- else}}
+
+// UpdateColumns modifies only the given columns of an existing {{.Web.R.SingularLower}} record, including
+// columns set to their zero value. The other columns keep their stored values.
+func (s *{{.Web.R.SingularLower}}Store) UpdateColumns(ctx context.Context, obj *model.{{.Web.R.GORMModel}}, columns ...string) error {
+	return s.store.DB(ctx).Model(obj).Select(columns).Updates(obj).Error
+}
+{{- end}}
+{{- $db := "s.store.DB"}}
+{{- if .Web.R.IsSoftDelete}}
+{{- $db = "s.db"}}
+
+// Unscoped returns a {{.Web.R.SingularLower}} store whose queries also match soft deleted records.
+func (s *{{.Web.R.SingularLower}}Store) Unscoped() {{.Web.R.SingularName}}Store {
+	return &{{.Web.R.SingularLower}}Store{
+		Store:    genericstore.NewStore[model.{{.Web.R.GORMModel}}](&unscopedStore{s.store}, storelogger.NewLogger()),
+		store:    s.store,
+		unscoped: true,
+	}
+}
+
+// db returns the database instance for opts, which also matches soft deleted records if the store is unscoped.
+func (s *{{.Web.R.SingularLower}}Store) db(ctx context.Context, opts *where.Options) *gorm.DB {
+	db := s.store.DB(ctx, opts)
+	if s.unscoped {
+		return db.Unscoped()
+	}
+	return db
+}
+
+// Restore undeletes the soft deleted {{.Web.R.SingularLower}} records matching the specified criteria.
+// It returns gorm.ErrRecordNotFound if no deleted record matches.
+func (s *{{.Web.R.SingularLower}}Store) Restore(ctx context.Context, opts *where.Options) error {
+	db := s.store.DB(ctx, opts).Unscoped().Model(&model.{{.Web.R.GORMModel}}{}).
+		Where("deleted_at IS NOT NULL").
+		Update("deleted_at", nil)
+	if db.Error != nil {
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Purge permanently removes {{.Web.R.SingularLower}} records matching the specified criteria, whether they are deleted or not.
+func (s *{{.Web.R.SingularLower}}Store) Purge(ctx context.Context, opts *where.Options) error {
+	return s.store.DB(ctx, opts).Unscoped().Delete(&model.{{.Web.R.GORMModel}}{}).Error
+}
+{{- end}}
+{{- if .Web.R.HasRelations}}
+
+// GetWithPreload retrieves a single {{.Web.R.SingularLower}} record and eagerly loads the given associations.
+func (s *{{.Web.R.SingularLower}}Store) GetWithPreload(ctx context.Context, opts *where.Options, preloads ...string) (*model.{{.Web.R.GORMModel}}, error) {
+	db := {{$db}}(ctx, opts)
+	for _, preload := range preloads {
+		db = db.Preload(preload)
+	}
+
+	var obj model.{{.Web.R.GORMModel}}
+	if err := db.First(&obj).Error; err != nil {
+		return nil, err
+	}
+	return &obj, nil
+}
+
+// ListWithPreload retrieves a list of {{.Web.R.SingularLower}} records and eagerly loads the given associations.
+func (s *{{.Web.R.SingularLower}}Store) ListWithPreload(ctx context.Context, opts *where.Options, preloads ...string) (count int64, ret []*model.{{.Web.R.GORMModel}}, err error) {
+	db := {{$db}}(ctx, opts)
+	for _, preload := range preloads {
+		db = db.Preload(preload)
+	}
+
+	err = db.Order("id desc").Find(&ret).Offset(-1).Limit(-1).Count(&count).Error
+	return count, ret, err
+}
+{{- end}}
+{{- if .Web.R.IsCursorPaginated}}
+
+// ListByCursor retrieves a page of {{.Web.R.SingularLower}} records after the page token of the query.
+func (s *{{.Web.R.SingularLower}}Store) ListByCursor(ctx context.Context, opts *where.Options, q *PageQuery) (int64, []*model.{{.Web.R.GORMModel}}, string, error) {
+	return ListByCursor[model.{{.Web.R.GORMModel}}](ctx, {{$db}}(ctx, opts), q)
 }
+{{- end}}
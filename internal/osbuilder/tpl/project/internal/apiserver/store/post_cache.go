@@ -0,0 +1,185 @@
+// nolint: dupl
+package store
+
+import (
+	"context"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	{{- if .Web.IsResolved}}
+	"gorm.io/plugin/dbresolver"
+	{{- end}}
+	{{- if .Web.WithOTel}}
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/pkg/metrics"
+	{{- end}}
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/model"
+	"{{.D.ModuleName}}/internal/pkg/cache"
+	{{- if .Web.IsResolved}}
+	"{{.D.ModuleName}}/internal/pkg/contextx"
+	{{- end}}
+)
+
+// {{.Web.R.SingularLowerFirst}}CacheColumns are the columns that identify a cached {{.Web.R.SingularLower}} record.
+var {{.Web.R.SingularLowerFirst}}CacheColumns = []string{
+	{{- range .Web.R.Parents}}"{{.IDColumn}}", {{end}}"{{.Web.R.IDColumn}}"}
+
+// cached{{.Web.R.SingularName}}Store decorates a {{.Web.R.SingularName}}Store with a read-through cache. Get requests
+// by the key columns are served from the cache, and the cached records are invalidated by the
+// writes of the store. Writes made inside a transaction invalidate the records once the
+// transaction commits, so that a read during the transaction does not leave a stale record.
+type cached{{.Web.R.SingularName}}Store struct {
+	{{.Web.R.SingularName}}Store
+
+	store  *store
+	loader *cache.Loader[model.{{.Web.R.GORMModel}}]
+	{{- if .Web.R.IsSoftDelete}}
+	// unscoped is set on the store returned by Unscoped, whose reads bypass the cache.
+	unscoped bool
+	{{- end}}
+}
+
+// Ensure cached{{.Web.R.SingularName}}Store implements {{.Web.R.SingularName}}Store at compile time.
+var _ {{.Web.R.SingularName}}Store = (*cached{{.Web.R.SingularName}}Store)(nil)
+
+// newCached{{.Web.R.SingularName}}Store returns next decorated with the read-through cache of s.
+func newCached{{.Web.R.SingularName}}Store(s *store, next {{.Web.R.SingularName}}Store) *cached{{.Web.R.SingularName}}Store {
+	{{- if .Web.WithOTel}}
+	onResult := func(ctx context.Context, result cache.Result) {
+		metrics.RecordCacheResult(ctx, "{{.Web.R.SingularLower}}", string(result))
+	}
+	{{- else}}
+	var onResult func(ctx context.Context, result cache.Result)
+	{{- end}}
+	return &cached{{.Web.R.SingularName}}Store{
+		{{.Web.R.SingularName}}Store: next,
+		store:  s,
+		loader: loaderOf[model.{{.Web.R.GORMModel}}](s, "{{.Web.R.SingularLower}}", onResult),
+	}
+}
+
+// {{.Web.R.SingularLowerFirst}}CacheKey returns the cache key of a {{.Web.R.SingularLower}} record.
+func {{.Web.R.SingularLowerFirst}}CacheKey(obj *model.{{.Web.R.GORMModel}}) string {
+	return recordKey({{range .Web.R.Parents}}"{{.IDColumn}}", obj.{{.IDField}}, {{end}}"{{.Web.R.IDColumn}}", obj.{{.Web.R.IDField}})
+}
+
+// Get retrieves a single {{.Web.R.SingularLower}} record matching the specified criteria, from the cache if
+// the criteria are the key columns of the record.
+func (s *cached{{.Web.R.SingularName}}Store) Get(ctx context.Context, opts *where.Options) (*model.{{.Web.R.GORMModel}}, error) {
+	key, ok := cacheKeyOf(opts, {{.Web.R.SingularLowerFirst}}CacheColumns...)
+	if !ok || inTransaction(ctx){{if .Web.R.IsSoftDelete}} || s.unscoped{{end}} {
+		return s.{{.Web.R.SingularName}}Store.Get(ctx, opts)
+	}
+	return s.loader.Get(ctx, key, func(ctx context.Context) (*model.{{.Web.R.GORMModel}}, error) {
+		{{- if .Web.IsResolved}}
+		// Load from the primary database, so that a lagging read replica does not refill the cache
+		// with a record that was just invalidated.
+		ctx = contextx.WithReadFromPrimary(ctx)
+		{{- end}}
+		return s.{{.Web.R.SingularName}}Store.Get(ctx, opts)
+	})
+}
+
+// Create persists a new {{.Web.R.SingularLower}} record and forgets that it did not exist.
+func (s *cached{{.Web.R.SingularName}}Store) Create(ctx context.Context, obj *model.{{.Web.R.GORMModel}}) error {
+	if err := s.{{.Web.R.SingularName}}Store.Create(ctx, obj); err != nil {
+		return err
+	}
+	invalidate(ctx, s.loader, {{.Web.R.SingularLowerFirst}}CacheKey(obj))
+	return nil
+}
+
+// Update modifies an existing {{.Web.R.SingularLower}} record and invalidates its cached copy.
+func (s *cached{{.Web.R.SingularName}}Store) Update(ctx context.Context, obj *model.{{.Web.R.GORMModel}}) error {
+	if err := s.{{.Web.R.SingularName}}Store.Update(ctx, obj); err != nil {
+		return err
+	}
+	invalidate(ctx, s.loader, {{.Web.R.SingularLowerFirst}}CacheKey(obj))
+	return nil
+}
+
+// UpdateColumns modifies only the given columns of an existing {{.Web.R.SingularLower}} record and
+// invalidates its cached copy.
+func (s *cached{{.Web.R.SingularName}}Store) UpdateColumns(ctx context.Context, obj *model.{{.Web.R.GORMModel}}, columns ...string) error {
+	if err := s.{{.Web.R.SingularName}}Store.UpdateColumns(ctx, obj, columns...); err != nil {
+		return err
+	}
+	invalidate(ctx, s.loader, {{.Web.R.SingularLowerFirst}}CacheKey(obj))
+	return nil
+}
+
+// Delete removes {{.Web.R.SingularLower}} records matching the specified criteria and invalidates their
+// cached copies.
+func (s *cached{{.Web.R.SingularName}}Store) Delete(ctx context.Context, opts *where.Options) error {
+	keys, err := s.keysOf(ctx, opts{{if .Web.R.IsSoftDelete}}, s.unscoped{{end}})
+	if err != nil {
+		return err
+	}
+	if err := s.{{.Web.R.SingularName}}Store.Delete(ctx, opts); err != nil {
+		return err
+	}
+	invalidate(ctx, s.loader, keys...)
+	return nil
+}
+{{- if .Web.R.IsSoftDelete}}
+
+// Unscoped returns a {{.Web.R.SingularLower}} store whose queries also match soft deleted records. Its
+// reads bypass the cache, while its writes still invalidate the cached records.
+func (s *cached{{.Web.R.SingularName}}Store) Unscoped() {{.Web.R.SingularName}}Store {
+	return &cached{{.Web.R.SingularName}}Store{
+		{{.Web.R.SingularName}}Store: s.{{.Web.R.SingularName}}Store.Unscoped(),
+		store:    s.store,
+		loader:   s.loader,
+		unscoped: true,
+	}
+}
+
+// Restore undeletes the soft deleted {{.Web.R.SingularLower}} records matching the specified criteria and
+// forgets that they did not exist.
+func (s *cached{{.Web.R.SingularName}}Store) Restore(ctx context.Context, opts *where.Options) error {
+	keys, err := s.keysOf(ctx, opts, true)
+	if err != nil {
+		return err
+	}
+	if err := s.{{.Web.R.SingularName}}Store.Restore(ctx, opts); err != nil {
+		return err
+	}
+	invalidate(ctx, s.loader, keys...)
+	return nil
+}
+
+// Purge permanently removes {{.Web.R.SingularLower}} records matching the specified criteria and
+// invalidates their cached copies.
+func (s *cached{{.Web.R.SingularName}}Store) Purge(ctx context.Context, opts *where.Options) error {
+	keys, err := s.keysOf(ctx, opts, true)
+	if err != nil {
+		return err
+	}
+	if err := s.{{.Web.R.SingularName}}Store.Purge(ctx, opts); err != nil {
+		return err
+	}
+	invalidate(ctx, s.loader, keys...)
+	return nil
+}
+{{- end}}
+
+// keysOf returns the cache keys of the {{.Web.R.SingularLower}} records matching opts{{if .Web.R.IsSoftDelete}}, including the soft
+// deleted records if unscoped is set{{end}}.{{if .Web.IsResolved}} The keys are read from the primary database, which has the records
+// that the read replicas may not have received yet.{{end}}
+func (s *cached{{.Web.R.SingularName}}Store) keysOf(ctx context.Context, opts *where.Options{{if .Web.R.IsSoftDelete}}, unscoped bool{{end}}) ([]string, error) {
+	db := s.store.DB(ctx, opts){{if .Web.IsResolved}}.Clauses(dbresolver.Write){{end}}
+	{{- if .Web.R.IsSoftDelete}}
+	if unscoped {
+		db = db.Unscoped()
+	}
+	{{- end}}
+
+	var objs []*model.{{.Web.R.GORMModel}}
+	if err := db.Select({{.Web.R.SingularLowerFirst}}CacheColumns).Find(&objs).Error; err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		keys = append(keys, {{.Web.R.SingularLowerFirst}}CacheKey(obj))
+	}
+	return keys, nil
+}
@@ -0,0 +1,19 @@
+package store
+
+import (
+	"context"
+
+	"github.com/onexstack/onexstack/pkg/store/where"
+	"gorm.io/gorm"
+)
+
+// unscopedStore is a database provider whose queries also match soft deleted records.
+// It backs the Unscoped stores of the kinds that are soft deleted.
+type unscopedStore struct {
+	*store
+}
+
+// DB returns the database instance of the store without the soft delete scope.
+func (s *unscopedStore) DB(ctx context.Context, wheres ...where.Where) *gorm.DB {
+	return s.store.DB(ctx, wheres...).Unscoped()
+}
@@ -6,8 +6,21 @@ import (
 
 	"github.com/google/wire"
 	"gorm.io/gorm"
+	{{- if .Web.IsResolved}}
+	"gorm.io/plugin/dbresolver"
+	{{- end}}
 
 	"github.com/onexstack/onexstack/pkg/store/where"
+	{{- if .Web.Cache}}
+
+	"{{.D.ModuleName}}/internal/pkg/cache"
+	{{- if .Web.IsResolved}}
+	"{{.D.ModuleName}}/internal/pkg/contextx"
+	{{- end}}
+	{{- else if .Web.IsResolved}}
+
+	"{{.D.ModuleName}}/internal/pkg/contextx"
+	{{- end}}
 )
 
 // ProviderSet defines the dependency injection providers for the store layer.
@@ -30,6 +43,10 @@ type IStore interface {
     {{- if .Web.WithUser}}
     User() UserStore
     {{- end}}
+	{{- if .Web.Outbox}}
+	// Outbox returns the store of the events published by the outbox relay.
+	Outbox() OutboxStore
+	{{- end}}
 }
 
 // txKey is the context key for storing the transaction *gorm.DB instance.
@@ -38,6 +55,15 @@ type txKey struct{}
 // store is the concrete implementation of the Interface.
 type store struct {
 	db *gorm.DB
+	{{- if .Web.Cache}}
+
+	// cache backs the read-through caches of the kinds created with `osbuilder create api --cache`.
+	cache        cache.Cache
+	cacheOptions *cache.Options
+	// loaders holds the read-through loader of each cached kind (see loaderOf).
+	loadersMu sync.Mutex
+	loaders   map[string]any
+	{{- end}}
 
 	// Additional database instances can be added as needed.
 	// Example: fake *gorm.DB
@@ -45,17 +71,29 @@ type store struct {
 
 // Ensure store implements the Interface at compile time.
 var _ IStore = (*store)(nil)
+{{- if .Web.Cache}}
+
+// NewStore creates and returns a new store instance, whose cached kinds are read through c.
+func NewStore(db *gorm.DB, c cache.Cache, cacheOptions *cache.Options) *store {
+	// Initialize the singleton store instance only once.
+	once.Do(func() { S = &store{db: db, cache: c, cacheOptions: cacheOptions} })
+{{- else}}
 
 // NewStore creates and returns a new store instance.
 func NewStore(db *gorm.DB) *store {
 	// Initialize the singleton store instance only once.
-	once.Do(func() { S = &store{db} })
+	once.Do(func() { S = &store{db: db} })
+{{- end}}
 
 	return S
 }
 
 // DB returns the database instance. If a transaction exists in the context,
 // it returns the transactional DB; otherwise, it returns the core DB.
+{{- if .Web.IsResolved}}
+// The queries of the core DB go to the read replicas, unless the context asks
+// to read from the primary database with contextx.WithReadFromPrimary.
+{{- end}}
 // Optional 'where' clauses can be applied to the returned DB instance.
 func (s *store) DB(ctx context.Context, wheres ...where.Where) *gorm.DB {
 	db := s.db
@@ -63,6 +101,13 @@ func (s *store) DB(ctx context.Context, wheres ...where.Where) *gorm.DB {
 	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
 		db = tx
 	}
+	{{- if .Web.IsResolved}}
+
+	// Read your own writes despite the replication lag of the read replicas.
+	if contextx.ReadFromPrimary(ctx) {
+		db = db.Clauses(dbresolver.Write)
+	}
+	{{- end}}
 
 	for _, w := range wheres {
 		db = w.Where(db)
@@ -77,12 +122,29 @@ func (s *store) FakeDB(ctx context.Context) *gorm.DB { return nil }
 
 // TX executes the provided function fn within a database transaction.
 // It injects the transaction handle into the context passed to fn.
+{{- if .Web.IsResolved}}
+// The transaction runs on the primary database{{if .Web.Datasources}}, so it must not access the
+// kinds stored in a named datasource (see DatasourceModels){{end}}.
+{{- end}}
 func (s *store) TX(ctx context.Context, fn func(ctx context.Context) error) error {
+	{{- if .Web.Cache}}
+	// The cached records written in the transaction are invalidated once it has committed.
+	txCtx, invalidations := withAfterCommit(ctx)
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Inject the transaction DB into the context.
+		return fn(context.WithValue(txCtx, txKey{}, tx))
+	})
+	if err == nil {
+		invalidations.run(ctx)
+	}
+	return err
+	{{- else}}
 	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// Inject the transaction DB into the context.
 		ctx := context.WithValue(ctx, txKey{}, tx)
 		return fn(ctx)
 	})
+	{{- end}}
 }
 
 {{- if .Web.WithUser}}
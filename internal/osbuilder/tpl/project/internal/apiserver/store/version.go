@@ -0,0 +1,7 @@
+package store
+
+import "errors"
+
+// ErrResourceVersionConflict is returned by the Update of a versioned kind when the record was
+// modified since it was read, that is, when its resource version is no longer the one it was read with.
+var ErrResourceVersionConflict = errors.New("resource version conflict")
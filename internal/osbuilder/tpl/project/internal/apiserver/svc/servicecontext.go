@@ -0,0 +1,70 @@
+package svc
+
+import (
+	"context"
+
+	{{- if eq .Web.StorageType "memory" }}
+	"github.com/onexstack/onexstack/pkg/db"
+	{{- end}}
+	"github.com/zeromicro/go-zero/core/logx"
+	{{- if eq .Web.StorageType "mariadb" }}
+	"gorm.io/driver/mysql"
+	{{- else if eq .Web.StorageType "postgresql" }}
+	"gorm.io/driver/postgres"
+	{{- else if eq .Web.StorageType "sqlite" }}
+	"github.com/glebarez/sqlite"
+	{{- end}}
+	"gorm.io/gorm"
+
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/biz"
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/config"
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/migrations"
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/pkg/validation"
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/store"
+)
+
+// ServiceContext holds the dependencies shared by the handlers, logics and servers of {{.Web.BinaryName}}.
+type ServiceContext struct {
+	Config    config.Config
+	Store     store.IStore
+	Biz       biz.IBiz
+	Validator *validation.Validator
+}
+
+// NewServiceContext opens the database, migrates its schema to the current version and wires
+// the store, biz and validation layers on top of it. It exits the process if the database
+// cannot be opened or migrated.
+func NewServiceContext(c config.Config) *ServiceContext {
+	dbInstance, err := OpenDB(c)
+	logx.Must(err)
+
+	// Apply the pending versioned migrations, which own the tables of the user and of the kinds
+	logx.Must(migrations.Up(context.Background(), dbInstance))
+
+	ds := store.NewStore(dbInstance)
+	return &ServiceContext{
+		Config:    c,
+		Store:     ds,
+		Biz:       biz.NewBiz(ds),
+		Validator: validation.New(ds),
+	}
+}
+
+// OpenDB opens the database of {{.Web.BinaryName}} without changing its schema.
+func OpenDB({{if eq .Web.StorageType "memory"}}_{{else}}c{{end}} config.Config) (*gorm.DB, error) {
+	logx.Infow("initializing database connection", logx.Field("type", "{{.Web.StorageType}}"))
+
+	{{- if eq .Web.StorageType "mariadb" }}
+	return gorm.Open(mysql.Open(c.DB.DataSource), &gorm.Config{})
+	{{- end}}
+	{{- if eq .Web.StorageType "postgresql" }}
+	return gorm.Open(postgres.Open(c.DB.DataSource), &gorm.Config{})
+	{{- end}}
+	{{- if eq .Web.StorageType "sqlite" }}
+	return gorm.Open(sqlite.Open(c.DB.DataSource), &gorm.Config{})
+	{{- end}}
+	{{- if eq .Web.StorageType "memory" }}
+	// TODO: Retrieve the database path from configuration instead of hardcoding.
+	return db.NewInMemorySQLite("/tmp/{{.Web.BinaryName}}.db")
+	{{- end}}
+}
@@ -12,10 +12,13 @@ import (
     {{- end}}
 
 	"{{.D.ModuleName}}/internal/{{.Web.Name}}/biz"
+	{{- if .Web.WithGraphQL}}
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/graph"
+	{{- end}}
 	"{{.D.ModuleName}}/internal/{{.Web.Name}}/handler"
 	"{{.D.ModuleName}}/internal/{{.Web.Name}}/pkg/validation"
 	"{{.D.ModuleName}}/internal/{{.Web.Name}}/store"
-    {{- if .Web.Clients }}
+    {{- if .Web.AllClients }}
     "{{.D.ModuleName}}/internal/{{.Web.Name}}/pkg/clientset"
     {{- end}}
     {{- if .Web.WithUser}}
@@ -23,6 +26,14 @@ import (
     mw "{{.D.ModuleName}}/internal/pkg/middleware/gin"
     {{- else if eq .Web.WebFramework "grpc"}}
     mw "{{.D.ModuleName}}/internal/pkg/middleware/grpc"
+    {{- else if eq .Web.WebFramework "hertz"}}
+    mw "{{.D.ModuleName}}/internal/pkg/middleware/hertz"
+    {{- else if eq .Web.WebFramework "kitex"}}
+    mw "{{.D.ModuleName}}/internal/pkg/middleware/kitex"
+    {{- else if eq .Web.WebFramework "nethttp"}}
+    mw "{{.D.ModuleName}}/internal/pkg/middleware/nethttp"
+    {{- else if eq .Web.WebFramework "connect"}}
+    mw "{{.D.ModuleName}}/internal/pkg/middleware/connect"
     {{- end}}
     {{- end}}
 )
@@ -31,6 +42,14 @@ import (
 // This keeps the main wire.Build call clean.
 var infrastructureSet = wire.NewSet(
     ProvideDB,
+    {{- if .Web.Cache}}
+    ProvideCache,
+    wire.FieldsOf(new(*Config), "CacheOptions"),
+    {{- end}}
+    {{- if .Web.Outbox}}
+    ProvideMQProducer,
+    ProvideOutboxRelay,
+    {{- end}}
     {{- if .Web.WithUser}}
     wire.NewSet(
         wire.Struct(new(UserRetriever), "*"),
@@ -38,9 +57,9 @@ var infrastructureSet = wire.NewSet(
     ),
     authz.ProviderSet,
     {{- end}}
-    {{- if .Web.Clients }}
-    {{- range .Web.Clients }}
-    Provide{{. | kind}}Client,
+    {{- if .Web.AllClients }}
+    {{- range .Web.AllClients }}
+    Provide{{.GoName}}Client,
     {{- end}}
     {{- if .Web.WithPreloader }}
     ProvideAStore,
@@ -64,6 +83,9 @@ func NewServer(context.Context, *Config) (*Server, error) {
         biz.ProviderSet,
         validation.ProviderSet,
         handler.NewHandler,
+        {{- if .Web.WithGraphQL}}
+        graph.NewHandler,
+        {{- end}}
 
         // Infrastructure dependencies
         infrastructureSet,
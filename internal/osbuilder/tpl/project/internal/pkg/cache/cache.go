@@ -0,0 +1,125 @@
+// Package cache provides the read-through cache in front of the generated stores, with an
+// in-memory LRU and a Redis backend.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// ErrNotFound is returned by Cache.Get when the key is not cached.
+var ErrNotFound = errors.New("cache: key not found")
+
+// Cache is a cache backend that stores encoded records by key.
+type Cache interface {
+	// Get returns the value of key, or ErrNotFound if the key is not cached or expired.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set stores the value of key, which expires after ttl.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete removes the given keys. Keys that are not cached are ignored.
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// Result is the outcome of a lookup through a Loader, reported to Loader.OnResult.
+type Result string
+
+const (
+	// Hit means the record was served from the cache.
+	Hit Result = "hit"
+	// NegativeHit means the cache remembered that the record does not exist.
+	NegativeHit Result = "negative_hit"
+	// Miss means the record was loaded from the store.
+	Miss Result = "miss"
+)
+
+// Key joins the parts of a cache key with colons (e.g., "post:post_id=post-abc123").
+func Key(parts ...string) string {
+	return strings.Join(parts, ":")
+}
+
+// Loader reads records of type T through a cache. Concurrent misses of the same key are
+// collapsed into a single load, so that an expired hot key does not stampede the database.
+// Records that do not exist are cached too, for a shorter time.
+type Loader[T any] struct {
+	cache     Cache
+	namespace string
+	opts      *Options
+	notFound  error
+	group     singleflight.Group
+
+	// OnResult, if set, is called with the outcome of every lookup, e.g., to record metrics.
+	OnResult func(ctx context.Context, result Result)
+}
+
+// NewLoader returns a loader whose keys are prefixed with the key prefix of the options and
+// namespace. Loads that fail with an error matching notFound are cached as negative entries.
+func NewLoader[T any](c Cache, namespace string, opts *Options, notFound error) *Loader[T] {
+	if opts.KeyPrefix != "" {
+		namespace = Key(opts.KeyPrefix, namespace)
+	}
+	return &Loader[T]{cache: c, namespace: namespace, opts: opts, notFound: notFound}
+}
+
+// Get returns the cached record of key, or loads it with load and caches the outcome.
+// Cache errors are not fatal: the record is then loaded from the store.
+func (l *Loader[T]) Get(ctx context.Context, key string, load func(ctx context.Context) (*T, error)) (*T, error) {
+	key = Key(l.namespace, key)
+	if value, err := l.cache.Get(ctx, key); err == nil {
+		if len(value) == 0 {
+			l.report(ctx, NegativeHit)
+			return nil, l.notFound
+		}
+		var obj T
+		if err := json.Unmarshal(value, &obj); err == nil {
+			l.report(ctx, Hit)
+			return &obj, nil
+		}
+	}
+	l.report(ctx, Miss)
+
+	// The shared load must not be canceled by the caller that happens to run it.
+	v, err, _ := l.group.Do(key, func() (any, error) {
+		ctx := context.WithoutCancel(ctx)
+		obj, err := load(ctx)
+		switch {
+		case err == nil:
+			if value, err := json.Marshal(obj); err == nil {
+				_ = l.cache.Set(ctx, key, value, l.opts.TTL)
+			}
+		case errors.Is(err, l.notFound) && l.opts.NegativeTTL > 0:
+			_ = l.cache.Set(ctx, key, []byte{}, l.opts.NegativeTTL)
+		}
+		return obj, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Callers sharing a load get their own copy of the record.
+	obj := *v.(*T)
+	return &obj, nil
+}
+
+// Invalidate removes the cached records of the given keys, so that the next lookups load them
+// from the store.
+func (l *Loader[T]) Invalidate(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	namespaced := make([]string, 0, len(keys))
+	for _, key := range keys {
+		namespaced = append(namespaced, Key(l.namespace, key))
+	}
+	return l.cache.Delete(ctx, namespaced...)
+}
+
+func (l *Loader[T]) report(ctx context.Context, result Result) {
+	if l.OnResult != nil {
+		l.OnResult(ctx, result)
+	}
+}
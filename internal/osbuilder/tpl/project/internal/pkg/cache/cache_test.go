@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errRecordNotFound = errors.New("record not found")
+
+type record struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+func TestLRU(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	c := NewLRU(2)
+	c.now = func() time.Time { return now }
+
+	require.NoError(t, c.Set(ctx, "a", []byte("1"), time.Minute))
+	require.NoError(t, c.Set(ctx, "b", []byte("2"), 0))
+	_, err := c.Get(ctx, "a")
+	require.NoError(t, err)
+
+	// "b" is the least recently used entry and is evicted.
+	require.NoError(t, c.Set(ctx, "c", []byte("3"), time.Minute))
+	_, err = c.Get(ctx, "b")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, 2, c.Len())
+
+	now = now.Add(time.Minute)
+	_, err = c.Get(ctx, "a")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, c.Delete(ctx, "c", "missing"))
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestLoader(t *testing.T) {
+	ctx := context.Background()
+	opts := NewOptions()
+	opts.KeyPrefix = "test"
+	c := NewLRU(opts.Size)
+	l := NewLoader[record](c, "post", opts, errRecordNotFound)
+
+	var results []Result
+	l.OnResult = func(_ context.Context, r Result) { results = append(results, r) }
+
+	loads := 0
+	load := func(context.Context) (*record, error) {
+		loads++
+		return &record{ID: "post-1", Title: "hello"}, nil
+	}
+
+	obj, err := l.Get(ctx, "post-1", load)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", obj.Title)
+	obj.Title = "changed"
+
+	// The cached record is not affected by changes of the returned copy.
+	obj, err = l.Get(ctx, "post-1", load)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", obj.Title)
+	assert.Equal(t, 1, loads)
+	_, err = c.Get(ctx, "test:post:post-1")
+	require.NoError(t, err)
+
+	require.NoError(t, l.Invalidate(ctx, "post-1"))
+	_, err = l.Get(ctx, "post-1", load)
+	require.NoError(t, err)
+	assert.Equal(t, 2, loads)
+	assert.Equal(t, []Result{Miss, Hit, Miss}, results)
+}
+
+func TestLoaderNegativeCaching(t *testing.T) {
+	ctx := context.Background()
+	l := NewLoader[record](NewLRU(10), "post", NewOptions(), errRecordNotFound)
+
+	loads := 0
+	load := func(context.Context) (*record, error) {
+		loads++
+		return nil, errRecordNotFound
+	}
+	for range 3 {
+		_, err := l.Get(ctx, "missing", load)
+		assert.ErrorIs(t, err, errRecordNotFound)
+	}
+	assert.Equal(t, 1, loads)
+
+	// Other errors are not cached.
+	failing := func(context.Context) (*record, error) {
+		loads++
+		return nil, errors.New("connection refused")
+	}
+	for range 2 {
+		_, err := l.Get(ctx, "broken", failing)
+		assert.Error(t, err)
+	}
+	assert.Equal(t, 3, loads)
+}
+
+func TestLoaderSingleflight(t *testing.T) {
+	ctx := context.Background()
+	l := NewLoader[record](NewLRU(10), "post", NewOptions(), errRecordNotFound)
+
+	var loads atomic.Int32
+	release := make(chan struct{})
+	load := func(context.Context) (*record, error) {
+		loads.Add(1)
+		<-release
+		return &record{ID: "post-1"}, nil
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			obj, err := l.Get(ctx, "post-1", load)
+			assert.NoError(t, err)
+			assert.Equal(t, "post-1", obj.ID)
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), loads.Load())
+}
@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Options contains the configuration of the read-through cache of the stores.
+type Options struct {
+	// TTL is how long a record stays cached.
+	TTL time.Duration `json:"ttl" mapstructure:"ttl"`
+	// NegativeTTL is how long the absence of a record stays cached. Zero disables negative caching.
+	NegativeTTL time.Duration `json:"negative-ttl" mapstructure:"negative-ttl"`
+	// KeyPrefix is prepended to every key, so that several services can share a Redis server.
+	KeyPrefix string `json:"key-prefix" mapstructure:"key-prefix"`
+	// Size is the maximum number of records of the in-memory LRU.
+	Size int `json:"size" mapstructure:"size"`
+	// Redis contains the options of the Redis backend.
+	Redis *RedisOptions `json:"redis" mapstructure:"redis"`
+}
+
+// RedisOptions contains the options of the Redis backend of the cache.
+type RedisOptions struct {
+	// Addr is the address of the Redis server (e.g., "127.0.0.1:6379").
+	Addr string `json:"addr" mapstructure:"addr"`
+	// Username is the ACL username, empty for the default user.
+	Username string `json:"username" mapstructure:"username"`
+	// Password is the password of the user.
+	Password string `json:"password" mapstructure:"password"`
+	// Database is the number of the Redis database.
+	Database int `json:"database" mapstructure:"database"`
+	// Timeout bounds the dial, read and write operations.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+}
+
+// NewOptions returns Options with default values.
+func NewOptions() *Options {
+	return &Options{
+		TTL:         5 * time.Minute,
+		NegativeTTL: 30 * time.Second,
+		Size:        10000,
+		Redis: &RedisOptions{
+			Addr:    "127.0.0.1:6379",
+			Timeout: 3 * time.Second,
+		},
+	}
+}
+
+// AddFlags adds the flags of the options to fs, prefixed with prefix (e.g., "cache").
+func (o *Options) AddFlags(fs *pflag.FlagSet, prefix string) {
+	fs.DurationVar(&o.TTL, prefix+".ttl", o.TTL, "How long a record stays cached.")
+	fs.DurationVar(&o.NegativeTTL, prefix+".negative-ttl", o.NegativeTTL, "How long the absence of a record stays cached. Zero disables negative caching.")
+	fs.StringVar(&o.KeyPrefix, prefix+".key-prefix", o.KeyPrefix, "Prefix of the cache keys, to share a Redis server between services.")
+	fs.IntVar(&o.Size, prefix+".size", o.Size, "Maximum number of records of the in-memory LRU cache.")
+	fs.StringVar(&o.Redis.Addr, prefix+".redis.addr", o.Redis.Addr, "Address of the Redis server of the cache.")
+	fs.StringVar(&o.Redis.Username, prefix+".redis.username", o.Redis.Username, "Username of the Redis server of the cache.")
+	fs.StringVar(&o.Redis.Password, prefix+".redis.password", o.Redis.Password, "Password of the Redis server of the cache.")
+	fs.IntVar(&o.Redis.Database, prefix+".redis.database", o.Redis.Database, "Database number of the Redis server of the cache.")
+	fs.DurationVar(&o.Redis.Timeout, prefix+".redis.timeout", o.Redis.Timeout, "Timeout of the operations on the Redis server of the cache.")
+}
+
+// Validate checks whether the options are valid.
+func (o *Options) Validate() []error {
+	var errs []error
+	if o.TTL <= 0 {
+		errs = append(errs, errors.New("cache.ttl must be positive"))
+	}
+	if o.NegativeTTL < 0 {
+		errs = append(errs, errors.New("cache.negative-ttl cannot be negative"))
+	}
+	if o.Size <= 0 {
+		errs = append(errs, errors.New("cache.size must be positive"))
+	}
+	return errs
+}
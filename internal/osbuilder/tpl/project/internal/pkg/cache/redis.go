@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis is a Cache backed by Redis, shared by all the replicas of a server.
+type Redis struct {
+	client redis.UniversalClient
+}
+
+// Ensure Redis implements Cache at compile time.
+var _ Cache = (*Redis)(nil)
+
+// NewRedis returns a Redis cache that uses client.
+func NewRedis(client redis.UniversalClient) *Redis {
+	return &Redis{client: client}
+}
+
+// NewRedisFromOptions connects to the Redis server of the options and checks that it is reachable.
+func NewRedisFromOptions(ctx context.Context, opts *RedisOptions) (*Redis, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:         opts.Addr,
+		Username:     opts.Username,
+		Password:     opts.Password,
+		DB:           opts.Database,
+		DialTimeout:  opts.Timeout,
+		ReadTimeout:  opts.Timeout,
+		WriteTimeout: opts.Timeout,
+	})
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+	return NewRedis(client), nil
+}
+
+// Get returns the value of key, or ErrNotFound if the key is not cached or expired.
+func (c *Redis) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrNotFound
+	}
+	return value, err
+}
+
+// Set stores the value of key, which expires after ttl, or never if ttl is not positive.
+func (c *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if ttl < 0 {
+		ttl = 0
+	}
+	return c.client.Set(ctx, key, value, ttl).Err()
+}
+
+// Delete removes the given keys.
+func (c *Redis) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return c.client.Del(ctx, keys...).Err()
+}
+
+// Close closes the connections to Redis.
+func (c *Redis) Close() error {
+	return c.client.Close()
+}
@@ -22,6 +22,8 @@ const (
 	traceIDKey contextKey = "traceID"
 	// loggerKey is the context key for storing and retrieving a structured logger.
 	loggerKey contextKey = "logger"
+	// readFromPrimaryKey is the context key for reading from the primary database instead of the read replicas.
+	readFromPrimaryKey contextKey = "readFromPrimary"
 )
 
 // WithUserID returns a new context with the given user ID.
@@ -114,6 +116,20 @@ func Logger(ctx context.Context) *slog.Logger {
 	return val
 }
 
+// WithReadFromPrimary returns a new context whose database queries are sent to the primary database
+// instead of the read replicas, e.g., to read the records written earlier by the same request
+// despite the replication lag.
+func WithReadFromPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readFromPrimaryKey, true)
+}
+
+// ReadFromPrimary reports whether the database queries of the context must be sent to the
+// primary database. Returns false if WithReadFromPrimary was not called.
+func ReadFromPrimary(ctx context.Context) bool {
+	val, ok := ctx.Value(readFromPrimaryKey).(bool)
+	return ok && val
+}
+
 // L is a short alias for Logger, retrieving the structured logger from the context.
 // If no logger is found, it returns slog.Default().
 func L(ctx context.Context) *slog.Logger {
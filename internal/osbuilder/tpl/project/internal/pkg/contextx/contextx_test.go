@@ -0,0 +1,22 @@
+package contextx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadFromPrimary(t *testing.T) {
+	ctx := context.Background()
+	assert.False(t, ReadFromPrimary(ctx))
+
+	primary := WithReadFromPrimary(ctx)
+	assert.True(t, ReadFromPrimary(primary))
+	assert.False(t, ReadFromPrimary(ctx), "the parent context must not be changed")
+
+	// The contexts derived from the context read from the primary database too.
+	derived, cancel := context.WithCancel(WithUserID(primary, "user-1"))
+	defer cancel()
+	assert.True(t, ReadFromPrimary(derived))
+}
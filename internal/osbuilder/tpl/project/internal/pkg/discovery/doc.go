@@ -0,0 +1,18 @@
+/*
+Package discovery resolves the instances of the web servers of the project registered to a service registry, so that the clients of the clientsets call them without a static address.
+
+A Resolver resolves the instances of one service registered to Polaris. The REST clients send every request to an instance chosen by Polaris through the http.RoundTripper returned by Transport, and the gRPC clients balance their calls between the instances with the gRPC resolver returned by GRPCBuilder.
+
+Example:
+
+	resolver, err := discovery.NewResolver(&discovery.PolarisOptions{
+		Addr:      "127.0.0.1:8091",
+		Namespace: "miniblog",
+		Service:   "mb-usercenter",
+	})
+	if err != nil {
+		return err
+	}
+	conn, err := grpc.NewClient("polaris:///mb-usercenter", grpc.WithResolvers(resolver.GRPCBuilder(10*time.Second)))
+*/
+package discovery
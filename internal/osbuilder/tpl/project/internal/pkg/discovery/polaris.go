@@ -0,0 +1,215 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/polarismesh/polaris-go"
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc/resolver"
+)
+
+// PolarisOptions contains the configuration of the resolution of a service registered to
+// Polaris.
+type PolarisOptions struct {
+	// Addr is the address of the Polaris server. Empty disables the resolution.
+	Addr string `json:"addr" mapstructure:"addr"`
+	// Namespace is the namespace of the service.
+	Namespace string `json:"namespace" mapstructure:"namespace"`
+	// Service is the name of the service, which is the binary name of the web server.
+	Service string `json:"service" mapstructure:"service"`
+}
+
+// Enabled reports whether the service is resolved from Polaris.
+func (o *PolarisOptions) Enabled() bool {
+	return o != nil && o.Addr != ""
+}
+
+// AddFlags adds the flags of the options to fs, prefixed with prefix (e.g., "usercenter.registry").
+func (o *PolarisOptions) AddFlags(fs *pflag.FlagSet, prefix string) {
+	fs.StringVar(&o.Addr, prefix+".addr", o.Addr, "Address of the Polaris server that resolves the service. Empty uses the static address.")
+	fs.StringVar(&o.Namespace, prefix+".namespace", o.Namespace, "Polaris namespace of the service.")
+	fs.StringVar(&o.Service, prefix+".service", o.Service, "Polaris name of the service.")
+}
+
+// Validate checks whether the options are valid.
+func (o *PolarisOptions) Validate() []error {
+	if !o.Enabled() {
+		return nil
+	}
+
+	var errs []error
+	if o.Namespace == "" {
+		errs = append(errs, fmt.Errorf("the Polaris namespace of the service at %s must be set", o.Addr))
+	}
+	if o.Service == "" {
+		errs = append(errs, fmt.Errorf("the Polaris name of the service at %s must be set", o.Addr))
+	}
+	return errs
+}
+
+// Resolver resolves the instances of a service registered to Polaris.
+type Resolver struct {
+	consumer  polaris.ConsumerAPI
+	namespace string
+	service   string
+}
+
+// NewResolver creates a Resolver of the service configured by opts.
+func NewResolver(opts *PolarisOptions) (*Resolver, error) {
+	consumer, err := polaris.NewConsumerAPIByAddress(opts.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Polaris at %s: %w", opts.Addr, err)
+	}
+	return &Resolver{consumer: consumer, namespace: opts.Namespace, service: opts.Service}, nil
+}
+
+// Instance returns the address (host:port) of an instance of the service chosen by the load
+// balancer of Polaris.
+func (r *Resolver) Instance() (string, error) {
+	req := &polaris.GetOneInstanceRequest{}
+	req.Namespace = r.namespace
+	req.Service = r.service
+	resp, err := r.consumer.GetOneInstance(req)
+	if err != nil {
+		return "", err
+	}
+	inst := resp.GetInstance()
+	if inst == nil {
+		return "", r.errNoInstance()
+	}
+	return net.JoinHostPort(inst.GetHost(), strconv.Itoa(int(inst.GetPort()))), nil
+}
+
+// Instances returns the addresses of the healthy instances of the service.
+func (r *Resolver) Instances() ([]string, error) {
+	req := &polaris.GetInstancesRequest{}
+	req.Namespace = r.namespace
+	req.Service = r.service
+	resp, err := r.consumer.GetInstances(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var addrs []string
+	for _, inst := range resp.GetInstances() {
+		if inst.IsHealthy() && !inst.IsIsolated() {
+			addrs = append(addrs, net.JoinHostPort(inst.GetHost(), strconv.Itoa(int(inst.GetPort()))))
+		}
+	}
+	if len(addrs) == 0 {
+		return nil, r.errNoInstance()
+	}
+	return addrs, nil
+}
+
+func (r *Resolver) errNoInstance() error {
+	return fmt.Errorf("no instance of service %s/%s", r.namespace, r.service)
+}
+
+// Transport returns an http.RoundTripper that sends every request through next to an instance of
+// the service, which replaces the host of the URL of the request.
+func (r *Resolver) Transport(next http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		addr, err := r.Instance()
+		if err != nil {
+			return nil, err
+		}
+
+		req = req.Clone(req.Context())
+		req.URL.Host = addr
+		req.Host = ""
+		return next.RoundTrip(req)
+	})
+}
+
+// roundTripperFunc adapts a function to the http.RoundTripper interface.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper.
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// GRPCBuilder returns a gRPC resolver builder of the "polaris" scheme, which resolves the targets
+// "polaris:///<service>" to the instances of the service and refreshes them every interval.
+func (r *Resolver) GRPCBuilder(interval time.Duration) resolver.Builder {
+	return &grpcBuilder{r: r, interval: interval}
+}
+
+type grpcBuilder struct {
+	r        *Resolver
+	interval time.Duration
+}
+
+// Scheme implements resolver.Builder.
+func (b *grpcBuilder) Scheme() string {
+	return "polaris"
+}
+
+// Build implements resolver.Builder.
+func (b *grpcBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	if service := target.Endpoint(); service != b.r.service {
+		return nil, fmt.Errorf("unexpected service %q in target %s, want %q", service, target.URL.String(), b.r.service)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	gr := &grpcResolver{r: b.r, cc: cc, cancel: cancel, resolveNow: make(chan struct{}, 1)}
+	go gr.watch(ctx, b.interval)
+	return gr, nil
+}
+
+// grpcResolver updates the addresses of a gRPC connection with the instances of the service.
+type grpcResolver struct {
+	r          *Resolver
+	cc         resolver.ClientConn
+	cancel     context.CancelFunc
+	resolveNow chan struct{}
+}
+
+// ResolveNow implements resolver.Resolver.
+func (gr *grpcResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case gr.resolveNow <- struct{}{}:
+	default:
+	}
+}
+
+// Close implements resolver.Resolver.
+func (gr *grpcResolver) Close() {
+	gr.cancel()
+}
+
+// watch resolves the instances of the service until ctx is canceled.
+func (gr *grpcResolver) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		gr.resolve()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-gr.resolveNow:
+		}
+	}
+}
+
+func (gr *grpcResolver) resolve() {
+	addrs, err := gr.r.Instances()
+	if err != nil {
+		gr.cc.ReportError(err)
+		return
+	}
+
+	state := resolver.State{Addresses: make([]resolver.Address, 0, len(addrs))}
+	for _, addr := range addrs {
+		state.Addresses = append(state.Addresses, resolver.Address{Addr: addr})
+	}
+	_ = gr.cc.UpdateState(state)
+}
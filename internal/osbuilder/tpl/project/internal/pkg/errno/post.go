@@ -23,3 +23,21 @@ var Err{{.Web.R.SingularName}}GetFailed = errorsx.New(http.StatusInternalServerE
 
 // Err{{.Web.R.SingularName}}ListFailed indicates that listing {{.Web.R.PluralLower}} failed.
 var Err{{.Web.R.SingularName}}ListFailed = errorsx.New(http.StatusInternalServerError, "InternalError.{{.Web.R.SingularName}}ListFailed", "Failed to list {{.Web.R.PluralLower}}.")
+{{- if .Web.R.HasResourceVersion}}
+
+// Err{{.Web.R.SingularName}}VersionConflict indicates that the {{.Web.R.SingularLower}} was modified since the version the update is based on.
+var Err{{.Web.R.SingularName}}VersionConflict = errorsx.New(http.StatusConflict, "Aborted.{{.Web.R.SingularName}}VersionConflict", "The {{.Web.R.SingularLower}} was modified by another request. Get the latest version and retry.")
+{{- end}}
+{{- if .Web.R.IsSoftDelete}}
+
+// Err{{.Web.R.SingularName}}RestoreFailed indicates that restoring a deleted {{.Web.R.SingularLower}} failed.
+var Err{{.Web.R.SingularName}}RestoreFailed = errorsx.New(http.StatusInternalServerError, "InternalError.{{.Web.R.SingularName}}RestoreFailed", "Failed to restore the {{.Web.R.SingularLower}}.")
+
+// Err{{.Web.R.SingularName}}PurgeFailed indicates that permanently removing the {{.Web.R.SingularLower}} failed.
+var Err{{.Web.R.SingularName}}PurgeFailed = errorsx.New(http.StatusInternalServerError, "InternalError.{{.Web.R.SingularName}}PurgeFailed", "Failed to purge the {{.Web.R.SingularLower}}.")
+{{- end}}
+{{- if .Web.R.HasMany}}
+
+// Err{{.Web.R.SingularName}}InUse indicates that the {{.Web.R.SingularLower}} cannot be deleted because other resources still reference it.
+var Err{{.Web.R.SingularName}}InUse = errorsx.New(http.StatusConflict, "FailedPrecondition.{{.Web.R.SingularName}}InUse", "The {{.Web.R.SingularLower}} is still referenced by other resources.")
+{{- end}}
@@ -0,0 +1,193 @@
+// Package kitexsvc serves the services of the protobuf API with Kitex.
+//
+// The Kitex service info of a service is built at runtime from its protobuf descriptor, which is
+// generated by protoc-gen-go together with the gRPC code, instead of from code generated by the
+// kitex tool. Every unary method of the service is served by the method of the same name of the
+// handler, which has the signature of the method in the gRPC server interface:
+//
+//	func(ctx context.Context, rq *v1.CreatePostRequest) (*v1.CreatePostResponse, error)
+//
+// The requests and responses are encoded as protobuf, so Kitex clients and gRPC clients can both
+// call the service.
+package kitexsvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/cloudwego/kitex/pkg/serviceinfo"
+	"github.com/cloudwego/kitex/pkg/streaming"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+var errInvalidMessageType = errors.New("invalid message type for service method handler")
+
+// NewServiceInfo returns the Kitex service info of the protobuf service of the full name serviceName,
+// e.g., "apiserver.v1.Apiserver", whose methods are served by the methods of handler. Streaming
+// methods are not served.
+func NewServiceInfo(serviceName string, handler any) (*serviceinfo.ServiceInfo, error) {
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(serviceName))
+	if err != nil {
+		return nil, fmt.Errorf("service %s: %w", serviceName, err)
+	}
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a service", serviceName)
+	}
+
+	handlerType := reflect.TypeOf(handler)
+	methods := map[string]serviceinfo.MethodInfo{}
+	for i := 0; i < sd.Methods().Len(); i++ {
+		md := sd.Methods().Get(i)
+		if md.IsStreamingClient() || md.IsStreamingServer() {
+			continue
+		}
+
+		name := string(md.Name())
+		m, ok := handlerType.MethodByName(name)
+		if !ok {
+			return nil, fmt.Errorf("handler %s does not implement method %s of service %s", handlerType, name, sd.FullName())
+		}
+		input, err := protoregistry.GlobalTypes.FindMessageByName(md.Input().FullName())
+		if err != nil {
+			return nil, fmt.Errorf("method %s: %w", md.FullName(), err)
+		}
+		output, err := protoregistry.GlobalTypes.FindMessageByName(md.Output().FullName())
+		if err != nil {
+			return nil, fmt.Errorf("method %s: %w", md.FullName(), err)
+		}
+
+		methods[name] = newMethodInfo(m.Func, input, output)
+	}
+
+	return &serviceinfo.ServiceInfo{
+		ServiceName:  string(sd.Name()),
+		HandlerType:  handler,
+		Methods:      methods,
+		PayloadCodec: serviceinfo.Protobuf,
+		Extra: map[string]any{
+			"PackageName": string(sd.ParentFile().Package()),
+		},
+	}, nil
+}
+
+// newMethodInfo returns the Kitex method info of a unary method served by fn, the method value of
+// the handler type, which takes the handler, the context and the request.
+func newMethodInfo(fn reflect.Value, input, output protoreflect.MessageType) serviceinfo.MethodInfo {
+	call := func(ctx context.Context, handler any, rq proto.Message) (proto.Message, error) {
+		out := fn.Call([]reflect.Value{reflect.ValueOf(handler), reflect.ValueOf(ctx), reflect.ValueOf(rq)})
+		if err, _ := out[1].Interface().(error); err != nil {
+			return nil, err
+		}
+		return out[0].Interface().(proto.Message), nil
+	}
+
+	methodHandler := func(ctx context.Context, handler, arg, result any) error {
+		switch a := arg.(type) {
+		case *streaming.Args:
+			rq := input.New().Interface()
+			if err := a.Stream.RecvMsg(rq); err != nil {
+				return err
+			}
+			resp, err := call(ctx, handler, rq)
+			if err != nil {
+				return err
+			}
+			return a.Stream.SendMsg(resp)
+		case *Args:
+			resp, err := call(ctx, handler, a.Req)
+			if err != nil {
+				return err
+			}
+			result.(*Result).Success = resp
+			return nil
+		default:
+			return errInvalidMessageType
+		}
+	}
+
+	return serviceinfo.NewMethodInfo(
+		methodHandler,
+		func() any { return &Args{input: input} },
+		func() any { return &Result{output: output} },
+		false,
+		serviceinfo.WithStreamingMode(serviceinfo.StreamingUnary),
+	)
+}
+
+// Args holds the request message of a call. Middlewares get the message with GetFirstArgument.
+type Args struct {
+	input protoreflect.MessageType
+	Req   proto.Message
+}
+
+// Marshal appends the protobuf encoding of the request to out.
+func (a *Args) Marshal(out []byte) ([]byte, error) {
+	if a.Req == nil {
+		return out, nil
+	}
+	return proto.MarshalOptions{}.MarshalAppend(out, a.Req)
+}
+
+// Unmarshal decodes the request from its protobuf encoding.
+func (a *Args) Unmarshal(in []byte) error {
+	rq := a.input.New().Interface()
+	if err := proto.Unmarshal(in, rq); err != nil {
+		return err
+	}
+	a.Req = rq
+	return nil
+}
+
+// GetFirstArgument returns the request message.
+func (a *Args) GetFirstArgument() any {
+	return a.Req
+}
+
+// Result holds the response message of a call. Middlewares get the message with GetResult.
+type Result struct {
+	output  protoreflect.MessageType
+	Success proto.Message
+}
+
+// Marshal appends the protobuf encoding of the response to out.
+func (r *Result) Marshal(out []byte) ([]byte, error) {
+	if r.Success == nil {
+		return out, nil
+	}
+	return proto.MarshalOptions{}.MarshalAppend(out, r.Success)
+}
+
+// Unmarshal decodes the response from its protobuf encoding.
+func (r *Result) Unmarshal(in []byte) error {
+	resp := r.output.New().Interface()
+	if err := proto.Unmarshal(in, resp); err != nil {
+		return err
+	}
+	r.Success = resp
+	return nil
+}
+
+// GetSuccess returns the response message.
+func (r *Result) GetSuccess() any {
+	return r.Success
+}
+
+// SetSuccess sets the response message.
+func (r *Result) SetSuccess(x any) {
+	r.Success, _ = x.(proto.Message)
+}
+
+// IsSetSuccess reports whether the response message is set.
+func (r *Result) IsSetSuccess() bool {
+	return r.Success != nil
+}
+
+// GetResult returns the response message.
+func (r *Result) GetResult() any {
+	return r.Success
+}
@@ -0,0 +1,49 @@
+package connect
+
+import (
+	"context"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	"github.com/onexstack/onexstack/pkg/token"
+	"google.golang.org/grpc/metadata"
+
+	"{{.D.ModuleName}}/internal/pkg/contextx"
+	"{{.D.ModuleName}}/internal/pkg/errno"
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/model"
+)
+
+// UserRetriever is an interface for retrieving user information based on username.
+type UserRetriever interface {
+	// GetUser retrieves user information by user ID.
+	GetUser(ctx context.Context, userID string) (*model.UserM, error)
+}
+
+// AuthnInterceptor is a Connect interceptor that authenticates the caller with the token in the
+// Authorization header of the call.
+func AuthnInterceptor(retriever UserRetriever) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			// Parse the JWT Token. The token package reads it from the incoming gRPC metadata of
+			// the context, so the Authorization header of the call is passed as such.
+			md := metadata.Pairs("authorization", req.Header().Get("Authorization"))
+			userID, err := token.ParseRequest(metadata.NewIncomingContext(ctx, md))
+			if err != nil {
+				slog.Error("Failed to parse request", "error", err)
+				return nil, errno.ErrTokenInvalid.WithMessage("%s", err.Error())
+			}
+
+			slog.Info("Token parsing successful", "userID", userID)
+
+			user, err := retriever.GetUser(ctx, userID)
+			if err != nil {
+				return nil, errno.ErrUnauthenticated.WithMessage("%s", err.Error())
+			}
+
+			ctx = contextx.WithUserID(ctx, user.UserID)
+			ctx = contextx.WithUsername(ctx, user.Username)
+
+			return next(ctx, req)
+		}
+	}
+}
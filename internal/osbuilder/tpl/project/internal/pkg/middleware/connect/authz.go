@@ -0,0 +1,45 @@
+package connect
+
+import (
+	"context"
+	"log/slog"
+
+	"connectrpc.com/connect"
+
+	"{{.D.ModuleName}}/internal/pkg/contextx"
+	"{{.D.ModuleName}}/internal/pkg/errno"
+)
+
+// Authorizer defines the interface for authorization implementation.
+type Authorizer interface {
+	Authorize(subject, object, action string) (bool, error)
+}
+
+// AuthzInterceptor is a Connect interceptor for request authorization. The object is the procedure
+// of the call, e.g., "/apiserver.v1.Apiserver/GetUser", which is the full method name of the gRPC
+// servers.
+func AuthzInterceptor(authorizer Authorizer) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			subject := contextx.UserID(ctx)
+			object := req.Spec().Procedure
+			action := "CALL"
+
+			// Log authorization context information.
+			slog.Info("Build authorize context", "subject", subject, "object", object, "action", action)
+
+			// Call the authorization interface for verification.
+			if allowed, err := authorizer.Authorize(subject, object, action); err != nil || !allowed {
+				return nil, errno.ErrPermissionDenied.WithMessage(
+					"access denied: subject=%s, object=%s, action=%s, reason=%v",
+					subject,
+					object,
+					action,
+					err,
+				)
+			}
+
+			return next(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package connect
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"go.opentelemetry.io/otel/trace"
+
+	"{{.D.ModuleName}}/internal/pkg/contextx"
+)
+
+// Context is a Connect interceptor that injects needed keys into the context.
+func Context() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			spanCtx := trace.SpanFromContext(ctx).SpanContext()
+
+			// Only inject traceID if span context is valid
+			if spanCtx.IsValid() {
+				ctx = contextx.WithTraceID(ctx, spanCtx.TraceID().String())
+			}
+
+			return next(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package connect
+
+import (
+	"net/http"
+	"strings"
+
+	connectcors "connectrpc.com/cors"
+)
+
+// Cors is a net/http middleware that handles Cross-Origin Resource Sharing (CORS) requests, so that
+// browsers may call the procedures with the Connect and gRPC-Web protocols. It allows the methods
+// and headers of the protocols, and exposes their response headers, e.g., `grpc-status`.
+func Cors(next http.Handler) http.Handler {
+	allowedMethods := strings.Join(connectcors.AllowedMethods(), ", ")
+	allowedHeaders := strings.Join(append(connectcors.AllowedHeaders(), "Authorization", "X-Request-Id"), ", ")
+	exposedHeaders := strings.Join(append(connectcors.ExposedHeaders(), "X-Request-Id"), ", ")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Requests without the Origin header do not come from browsers, e.g., gRPC clients.
+		if r.Header.Get("Origin") == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Expose-Headers", exposedHeaders)
+
+		// Handle preflight requests (OPTIONS method)
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Methods", allowedMethods)
+			w.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
+			w.Header().Set("Access-Control-Max-Age", "7200")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,21 @@
+package connect
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+)
+
+// DefaulterInterceptor is a Connect interceptor that sets the default values of the request.
+func DefaulterInterceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			// Call the Default() method of the request message, if it has one.
+			if defaulter, ok := req.Any().(interface{ Default() }); ok {
+				defaulter.Default()
+			}
+
+			return next(ctx, req)
+		}
+	}
+}
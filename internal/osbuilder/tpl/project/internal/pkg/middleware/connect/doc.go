@@ -0,0 +1 @@
+package connect // import "{{.D.ModuleName}}/internal/pkg/middleware/connect"
@@ -0,0 +1,35 @@
+package connect
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/onexstack/onexstack/pkg/errorsx"
+
+	"{{.D.ModuleName}}/internal/pkg/contextx"
+	"{{.D.ModuleName}}/internal/pkg/known"
+)
+
+// RequestIDInterceptor is a Connect interceptor that sets the request ID of every call. It reads
+// the `x-request-id` from the headers of the call, or generates a new UUID if it is not present,
+// injects it into the context, and returns it in the response header and with the errors.
+func RequestIDInterceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			requestID := req.Header().Get(string(known.XRequestID))
+			if requestID == "" {
+				requestID = uuid.New().String()
+			}
+
+			ctx = contextx.WithRequestID(ctx, requestID)
+			resp, err := next(ctx, req)
+			if err != nil {
+				return nil, errorsx.FromError(err).WithRequestID(requestID)
+			}
+
+			resp.Header().Set(string(known.XRequestID), requestID)
+			return resp, nil
+		}
+	}
+}
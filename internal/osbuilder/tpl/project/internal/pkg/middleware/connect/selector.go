@@ -0,0 +1,25 @@
+package connect
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+)
+
+// MatchFunc reports whether an interceptor applies to a call of the procedure, e.g.,
+// "/apiserver.v1.Apiserver/Login".
+type MatchFunc func(ctx context.Context, procedure string) bool
+
+// Selector returns an interceptor that applies interceptor to the calls matched by match only, like
+// the selector interceptors of the gRPC servers.
+func Selector(interceptor connect.UnaryInterceptorFunc, match MatchFunc) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		selected := interceptor(next)
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			if match(ctx, req.Spec().Procedure) {
+				return selected(ctx, req)
+			}
+			return next(ctx, req)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package connect
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+	"github.com/onexstack/onexstack/pkg/errorsx"
+)
+
+// StatusInterceptor is a Connect interceptor that returns the errors of the call as Connect errors,
+// with the code of their gRPC status and the reason and metadata of their errorsx form in the
+// details, so that gRPC clients get them back with errorsx.FromError as from the gRPC servers.
+func StatusInterceptor() connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			resp, err := next(ctx, req)
+			if err == nil {
+				return resp, nil
+			}
+
+			st := errorsx.FromError(err).GRPCStatus().Proto()
+			cerr := connect.NewError(connect.Code(st.GetCode()), errors.New(st.GetMessage()))
+			for _, detail := range st.GetDetails() {
+				if d, err := connect.NewErrorDetail(detail); err == nil {
+					cerr.AddDetail(d)
+				}
+			}
+			return nil, cerr
+		}
+	}
+}
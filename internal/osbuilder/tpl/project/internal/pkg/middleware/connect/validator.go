@@ -0,0 +1,26 @@
+package connect
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+)
+
+// RequestValidator defines the interface for custom validation.
+type RequestValidator interface {
+	Validate(ctx context.Context, rq any) error
+}
+
+// ValidatorInterceptor is a Connect interceptor that validates the request.
+func ValidatorInterceptor(validator RequestValidator) connect.UnaryInterceptorFunc {
+	return func(next connect.UnaryFunc) connect.UnaryFunc {
+		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+			// The validator returns errno errors itself, so the error is returned as is.
+			if err := validator.Validate(ctx, req.Any()); err != nil {
+				return nil, err
+			}
+
+			return next(ctx, req)
+		}
+	}
+}
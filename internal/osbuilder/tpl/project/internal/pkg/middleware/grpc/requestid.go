@@ -19,14 +19,14 @@ func RequestIDInterceptor() grpc.UnaryServerInterceptor {
 		md, _ := metadata.FromIncomingContext(ctx)
 
 		// 从请求中获取请求 ID
-		if requestIDs := md[known.XRequestID]; len(requestIDs) > 0 {
+		if requestIDs := md[string(known.XRequestID)]; len(requestIDs) > 0 {
 			requestID = requestIDs[0]
 		}
 
 		// 如果没有请求 ID，则生成一个新的 UUID
 		if requestID == "" {
 			requestID = uuid.New().String()
-			md.Append(known.XRequestID, requestID)
+			md.Append(string(known.XRequestID), requestID)
 		}
 
 		// 将元数据设置为新的 incoming context
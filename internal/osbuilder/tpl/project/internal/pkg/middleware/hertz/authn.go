@@ -0,0 +1,56 @@
+package hertz
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/onexstack/onexstack/pkg/errorsx"
+	"github.com/onexstack/onexstack/pkg/token"
+	"google.golang.org/grpc/metadata"
+
+	"{{.D.ModuleName}}/internal/pkg/contextx"
+	"{{.D.ModuleName}}/internal/pkg/errno"
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/model"
+)
+
+// UserRetriever is an interface for retrieving user information based on username.
+type UserRetriever interface {
+	// GetUser retrieves user information by user ID.
+	GetUser(ctx context.Context, userID string) (*model.UserM, error)
+}
+
+// AuthnMiddleware is an authentication middleware used to extract and validate the token from
+// the Authorization header of the request.
+func AuthnMiddleware(retriever UserRetriever) app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		// Parse the JWT Token. The token package reads it from the incoming metadata of
+		// non-gin requests, so the Authorization header is passed as such.
+		md := metadata.Pairs("authorization", string(c.GetHeader("Authorization")))
+		userID, err := token.ParseRequest(metadata.NewIncomingContext(ctx, md))
+		if err != nil {
+			abortWithError(c, errno.ErrTokenInvalid.WithMessage("%s", err.Error()))
+			return
+		}
+
+		slog.Info("Token parsing successful", "userID", userID)
+
+		user, err := retriever.GetUser(ctx, userID)
+		if err != nil {
+			abortWithError(c, errno.ErrUnauthenticated.WithMessage("%s", err.Error()))
+			return
+		}
+
+		ctx = contextx.WithUserID(ctx, user.UserID)
+		ctx = contextx.WithUsername(ctx, user.Username)
+
+		c.Next(ctx)
+	}
+}
+
+// abortWithError stops the handler chain and writes err with the HTTP status code and the JSON
+// body of its errorsx form, as the handlers do.
+func abortWithError(c *app.RequestContext, err error) {
+	errx := errorsx.FromError(err)
+	c.AbortWithStatusJSON(errx.Code, errx)
+}
@@ -0,0 +1,42 @@
+package hertz
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/cloudwego/hertz/pkg/app"
+
+	"{{.D.ModuleName}}/internal/pkg/contextx"
+	"{{.D.ModuleName}}/internal/pkg/errno"
+)
+
+// Authorizer defines the interface for authorization implementation.
+type Authorizer interface {
+	Authorize(subject, object, action string) (bool, error)
+}
+
+// AuthzMiddleware is a Hertz middleware for request authorization.
+func AuthzMiddleware(authorizer Authorizer) app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		subject := contextx.UserID(ctx)
+		object := string(c.Path())
+		action := string(c.Method())
+
+		// Log authorization context information.
+		slog.Info("Build authorize context", "subject", subject, "object", object, "action", action)
+
+		// Call the authorization interface for verification.
+		if allowed, err := authorizer.Authorize(subject, object, action); err != nil || !allowed {
+			abortWithError(c, errno.ErrPermissionDenied.WithMessage(
+				"access denied: subject=%s, object=%s, action=%s, reason=%v",
+				subject,
+				object,
+				action,
+				err,
+			))
+			return
+		}
+
+		c.Next(ctx) // Continue processing the request.
+	}
+}
@@ -0,0 +1,23 @@
+package hertz
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"go.opentelemetry.io/otel/trace"
+
+	"{{.D.ModuleName}}/internal/pkg/contextx"
+)
+
+// Context is a middleware that injects common prefix fields to the context of the request.
+func Context() app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		// Extract the TraceID from the current OpenTelemetry span in the request context.
+		// If no span is present, a zero-value TraceID will be returned, which is still a valid string.
+		traceID := trace.SpanFromContext(ctx).SpanContext().TraceID().String()
+
+		// Pass a new context with the extracted TraceID to the next middleware or handler.
+		// This makes the TraceID available via contextx.TraceID(ctx).
+		c.Next(contextx.WithTraceID(ctx, traceID))
+	}
+}
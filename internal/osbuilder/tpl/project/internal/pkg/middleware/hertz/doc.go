@@ -0,0 +1 @@
+package hertz // import "{{.D.ModuleName}}/internal/pkg/middleware/hertz"
@@ -0,0 +1,37 @@
+package hertz
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/google/uuid"
+
+	"{{.D.ModuleName}}/internal/pkg/contextx"
+	"{{.D.ModuleName}}/internal/pkg/known"
+)
+
+// RequestIDMiddleware is a Hertz middleware that ensures every HTTP request
+// has a unique request ID. It extracts the `x-request-id` from the incoming
+// request headers, or generates a new UUID if one is not present.
+// This request ID is then injected into the `context.Context` passed to the
+// downstream handlers and also added to the HTTP response headers.
+func RequestIDMiddleware() app.HandlerFunc {
+	return func(ctx context.Context, c *app.RequestContext) {
+		// Attempt to retrieve the request ID from the incoming `x-request-id` header.
+		requestID := string(c.GetHeader(string(known.XRequestID)))
+
+		// If no request ID is provided in the header, generate a new UUID.
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+
+		// Set the `x-request-id` header in the HTTP response, ensuring the client
+		// receives the request ID for tracing and correlation.
+		c.Header(string(known.XRequestID), requestID)
+
+		// Store the request ID in the context.Context using `contextx`, which Hertz passes
+		// down the chain. This makes the request ID accessible to subsequent handlers and
+		// business logic.
+		c.Next(contextx.WithRequestID(ctx, requestID))
+	}
+}
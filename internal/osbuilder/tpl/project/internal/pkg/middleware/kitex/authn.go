@@ -0,0 +1,49 @@
+package kitex
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/onexstack/onexstack/pkg/token"
+	"google.golang.org/grpc/metadata"
+
+	"{{.D.ModuleName}}/internal/pkg/contextx"
+	"{{.D.ModuleName}}/internal/pkg/errno"
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/model"
+)
+
+// UserRetriever is an interface for retrieving user information based on username.
+type UserRetriever interface {
+	// GetUser retrieves user information by user ID.
+	GetUser(ctx context.Context, userID string) (*model.UserM, error)
+}
+
+// AuthnMiddleware is a Kitex middleware that authenticates the caller with the token in the
+// authorization metadata of the call.
+func AuthnMiddleware(retriever UserRetriever) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, req, resp any) error {
+			// Parse the JWT Token. The token package reads it from the incoming gRPC metadata of
+			// the context, so the authorization metadata of the call is passed as such.
+			md := metadata.Pairs("authorization", incomingValue(ctx, "authorization"))
+			userID, err := token.ParseRequest(metadata.NewIncomingContext(ctx, md))
+			if err != nil {
+				slog.Error("Failed to parse request", "error", err)
+				return errno.ErrTokenInvalid.WithMessage("%s", err.Error())
+			}
+
+			slog.Info("Token parsing successful", "userID", userID)
+
+			user, err := retriever.GetUser(ctx, userID)
+			if err != nil {
+				return errno.ErrUnauthenticated.WithMessage("%s", err.Error())
+			}
+
+			ctx = contextx.WithUserID(ctx, user.UserID)
+			ctx = contextx.WithUsername(ctx, user.Username)
+
+			return next(ctx, req, resp)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package kitex
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/cloudwego/kitex/pkg/endpoint"
+
+	"{{.D.ModuleName}}/internal/pkg/contextx"
+	"{{.D.ModuleName}}/internal/pkg/errno"
+)
+
+// Authorizer defines the interface for authorization implementation.
+type Authorizer interface {
+	Authorize(subject, object, action string) (bool, error)
+}
+
+// AuthzMiddleware is a Kitex middleware for request authorization. The object is the full name of
+// the method, e.g., "/apiserver.v1.Apiserver/GetUser", as for the gRPC servers.
+func AuthzMiddleware(authorizer Authorizer) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, req, resp any) error {
+			subject := contextx.UserID(ctx)
+			object := FullMethod(ctx)
+			action := "CALL"
+
+			// Log authorization context information.
+			slog.Info("Build authorize context", "subject", subject, "object", object, "action", action)
+
+			// Call the authorization interface for verification.
+			if allowed, err := authorizer.Authorize(subject, object, action); err != nil || !allowed {
+				return errno.ErrPermissionDenied.WithMessage(
+					"access denied: subject=%s, object=%s, action=%s, reason=%v",
+					subject,
+					object,
+					action,
+					err,
+				)
+			}
+
+			return next(ctx, req, resp)
+		}
+	}
+}
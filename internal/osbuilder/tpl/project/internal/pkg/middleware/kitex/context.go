@@ -0,0 +1,26 @@
+package kitex
+
+import (
+	"context"
+
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"go.opentelemetry.io/otel/trace"
+
+	"{{.D.ModuleName}}/internal/pkg/contextx"
+)
+
+// Context is a Kitex middleware that injects needed keys into the context.
+func Context() endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, req, resp any) error {
+			spanCtx := trace.SpanFromContext(ctx).SpanContext()
+
+			// Only inject traceID if span context is valid
+			if spanCtx.IsValid() {
+				ctx = contextx.WithTraceID(ctx, spanCtx.TraceID().String())
+			}
+
+			return next(ctx, req, resp)
+		}
+	}
+}
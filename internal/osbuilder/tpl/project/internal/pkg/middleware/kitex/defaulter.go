@@ -0,0 +1,22 @@
+package kitex
+
+import (
+	"context"
+
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/utils/kitexutil"
+)
+
+// DefaulterMiddleware is a Kitex middleware that sets the default values of the request.
+func DefaulterMiddleware() endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, req, resp any) error {
+			// Call the Default() method of the request message, if it has one.
+			if defaulter, ok := kitexutil.GetRealReqFromKitexArgs(req).(interface{ Default() }); ok {
+				defaulter.Default()
+			}
+
+			return next(ctx, req, resp)
+		}
+	}
+}
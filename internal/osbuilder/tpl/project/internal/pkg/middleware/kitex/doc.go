@@ -0,0 +1 @@
+package kitex // import "{{.D.ModuleName}}/internal/pkg/middleware/kitex"
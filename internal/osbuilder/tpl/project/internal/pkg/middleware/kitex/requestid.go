@@ -0,0 +1,50 @@
+package kitex
+
+import (
+	"context"
+
+	"github.com/bytedance/gopkg/cloud/metainfo"
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/remote/trans/nphttp2"
+	"github.com/cloudwego/kitex/pkg/remote/trans/nphttp2/metadata"
+	"github.com/google/uuid"
+	"github.com/onexstack/onexstack/pkg/errorsx"
+
+	"{{.D.ModuleName}}/internal/pkg/contextx"
+	"{{.D.ModuleName}}/internal/pkg/known"
+)
+
+// RequestIDMiddleware is a Kitex middleware that sets the request ID of every call. It reads the
+// `x-request-id` from the metadata of the call, or generates a new UUID if it is not present,
+// injects it into the context, and returns it in the response header and with the errors.
+func RequestIDMiddleware() endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, req, resp any) error {
+			requestID := incomingValue(ctx, string(known.XRequestID))
+			if requestID == "" {
+				requestID = uuid.New().String()
+			}
+
+			// Return the request ID in the header of the response to gRPC clients.
+			_ = nphttp2.SetHeader(ctx, metadata.Pairs(string(known.XRequestID), requestID))
+
+			ctx = contextx.WithRequestID(ctx, requestID)
+			if err := next(ctx, req, resp); err != nil {
+				return errorsx.FromError(err).WithRequestID(requestID)
+			}
+			return nil
+		}
+	}
+}
+
+// incomingValue returns the value of key in the metadata of the call, which gRPC clients send as
+// headers and Kitex clients as transient meta information.
+func incomingValue(ctx context.Context, key string) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+	}
+	value, _ := metainfo.GetValue(ctx, key)
+	return value
+}
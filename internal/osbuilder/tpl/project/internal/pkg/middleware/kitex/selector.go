@@ -0,0 +1,36 @@
+package kitex
+
+import (
+	"context"
+
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/rpcinfo"
+)
+
+// MatchFunc reports whether a middleware applies to a call.
+type MatchFunc func(ctx context.Context) bool
+
+// Selector returns a middleware that applies m to the calls matched by match only, like the
+// selector interceptors of the gRPC servers.
+func Selector(m endpoint.Middleware, match MatchFunc) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		selected := m(next)
+		return func(ctx context.Context, req, resp any) error {
+			if match(ctx) {
+				return selected(ctx, req, resp)
+			}
+			return next(ctx, req, resp)
+		}
+	}
+}
+
+// FullMethod returns the full name of the method of a call in the form of gRPC, e.g.,
+// "/apiserver.v1.Apiserver/Login".
+func FullMethod(ctx context.Context) string {
+	ri := rpcinfo.GetRPCInfo(ctx)
+	if ri == nil {
+		return ""
+	}
+	inv := ri.Invocation()
+	return "/" + inv.PackageName() + "." + inv.ServiceName() + "/" + inv.MethodName()
+}
@@ -0,0 +1,24 @@
+package kitex
+
+import (
+	"context"
+
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/remote/trans/nphttp2/status"
+	"github.com/onexstack/onexstack/pkg/errorsx"
+)
+
+// StatusMiddleware is a Kitex middleware that returns the errors of the call as gRPC statuses,
+// with the reason and metadata of their errorsx form in the details, so that gRPC clients get
+// them back with errorsx.FromError as from the gRPC servers.
+func StatusMiddleware() endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, req, resp any) error {
+			err := next(ctx, req, resp)
+			if err == nil {
+				return nil
+			}
+			return status.FromProto(errorsx.FromError(err).GRPCStatus().Proto()).Err()
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package kitex
+
+import (
+	"context"
+
+	"github.com/cloudwego/kitex/pkg/endpoint"
+	"github.com/cloudwego/kitex/pkg/utils/kitexutil"
+)
+
+// RequestValidator defines the interface for custom validation.
+type RequestValidator interface {
+	Validate(ctx context.Context, rq any) error
+}
+
+// ValidatorMiddleware is a Kitex middleware that validates the request.
+func ValidatorMiddleware(validator RequestValidator) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, req, resp any) error {
+			// The validator returns errno errors itself, so the error is returned as is.
+			if err := validator.Validate(ctx, kitexutil.GetRealReqFromKitexArgs(req)); err != nil {
+				return err
+			}
+
+			return next(ctx, req, resp)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package nethttp
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/onexstack/onexstack/pkg/token"
+	"google.golang.org/grpc/metadata"
+
+	"{{.D.ModuleName}}/internal/pkg/contextx"
+	"{{.D.ModuleName}}/internal/pkg/errno"
+	"{{.D.ModuleName}}/internal/{{.Web.Name}}/model"
+)
+
+// UserRetriever is an interface for retrieving user information based on username.
+type UserRetriever interface {
+	// GetUser retrieves user information by user ID.
+	GetUser(ctx context.Context, userID string) (*model.UserM, error)
+}
+
+// AuthnMiddleware is an authentication middleware used to extract and validate the token from
+// the Authorization header of the request.
+func AuthnMiddleware(retriever UserRetriever) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Parse the JWT Token. The token package reads it from the incoming metadata of
+			// non-gin requests, so the Authorization header is passed as such.
+			md := metadata.Pairs("authorization", r.Header.Get("Authorization"))
+			userID, err := token.ParseRequest(metadata.NewIncomingContext(r.Context(), md))
+			if err != nil {
+				writeError(w, errno.ErrTokenInvalid.WithMessage("%s", err.Error()))
+				return
+			}
+
+			slog.Info("Token parsing successful", "userID", userID)
+
+			user, err := retriever.GetUser(r.Context(), userID)
+			if err != nil {
+				writeError(w, errno.ErrUnauthenticated.WithMessage("%s", err.Error()))
+				return
+			}
+
+			ctx := contextx.WithUserID(r.Context(), user.UserID)
+			ctx = contextx.WithUsername(ctx, user.Username)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
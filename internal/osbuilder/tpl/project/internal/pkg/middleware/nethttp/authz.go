@@ -0,0 +1,42 @@
+package nethttp
+
+import (
+	"log/slog"
+	"net/http"
+
+	"{{.D.ModuleName}}/internal/pkg/contextx"
+	"{{.D.ModuleName}}/internal/pkg/errno"
+)
+
+// Authorizer defines the interface for authorization implementation.
+type Authorizer interface {
+	Authorize(subject, object, action string) (bool, error)
+}
+
+// AuthzMiddleware is a net/http middleware for request authorization.
+func AuthzMiddleware(authorizer Authorizer) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			subject := contextx.UserID(r.Context())
+			object := r.URL.Path
+			action := r.Method
+
+			// Log authorization context information.
+			slog.Info("Build authorize context", "subject", subject, "object", object, "action", action)
+
+			// Call the authorization interface for verification.
+			if allowed, err := authorizer.Authorize(subject, object, action); err != nil || !allowed {
+				writeError(w, errno.ErrPermissionDenied.WithMessage(
+					"access denied: subject=%s, object=%s, action=%s, reason=%v",
+					subject,
+					object,
+					action,
+					err,
+				))
+				return
+			}
+
+			next.ServeHTTP(w, r) // Continue processing the request.
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package nethttp
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"{{.D.ModuleName}}/internal/pkg/contextx"
+)
+
+// Context is a middleware that injects common prefix fields to the context of the request.
+func Context() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Extract the TraceID from the current OpenTelemetry span in the request context.
+			// If no span is present, a zero-value TraceID will be returned, which is still a valid string.
+			traceID := trace.SpanFromContext(r.Context()).SpanContext().TraceID().String()
+
+			// Pass a new context with the extracted TraceID to the next middleware or handler.
+			// This makes the TraceID available via contextx.TraceID(ctx).
+			next.ServeHTTP(w, r.WithContext(contextx.WithTraceID(r.Context(), traceID)))
+		})
+	}
+}
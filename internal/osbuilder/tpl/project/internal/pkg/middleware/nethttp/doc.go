@@ -0,0 +1 @@
+package nethttp // import "{{.D.ModuleName}}/internal/pkg/middleware/nethttp"
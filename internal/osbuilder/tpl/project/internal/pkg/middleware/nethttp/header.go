@@ -0,0 +1,104 @@
+package nethttp
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// NoCache is a net/http middleware that prevents clients from caching HTTP responses.
+// It sets standard Cache-Control, Expires, and Last-Modified headers to
+// ensure the client always fetches the fresh content.
+func NoCache(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-cache, no-store, max-age=0, must-revalidate")
+		w.Header().Set("Expires", "Thu, 01 Jan 1970 00:00:00 GMT")
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// CorsConfig holds configuration options for the CORS middleware.
+type CorsConfig struct {
+	// AllowedOrigins specifies a list of origins that may access the resource.
+	// If set to ["*"], all origins are allowed.
+	AllowedOrigins []string
+	// AllowedMethods specifies the methods allowed when accessing the resource.
+	AllowedMethods []string
+	// AllowedHeaders specifies the headers that can be used when making the actual request.
+	AllowedHeaders []string
+	// AllowCredentials indicates whether or not the response to the request
+	// can be exposed when the credentials flag is true.
+	AllowCredentials bool
+	// MaxAge indicates how long the results of a preflight request can be cached.
+	MaxAge time.Duration
+}
+
+// DefaultCorsConfig provides a sensible default configuration for CORS.
+func DefaultCorsConfig() CorsConfig {
+	return CorsConfig{
+		AllowedOrigins:   []string{"*"}, // Allow all origins by default
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Origin", "Content-Type", "Accept", "Authorization"},
+		AllowCredentials: true,
+		MaxAge:           12 * time.Hour,
+	}
+}
+
+// Cors is a net/http middleware that handles Cross-Origin Resource Sharing (CORS) requests.
+// It configures the necessary HTTP headers based on the provided CorsConfig.
+func Cors(config CorsConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			var allowedOrigin string
+
+			// Check if the origin is allowed
+			if len(config.AllowedOrigins) == 0 || (len(config.AllowedOrigins) == 1 && config.AllowedOrigins[0] == "*") {
+				allowedOrigin = "*"
+			} else {
+				for _, o := range config.AllowedOrigins {
+					if o == origin {
+						allowedOrigin = origin
+						break
+					}
+				}
+			}
+
+			// Set response headers for all requests
+			w.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
+			w.Header().Set("Access-Control-Allow-Methods", strings.Join(config.AllowedMethods, ", "))
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(config.AllowedHeaders, ", "))
+			w.Header().Set("Access-Control-Max-Age", fmt.Sprintf("%.0f", config.MaxAge.Seconds()))
+
+			if config.AllowCredentials {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			// Handle preflight requests (OPTIONS method)
+			if r.Method == http.MethodOptions {
+				w.WriteHeader(http.StatusNoContent) // Use 204 No Content for preflight success
+				return
+			}
+
+			next.ServeHTTP(w, r) // Continue processing the actual request
+		})
+	}
+}
+
+// Secure is a net/http middleware that adds security-related HTTP headers to responses.
+// These headers help mitigate common web vulnerabilities.
+func Secure(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Frame-Options", "DENY")           // Prevents clickjacking by forbidding embedding in iframes
+		w.Header().Set("X-Content-Type-Options", "nosniff") // Prevents browsers from MIME-sniffing a response away from the declared Content-Type
+		w.Header().Set("X-XSS-Protection", "1; mode=block") // Enables the XSS filter in browsers
+
+		// Only apply HSTS over TLS connections
+		if r.TLS != nil {
+			w.Header().Set("Strict-Transport-Security", "max-age=31536000; includeSubDomains; preload")
+		}
+		next.ServeHTTP(w, r)
+	})
+}
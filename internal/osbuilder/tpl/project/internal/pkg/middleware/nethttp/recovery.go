@@ -0,0 +1,39 @@
+package nethttp
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/onexstack/onexstack/pkg/errorsx"
+
+	"{{.D.ModuleName}}/internal/pkg/errno"
+)
+
+// Recovery is a net/http middleware that recovers from the panics of the downstream handlers, logs
+// them with their stack trace and replies with an internal server error.
+func Recovery(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if err := recover(); err != nil {
+				// http.ErrAbortHandler aborts the response on purpose and is handled by net/http.
+				if err == http.ErrAbortHandler {
+					panic(err)
+				}
+				slog.ErrorContext(r.Context(), "panic recovered", "error", err, "stack", string(debug.Stack()))
+				writeError(w, errno.ErrInternal)
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// writeError writes err with the HTTP status code and the JSON body of its errorsx form, as the
+// handlers do. The middlewares return without calling the next handler after writing an error.
+func writeError(w http.ResponseWriter, err error) {
+	errx := errorsx.FromError(err)
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(errx.Code)
+	_ = json.NewEncoder(w).Encode(errx)
+}
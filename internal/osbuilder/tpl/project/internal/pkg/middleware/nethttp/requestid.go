@@ -0,0 +1,37 @@
+package nethttp
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"{{.D.ModuleName}}/internal/pkg/contextx"
+	"{{.D.ModuleName}}/internal/pkg/known"
+)
+
+// RequestIDMiddleware is a net/http middleware that ensures every HTTP request
+// has a unique request ID. It extracts the `x-request-id` from the incoming
+// request headers, or generates a new UUID if one is not present.
+// This request ID is then injected into the context of the request passed to the
+// downstream handlers and also added to the HTTP response headers.
+func RequestIDMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Attempt to retrieve the request ID from the incoming `x-request-id` header.
+			requestID := r.Header.Get(string(known.XRequestID))
+
+			// If no request ID is provided in the header, generate a new UUID.
+			if requestID == "" {
+				requestID = uuid.New().String()
+			}
+
+			// Set the `x-request-id` header in the HTTP response, ensuring the client
+			// receives the request ID for tracing and correlation.
+			w.Header().Set(string(known.XRequestID), requestID)
+
+			// Store the request ID in the context of the request using `contextx`. This makes
+			// the request ID accessible to subsequent handlers and business logic.
+			next.ServeHTTP(w, r.WithContext(contextx.WithRequestID(r.Context(), requestID)))
+		})
+	}
+}
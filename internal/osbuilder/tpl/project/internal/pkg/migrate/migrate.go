@@ -0,0 +1,334 @@
+// Package migrate applies and reverts versioned SQL migrations.
+//
+// A migration is a pair of files named <version>_<name>.up.sql and <version>_<name>.down.sql,
+// e.g., 000001_create_mb_post.up.sql. Statements are separated by a semicolon at the end of a
+// line. The applied migrations are recorded in the schema_migrations table, and concurrent
+// migrators are serialized by an advisory lock on MySQL and PostgreSQL.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dialects lists the SQL dialects migrations are written for, by their GORM dialector names.
+var Dialects = []string{"mysql", "postgres", "sqlite"}
+
+// historyTable records the applied migrations.
+const historyTable = "schema_migrations"
+
+// fileName matches the name of a migration file.
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a versioned schema change.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// String returns the file name prefix of the migration, e.g., "000001_create_mb_post".
+func (m *Migration) String() string {
+	return fmt.Sprintf("%06d_%s", m.Version, m.Name)
+}
+
+// Status is a migration together with the time it was applied, if it was.
+type Status struct {
+	*Migration
+	// AppliedAt is empty for pending migrations.
+	AppliedAt string
+}
+
+// Migrator applies the migrations of a dialect to a database.
+type Migrator struct {
+	db         *sql.DB
+	dialect    string
+	migrations []*Migration
+}
+
+// New returns a migrator of the migrations found in the root of fsys, written for dialect.
+func New(db *sql.DB, dialect string, fsys fs.FS) (*Migrator, error) {
+	switch dialect {
+	case "mysql", "postgres", "sqlite":
+	default:
+		return nil, fmt.Errorf("unsupported dialect %q", dialect)
+	}
+
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int64]*Migration{}
+	for _, e := range entries {
+		match := fileName.FindStringSubmatch(e.Name())
+		if e.IsDir() || match == nil {
+			continue
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migrations %s and %s have the same version", m, e.Name())
+		}
+
+		b, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+		if match[3] == "up" {
+			m.Up = string(b)
+		} else {
+			m.Down = string(b)
+		}
+	}
+
+	mg := &Migrator{db: db, dialect: dialect}
+	for _, m := range byVersion {
+		mg.migrations = append(mg.migrations, m)
+	}
+	sort.Slice(mg.migrations, func(i, j int) bool { return mg.migrations[i].Version < mg.migrations[j].Version })
+	return mg, nil
+}
+
+// Up applies the first n pending migrations, or all of them if n is not positive, and returns
+// the applied migrations.
+func (mg *Migrator) Up(ctx context.Context, n int) ([]*Migration, error) {
+	var done []*Migration
+	err := mg.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := mg.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, m := range mg.migrations {
+			if n > 0 && len(done) == n {
+				break
+			}
+			if _, ok := applied[m.Version]; ok {
+				continue
+			}
+			insert := fmt.Sprintf("INSERT INTO %s (version, name, applied_at) VALUES (%s, %s, %s)",
+				historyTable, mg.bind(1), mg.bind(2), mg.bind(3))
+			if err := mg.exec(ctx, conn, m, m.Up, insert, m.Version, m.Name, time.Now().UTC().Format(time.RFC3339)); err != nil {
+				return err
+			}
+			done = append(done, m)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down reverts the last n applied migrations, or the last one if n is not positive, and returns
+// the reverted migrations.
+func (mg *Migrator) Down(ctx context.Context, n int) ([]*Migration, error) {
+	if n <= 0 {
+		n = 1
+	}
+
+	var done []*Migration
+	err := mg.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := mg.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(mg.migrations) - 1; i >= 0 && len(done) < n; i-- {
+			m := mg.migrations[i]
+			if _, ok := applied[m.Version]; !ok {
+				continue
+			}
+			remove := fmt.Sprintf("DELETE FROM %s WHERE version = %s", historyTable, mg.bind(1))
+			if err := mg.exec(ctx, conn, m, m.Down, remove, m.Version); err != nil {
+				return err
+			}
+			done = append(done, m)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status returns every migration together with the time it was applied.
+func (mg *Migrator) Status(ctx context.Context) ([]*Status, error) {
+	conn, err := mg.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	applied, err := mg.applied(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]*Status, 0, len(mg.migrations))
+	for _, m := range mg.migrations {
+		statuses = append(statuses, &Status{Migration: m, AppliedAt: applied[m.Version]})
+	}
+	return statuses, nil
+}
+
+// exec runs the statements of a migration and the statement that records it in one transaction.
+// MySQL commits DDL statements implicitly, so a failed migration may be partially applied there.
+func (mg *Migrator) exec(ctx context.Context, conn *sql.Conn, m *Migration, script string, record string, args ...any) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, stmt := range statements(script) {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("migration %s: %w", m, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, record, args...); err != nil {
+		return fmt.Errorf("migration %s: %w", m, err)
+	}
+	return tx.Commit()
+}
+
+// applied creates the history table if needed and returns the applied versions with the time
+// they were applied.
+func (mg *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int64]string, error) {
+	create := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (version BIGINT NOT NULL PRIMARY KEY, "+
+		"name VARCHAR(255) NOT NULL, applied_at VARCHAR(32) NOT NULL)", historyTable)
+	if _, err := conn.ExecContext(ctx, create); err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.QueryContext(ctx, "SELECT version, applied_at FROM "+historyTable)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int64]string{}
+	for rows.Next() {
+		var version int64
+		var appliedAt string
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// locked runs fn on a dedicated connection while holding the advisory lock of the history table,
+// so that migrators started together, e.g., by several replicas of a server, apply each migration
+// once. SQLite has no advisory lock, but only lets one transaction write at a time, and a
+// migration applied concurrently fails on the primary key of its history record.
+func (mg *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn) error) (err error) {
+	conn, err := mg.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(historyTable))
+	key := int64(h.Sum64() >> 1)
+
+	switch mg.dialect {
+	case "mysql":
+		var ok sql.NullInt64
+		if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 60)", historyTable).Scan(&ok); err != nil {
+			return err
+		}
+		if ok.Int64 != 1 {
+			return errors.New("timed out waiting for the migration lock")
+		}
+		defer func() { _, _ = conn.ExecContext(context.WithoutCancel(ctx), "SELECT RELEASE_LOCK(?)", historyTable) }()
+	case "postgres":
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", key); err != nil {
+			return err
+		}
+		defer func() { _, _ = conn.ExecContext(context.WithoutCancel(ctx), "SELECT pg_advisory_unlock($1)", key) }()
+	}
+	return fn(conn)
+}
+
+// bind returns the placeholder of the i-th argument of a statement.
+func (mg *Migrator) bind(i int) string {
+	if mg.dialect == "postgres" {
+		return "$" + strconv.Itoa(i)
+	}
+	return "?"
+}
+
+// statements splits a script into statements at the semicolons that end a line, and drops the
+// comments and blank lines between them.
+func statements(script string) []string {
+	var stmts []string
+	var b strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if b.Len() == 0 && (trimmed == "" || strings.HasPrefix(trimmed, "--")) {
+			continue
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			stmts = append(stmts, strings.TrimSpace(b.String()))
+			b.Reset()
+		}
+	}
+	if stmt := strings.TrimSpace(b.String()); stmt != "" {
+		stmts = append(stmts, stmt)
+	}
+	return stmts
+}
+
+// Create writes the empty up and down files of a new migration for every dialect into the
+// dialect directories of dir, numbered after the latest migration, and returns their paths.
+func Create(dir string, name string) ([]string, error) {
+	if !regexp.MustCompile(`^\w+$`).MatchString(name) {
+		return nil, fmt.Errorf("invalid migration name %q: use letters, digits and underscores", name)
+	}
+
+	var latest int64
+	for _, dialect := range Dialects {
+		entries, err := os.ReadDir(filepath.Join(dir, dialect))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		for _, e := range entries {
+			if match := fileName.FindStringSubmatch(e.Name()); match != nil {
+				if v, _ := strconv.ParseInt(match[1], 10, 64); v > latest {
+					latest = v
+				}
+			}
+		}
+	}
+
+	m := &Migration{Version: latest + 1, Name: name}
+	var paths []string
+	for _, dialect := range Dialects {
+		if err := os.MkdirAll(filepath.Join(dir, dialect), 0o755); err != nil {
+			return nil, err
+		}
+		for _, direction := range []string{"up", "down"} {
+			path := filepath.Join(dir, dialect, m.String()+"."+direction+".sql")
+			content := fmt.Sprintf("-- %s migration %s for %s.\n", direction, m, dialect)
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				return nil, err
+			}
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
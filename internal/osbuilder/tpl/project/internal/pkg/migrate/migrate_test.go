@@ -0,0 +1,130 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	_ "github.com/glebarez/go-sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMigrations = fstest.MapFS{
+	"000001_create_post.up.sql": {Data: []byte(`CREATE TABLE "post" (
+  "id" INTEGER PRIMARY KEY AUTOINCREMENT,
+  "title" TEXT
+);
+CREATE INDEX "idx_post_title" ON "post" ("title");
+`)},
+	"000001_create_post.down.sql": {Data: []byte(`DROP TABLE "post";` + "\n")},
+	"000002_alter_post.up.sql":    {Data: []byte(`ALTER TABLE "post" ADD COLUMN "views" INTEGER;` + "\n")},
+	"000002_alter_post.down.sql": {Data: []byte(`-- Drop the views column.
+ALTER TABLE "post" DROP COLUMN "views";
+`)},
+	".keep": {},
+}
+
+func newTestMigrator(t *testing.T) (*Migrator, *sql.DB) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = db.Close() })
+
+	mg, err := New(db, "sqlite", testMigrations)
+	require.NoError(t, err)
+	return mg, db
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	mg, db := newTestMigrator(t)
+
+	done, err := mg.Up(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, done, 1)
+	assert.Equal(t, "000001_create_post", done[0].String())
+
+	done, err = mg.Up(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, done, 1)
+	assert.Equal(t, "000002_alter_post", done[0].String())
+	_, err = db.Exec(`INSERT INTO "post" ("title", "views") VALUES ('hello', 1)`)
+	require.NoError(t, err)
+
+	// Applied migrations are skipped.
+	done, err = mg.Up(ctx, 0)
+	require.NoError(t, err)
+	assert.Empty(t, done)
+
+	statuses, err := mg.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.NotEmpty(t, statuses[0].AppliedAt)
+	assert.NotEmpty(t, statuses[1].AppliedAt)
+
+	done, err = mg.Down(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, done, 1)
+	assert.Equal(t, "000002_alter_post", done[0].String())
+	_, err = db.Exec(`INSERT INTO "post" ("title", "views") VALUES ('hello', 1)`)
+	assert.Error(t, err)
+
+	statuses, err = mg.Status(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, statuses[1].AppliedAt)
+
+	done, err = mg.Down(ctx, 5)
+	require.NoError(t, err)
+	assert.Len(t, done, 1)
+	_, err = db.Exec(`SELECT 1 FROM "post"`)
+	assert.Error(t, err)
+}
+
+func TestMigratorFailedMigration(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mg, err := New(db, "sqlite", fstest.MapFS{
+		"000001_broken.up.sql":   {Data: []byte("CREATE TABLE \"a\" (\"id\" INTEGER);\nCREATE TABLE \"a\" (\"id\" INTEGER);\n")},
+		"000001_broken.down.sql": {Data: []byte("DROP TABLE \"a\";\n")},
+	})
+	require.NoError(t, err)
+
+	_, err = mg.Up(ctx, 0)
+	require.ErrorContains(t, err, "migration 000001_broken")
+
+	// The statements of the failed migration are rolled back and it is still pending.
+	_, err = db.Exec(`SELECT 1 FROM "a"`)
+	assert.Error(t, err)
+	statuses, err := mg.Status(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, statuses[0].AppliedAt)
+}
+
+func TestStatements(t *testing.T) {
+	assert.Equal(t, []string{
+		"CREATE TABLE \"a\" (\n  \"id\" INTEGER\n);",
+		"INSERT INTO \"a\" VALUES (1);",
+	}, statements("-- Create a.\nCREATE TABLE \"a\" (\n  \"id\" INTEGER\n);\n\nINSERT INTO \"a\" VALUES (1);\n"))
+	assert.Empty(t, statements("-- Nothing to do.\n"))
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sqlite"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sqlite", "000003_create_post.up.sql"), nil, 0o644))
+
+	paths, err := Create(dir, "add_index")
+	require.NoError(t, err)
+	require.Len(t, paths, 6)
+	assert.Equal(t, filepath.Join(dir, "mysql", "000004_add_index.up.sql"), paths[0])
+	assert.Equal(t, filepath.Join(dir, "sqlite", "000004_add_index.down.sql"), paths[5])
+
+	_, err = Create(dir, "add index")
+	assert.Error(t, err)
+}
@@ -0,0 +1,244 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// errStopped is returned when a message is not handled to the end because the consumer stopped.
+var errStopped = errors.New("consumer stopped")
+
+// delivery is a message received from a broker, acknowledged once it is handled.
+type delivery struct {
+	msg *Message
+	// ack acknowledges the message, so that the broker does not deliver it again.
+	ack func(ctx context.Context) error
+	// progress, if set, tells the broker that the message is still being handled, so that it does
+	// not deliver it again while it is retried.
+	progress func()
+}
+
+// dispatcher handles the messages received by the consumers: it retries the messages whose
+// handling failed, and dead-letters or skips those still failing after the last attempt. For the
+// brokers that do not assign partitions to their consumers (NATS, RabbitMQ, Redis Streams and
+// memory), the messages of a topic are also sharded across a pool of workers by key, so that the
+// messages of a key are handled in order, and a message is acknowledged once it is handled,
+// dead-lettered or skipped. The Kafka consumer shards the messages of each partition itself.
+type dispatcher struct {
+	system   string   // Name of the broker in the traces (e.g., "nats")
+	producer Producer // Publishes the dead-lettered messages
+	workers  int
+	inflight chan struct{} // Bounds the number of messages handled at once
+	tracer   trace.Tracer
+
+	quit     chan struct{} // Closed to stop the workers
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// newDispatcher returns a dispatcher configured by opts, which dead-letters messages with
+// producer.
+func newDispatcher(system string, producer Producer, opts *Options) *dispatcher {
+	return &dispatcher{
+		system:   system,
+		producer: producer,
+		workers:  opts.Workers,
+		inflight: make(chan struct{}, opts.MaxInFlight),
+		tracer:   otel.Tracer("mq-consumer"),
+		quit:     make(chan struct{}),
+	}
+}
+
+// subscription dispatches the messages of a topic to its workers, if it has any.
+type subscription struct {
+	d      *dispatcher
+	topic  string
+	fn     HandlerFunc
+	opts   *HandlerOptions
+	shards []chan *delivery
+}
+
+// subscribe starts the workers of a topic, which handle its messages with fn until the dispatcher
+// stops. The handlers are called with ctx.
+func (d *dispatcher) subscribe(ctx context.Context, topic string, fn HandlerFunc, opts *HandlerOptions) *subscription {
+	workers := d.workers
+	if opts.InOrder {
+		workers = 1
+	}
+
+	s := &subscription{d: d, topic: topic, fn: fn, opts: opts, shards: make([]chan *delivery, workers)}
+	for i := range s.shards {
+		s.shards[i] = make(chan *delivery)
+		d.wg.Add(1)
+		go s.work(ctx, s.shards[i])
+	}
+	return s
+}
+
+// dispatch waits until a worker takes a delivery. It returns false if the dispatcher stopped
+// first, in which case the message is delivered again by the broker.
+func (s *subscription) dispatch(dl *delivery) bool {
+	shard := 0
+	if len(s.shards) > 1 {
+		hash := fnv.New32a()
+		hash.Write([]byte(dl.msg.Key))
+		shard = int(hash.Sum32() % uint32(len(s.shards)))
+	}
+
+	select {
+	case s.shards[shard] <- dl:
+		return true
+	case <-s.d.quit:
+		return false
+	}
+}
+
+// work handles the deliveries of a shard one at a time.
+func (s *subscription) work(ctx context.Context, deliveries <-chan *delivery) {
+	defer s.d.wg.Done()
+	for {
+		select {
+		case <-s.d.quit:
+			return
+		case dl := <-deliveries:
+			if err := s.d.process(ctx, s, dl, s.d.quit); err != nil {
+				continue // Stopped: the message is delivered again
+			}
+			if err := dl.ack(ctx); err != nil {
+				slog.ErrorContext(ctx, "failed to acknowledge message", "system", s.d.system, "topic", s.topic,
+					"id", dl.msg.ID, "error", err)
+			}
+		}
+	}
+}
+
+// stop stops the workers and waits for the messages being handled.
+func (d *dispatcher) stop() {
+	d.stopOnce.Do(func() { close(d.quit) })
+	d.wg.Wait()
+}
+
+// stopped returns a channel that is closed when the dispatcher stops.
+func (d *dispatcher) stopped() <-chan struct{} {
+	return d.quit
+}
+
+// process handles a message until it succeeds or runs out of attempts, then dead-letters or skips
+// the message if it still fails. It returns errStopped if quit is closed first, in which case the
+// message must not be acknowledged.
+func (d *dispatcher) process(ctx context.Context, s *subscription, dl *delivery, quit <-chan struct{}) error {
+	msg := dl.msg
+	parentCtx := otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(msg.Headers))
+	spanCtx, span := d.tracer.Start(parentCtx, fmt.Sprintf("%s.receive.%s", d.system, msg.Topic),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", d.system),
+			attribute.String("messaging.operation", "receive"),
+			attribute.String("messaging.destination_name", msg.Topic),
+			attribute.String("messaging.message_id", msg.ID),
+		),
+	)
+	defer span.End()
+
+	attempts, err := d.attempt(spanCtx, s, dl, quit)
+	if err == nil || err == errStopped {
+		return err
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	if s.opts.DeadLetterTopic == "" {
+		slog.ErrorContext(spanCtx, "message handler failed, skipping message", "system", d.system, "topic", msg.Topic,
+			"id", msg.ID, "attempts", attempts, "error", err)
+		return nil
+	}
+	return d.deadLetter(spanCtx, s, msg, attempts, err, quit)
+}
+
+// attempt calls the handler of a message until it succeeds or runs out of attempts, and returns
+// the number of attempts with the error of the last one.
+func (d *dispatcher) attempt(ctx context.Context, s *subscription, dl *delivery, quit <-chan struct{}) (int, error) {
+	for attempt := 1; ; attempt++ {
+		select {
+		case d.inflight <- struct{}{}:
+		case <-quit:
+			return attempt - 1, errStopped
+		}
+		if dl.progress != nil && attempt > 1 {
+			dl.progress()
+		}
+		err := s.fn(ctx, dl.msg)
+		<-d.inflight
+		if err == nil || attempt >= s.opts.Retry.MaxAttempts {
+			return attempt, err
+		}
+
+		backoff := s.opts.Retry.backoff(attempt)
+		slog.WarnContext(ctx, "message handler failed, retrying", "system", d.system, "topic", dl.msg.Topic,
+			"id", dl.msg.ID, "attempt", attempt, "backoff", backoff, "error", err)
+		if !wait(backoff, quit) {
+			return attempt, errStopped
+		}
+	}
+}
+
+// deadLetter publishes a message that could not be handled to the dead-letter topic of its
+// subscription. Publishing is retried until it succeeds, so that the message is never skipped, or
+// until quit is closed.
+func (d *dispatcher) deadLetter(ctx context.Context, s *subscription, msg *Message, attempts int, cause error, quit <-chan struct{}) error {
+	dlq := deadLetterMessage(s.opts.DeadLetterTopic, msg, attempts, cause, time.Now())
+	for attempt := 1; ; attempt++ {
+		err := d.producer.Publish(ctx, dlq)[0]
+		if err == nil {
+			slog.WarnContext(ctx, "message dead-lettered", "system", d.system, "topic", msg.Topic, "id", msg.ID,
+				"dead_letter_topic", s.opts.DeadLetterTopic, "attempts", attempts, "error", cause)
+			return nil
+		}
+
+		backoff := RetryPolicy{Backoff: 100 * time.Millisecond, MaxBackoff: 10 * time.Second}.backoff(attempt)
+		slog.ErrorContext(ctx, "failed to publish message to dead-letter topic, retrying", "system", d.system,
+			"topic", msg.Topic, "id", msg.ID, "dead_letter_topic", s.opts.DeadLetterTopic, "backoff", backoff, "error", err)
+		if !wait(backoff, quit) {
+			return errStopped
+		}
+	}
+}
+
+// wait waits for duration. It returns false if quit is closed first.
+func wait(duration time.Duration, quit <-chan struct{}) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-quit:
+		return false
+	}
+}
+
+// deadLetterMessage returns the message published to topic for the message msg that failed with
+// cause after attempts attempts. It keeps the key, the value and the headers of msg.
+func deadLetterMessage(topic string, msg *Message, attempts int, cause error, failedAt time.Time) *Message {
+	headers := make(map[string]string, len(msg.Headers)+5)
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	headers[HeaderDeadLetterTopic] = msg.Topic
+	headers[HeaderDeadLetterID] = msg.ID
+	headers[HeaderDeadLetterError] = cause.Error()
+	headers[HeaderDeadLetterAttempts] = strconv.Itoa(attempts)
+	headers[HeaderDeadLetterFailedAt] = failedAt.UTC().Format(time.RFC3339)
+	return &Message{Topic: topic, Key: msg.Key, Value: msg.Value, Headers: headers}
+}
@@ -0,0 +1,282 @@
+package mq
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// KafkaProducer publishes messages to Kafka. The messages of a key are produced to the same
+// partition in order: the producer is idempotent, so that the requests it retries are neither
+// duplicated nor reordered, and it fails the records buffered behind a record of a partition that
+// failed.
+type KafkaProducer struct {
+	client *kgo.Client
+}
+
+// KafkaConsumer consumes messages from Kafka as a member of a consumer group. Each partition
+// assigned to the consumer is handled by its own pool of workers: the messages of a partition are
+// sharded across the workers by key, and the offset of a message is committed only once the
+// message and all the messages before it in its partition are handled, dead-lettered or skipped,
+// so that the messages that were not handled are consumed again after a restart or a rebalance.
+type KafkaConsumer struct {
+	client   *kgo.Client
+	producer *KafkaProducer // Publishes the dead-lettered messages
+	d        *dispatcher
+	// workers is the number of workers of each partition.
+	workers int
+
+	mu   sync.RWMutex // Protects subs
+	subs map[string]*subscription
+
+	consumersMu sync.Mutex // Protects ctx and consumers
+	// ctx is the context of the handlers, which is not canceled when the consumer stops so that
+	// the messages being handled are handled to the end.
+	ctx       context.Context
+	consumers map[topicPartition]*partitionConsumer
+
+	cancel   context.CancelFunc // Stops the polling loop
+	stopped  chan struct{}      // Closed when the polling loop returns
+	stopOnce sync.Once
+}
+
+// topicPartition identifies a partition of a topic.
+type topicPartition struct {
+	topic     string
+	partition int32
+}
+
+// Ensure KafkaProducer and KafkaConsumer implement Producer and Consumer at compile time.
+var (
+	_ Producer = (*KafkaProducer)(nil)
+	_ Consumer = (*KafkaConsumer)(nil)
+)
+
+// NewKafkaProducer creates a producer connected to the Kafka cluster of opts.
+func NewKafkaProducer(opts *KafkaOptions) (*KafkaProducer, error) {
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(opts.Brokers...),
+		kgo.RequiredAcks(kgo.AllISRAcks()),
+		kgo.RecordDeliveryTimeout(opts.Timeout),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka client: %w", err)
+	}
+	return &KafkaProducer{client: client}, nil
+}
+
+// Publish produces msgs and waits until every record is acknowledged or failed.
+func (p *KafkaProducer) Publish(ctx context.Context, msgs ...*Message) []error {
+	records := make([]*kgo.Record, len(msgs))
+	index := make(map[*kgo.Record]int, len(msgs))
+	for i, msg := range msgs {
+		record := &kgo.Record{Topic: msg.Topic, Key: []byte(msg.Key), Value: msg.Value}
+		for k, v := range msg.Headers {
+			record.Headers = append(record.Headers, kgo.RecordHeader{Key: k, Value: []byte(v)})
+		}
+		records[i] = record
+		index[record] = i
+	}
+
+	// The results are in the order the records were acknowledged, not in the order of msgs.
+	errs := make([]error, len(msgs))
+	for _, result := range p.client.ProduceSync(ctx, records...) {
+		errs[index[result.Record]] = result.Err
+	}
+	return errs
+}
+
+// Close flushes the buffered records and closes the connections to the brokers.
+func (p *KafkaProducer) Close() error {
+	p.client.Close()
+	return nil
+}
+
+// NewKafkaConsumer creates a consumer of the consumer group of opts, connected to its Kafka
+// cluster.
+func NewKafkaConsumer(opts *Options) (*KafkaConsumer, error) {
+	producer, err := NewKafkaProducer(opts.Kafka)
+	if err != nil {
+		return nil, err
+	}
+	c := &KafkaConsumer{
+		producer:  producer,
+		d:         newDispatcher("kafka", producer, opts),
+		workers:   opts.Workers,
+		subs:      map[string]*subscription{},
+		consumers: map[topicPartition]*partitionConsumer{},
+		stopped:   make(chan struct{}),
+	}
+
+	// The offsets are committed by the consumers of the partitions, which are stopped when their
+	// partition is revoked or lost so that they never commit the offsets of another member.
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(opts.Kafka.Brokers...),
+		kgo.ConsumerGroup(opts.Group),
+		kgo.DisableAutoCommit(),
+		kgo.OnPartitionsAssigned(c.assigned),
+		kgo.OnPartitionsRevoked(c.revoked),
+		kgo.OnPartitionsLost(c.lost),
+	)
+	if err != nil {
+		_ = producer.Close()
+		return nil, fmt.Errorf("failed to create Kafka client: %w", err)
+	}
+	c.client = client
+	return c, nil
+}
+
+// Register registers the handler of a topic and subscribes the consumer to the topic. It can be
+// called while the consumer is running.
+func (c *KafkaConsumer) Register(topic string, fn HandlerFunc, opts ...HandlerOption) {
+	c.mu.Lock()
+	c.subs[topic] = &subscription{d: c.d, topic: topic, fn: fn, opts: NewHandlerOptions(opts...)}
+	c.mu.Unlock()
+
+	// AddConsumeTopics is thread-safe and can be called while the client is running.
+	c.client.AddConsumeTopics(topic)
+}
+
+// subscription returns the subscription of a topic, or nil if none is registered.
+func (c *KafkaConsumer) subscription(topic string) *subscription {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.subs[topic]
+}
+
+// Start polls records and dispatches them to the consumers of their partitions until ctx is
+// canceled or Stop is called.
+func (c *KafkaConsumer) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	c.consumersMu.Lock()
+	c.cancel = cancel
+	c.ctx = context.WithoutCancel(ctx)
+	c.consumersMu.Unlock()
+	defer close(c.stopped)
+
+	for {
+		fetches := c.client.PollFetches(ctx)
+		if fetches.IsClientClosed() || ctx.Err() != nil {
+			return nil
+		}
+
+		fetches.EachError(func(topic string, partition int32, err error) {
+			slog.ErrorContext(ctx, "failed to fetch Kafka records", "topic", topic, "partition", partition, "error", err)
+		})
+		if fetches.Err0() != nil && fetches.NumRecords() == 0 {
+			time.Sleep(100 * time.Millisecond) // Simple backoff for transient errors
+			continue
+		}
+
+		// A consumer blocks the loop until it can take the records, so that the records of a
+		// partition are handled in the order they were fetched and slow partitions stop fetching.
+		fetches.EachPartition(func(p kgo.FetchTopicPartition) {
+			if len(p.Records) == 0 {
+				return
+			}
+			c.consumersMu.Lock()
+			pc := c.consumers[topicPartition{p.Topic, p.Partition}]
+			c.consumersMu.Unlock()
+			if pc == nil {
+				// The partition was revoked since the poll: its next owner consumes the records.
+				return
+			}
+			pc.enqueue(ctx, p.Records)
+		})
+	}
+}
+
+// Stop stops polling, waits for the messages being handled, commits their offsets and closes the
+// clients. The records polled but not handled yet are consumed again by the next owner of their
+// partition.
+func (c *KafkaConsumer) Stop() {
+	c.stopOnce.Do(func() {
+		c.consumersMu.Lock()
+		cancel := c.cancel
+		c.consumersMu.Unlock()
+		if cancel != nil {
+			cancel()
+			<-c.stopped
+		}
+
+		c.stopConsumers(nil)
+		c.d.stop()
+		c.client.Close() // Leaves the consumer group
+		_ = c.producer.Close()
+	})
+}
+
+// assigned starts the consumers of the partitions assigned to the consumer.
+func (c *KafkaConsumer) assigned(_ context.Context, _ *kgo.Client, assigned map[string][]int32) {
+	c.consumersMu.Lock()
+	defer c.consumersMu.Unlock()
+
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	for topic, partitions := range assigned {
+		for _, partition := range partitions {
+			tp := topicPartition{topic, partition}
+			pc := newPartitionConsumer(c, tp)
+			c.consumers[tp] = pc
+			go pc.run(ctx)
+		}
+	}
+	slog.Info("Kafka partitions assigned", "partitions", assigned)
+}
+
+// revoked stops the consumers of the partitions revoked from the consumer. They commit the
+// offsets of the messages they handled before the partitions are given to another member.
+func (c *KafkaConsumer) revoked(_ context.Context, _ *kgo.Client, revoked map[string][]int32) {
+	c.stopConsumers(revoked)
+	slog.Info("Kafka partitions revoked", "partitions", revoked)
+}
+
+// lost stops the consumers of the partitions lost by the consumer, whose offsets can no longer
+// be committed.
+func (c *KafkaConsumer) lost(_ context.Context, _ *kgo.Client, lost map[string][]int32) {
+	c.stopConsumers(lost)
+	slog.Warn("Kafka partitions lost", "partitions", lost)
+}
+
+// stopConsumers stops the consumers of partitions, or all the consumers if partitions is nil,
+// and waits for them to return.
+func (c *KafkaConsumer) stopConsumers(partitions map[string][]int32) {
+	c.consumersMu.Lock()
+	var stopping []*partitionConsumer
+	for tp, pc := range c.consumers {
+		if partitions != nil && !slices.Contains(partitions[tp.topic], tp.partition) {
+			continue
+		}
+		delete(c.consumers, tp)
+		close(pc.quit)
+		stopping = append(stopping, pc)
+	}
+	c.consumersMu.Unlock()
+
+	for _, pc := range stopping {
+		<-pc.done
+	}
+}
+
+// kafkaDelivery returns the delivery of a record, whose offset is committed by the consumer of
+// its partition rather than acknowledged.
+func kafkaDelivery(record *kgo.Record) *delivery {
+	msg := &Message{
+		Topic:   record.Topic,
+		Key:     string(record.Key),
+		Value:   record.Value,
+		Headers: make(map[string]string, len(record.Headers)),
+		ID:      fmt.Sprintf("%d/%d", record.Partition, record.Offset),
+	}
+	for _, h := range record.Headers {
+		msg.Headers[h.Key] = string(h.Value)
+	}
+	return &delivery{msg: msg}
+}
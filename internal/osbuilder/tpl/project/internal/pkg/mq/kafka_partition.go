@@ -0,0 +1,131 @@
+package mq
+
+import (
+	"context"
+	"hash/fnv"
+	"log/slog"
+	"sync"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// partitionConsumer handles the records of a partition assigned to a KafkaConsumer with a pool of
+// workers, and commits their offsets.
+type partitionConsumer struct {
+	c       *KafkaConsumer
+	tp      topicPartition
+	batches chan []*kgo.Record
+	quit    chan struct{} // Closed to stop the consumer
+	done    chan struct{} // Closed when the consumer returns
+}
+
+// newPartitionConsumer returns the consumer of the partition tp.
+func newPartitionConsumer(c *KafkaConsumer, tp topicPartition) *partitionConsumer {
+	return &partitionConsumer{
+		c:       c,
+		tp:      tp,
+		batches: make(chan []*kgo.Record, 1),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+// enqueue waits until the consumer takes records, the consumer stops or ctx is canceled.
+func (pc *partitionConsumer) enqueue(ctx context.Context, records []*kgo.Record) {
+	select {
+	case pc.batches <- records:
+	case <-pc.quit:
+	case <-ctx.Done():
+	}
+}
+
+// run handles the batches of records of the partition until the consumer is stopped.
+func (pc *partitionConsumer) run(ctx context.Context) {
+	defer close(pc.done)
+
+	for {
+		select {
+		case <-pc.quit:
+			return
+		case records := <-pc.batches:
+			handled := pc.handle(ctx, records)
+			if handled > 0 {
+				pc.commit(ctx, records[handled-1])
+			}
+			if handled < len(records) {
+				return // Stopped: the remaining records are consumed again by the next owner
+			}
+		}
+	}
+}
+
+// handle handles a batch of records and returns the number of leading records of the batch that
+// were handled, which is the whole batch unless the consumer was stopped.
+func (pc *partitionConsumer) handle(ctx context.Context, records []*kgo.Record) int {
+	s := pc.c.subscription(pc.tp.topic)
+	if s == nil {
+		return len(records)
+	}
+
+	workers := pc.c.workers
+	if s.opts.InOrder {
+		workers = 1
+	}
+
+	// The records of a key go to the same worker, which handles them in order. The records
+	// without a key are spread across the workers.
+	shards := make([][]int, workers)
+	for i, r := range records {
+		shard := i % workers
+		if len(r.Key) > 0 {
+			hash := fnv.New32a()
+			hash.Write(r.Key)
+			shard = int(hash.Sum32() % uint32(workers))
+		}
+		shards[shard] = append(shards[shard], i)
+	}
+
+	handled := make([]bool, len(records))
+	var wg sync.WaitGroup
+	for _, shard := range shards {
+		if len(shard) == 0 {
+			continue
+		}
+		wg.Add(1)
+		go func(shard []int) {
+			defer wg.Done()
+			for _, i := range shard {
+				if pc.stopping() || pc.c.d.process(ctx, s, kafkaDelivery(records[i]), pc.quit) != nil {
+					return
+				}
+				handled[i] = true
+			}
+		}(shard)
+	}
+	wg.Wait()
+
+	n := 0
+	for n < len(records) && handled[n] {
+		n++
+	}
+	return n
+}
+
+// commit commits the offset following record, so that the partition is consumed from the record
+// after it.
+func (pc *partitionConsumer) commit(ctx context.Context, record *kgo.Record) {
+	if err := pc.c.client.CommitRecords(ctx, record); err != nil {
+		slog.ErrorContext(ctx, "failed to commit Kafka offset", "topic", record.Topic, "partition", record.Partition,
+			"offset", record.Offset, "error", err)
+	}
+}
+
+// stopping reports whether the consumer is being stopped.
+func (pc *partitionConsumer) stopping() bool {
+	select {
+	case <-pc.quit:
+		return true
+	default:
+		return false
+	}
+}
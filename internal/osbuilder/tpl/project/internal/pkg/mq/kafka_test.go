@@ -0,0 +1,106 @@
+package mq
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kfake"
+)
+
+// The retries and the dead-letter topic are tested with the MemoryBroker, which handles the
+// messages like the KafkaConsumer. These tests cover the partitions and the offsets.
+
+// newKafkaCluster starts a fake Kafka cluster with the topics and returns its options.
+func newKafkaCluster(t *testing.T, partitions int32, topics ...string) *Options {
+	t.Helper()
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.SeedTopics(partitions, topics...))
+	require.NoError(t, err)
+	t.Cleanup(cluster.Close)
+
+	opts := NewOptions()
+	opts.MaxInFlight = 2
+	opts.Kafka.Brokers = cluster.ListenAddrs()
+	return opts
+}
+
+// produce publishes a message of value-key for each key to topic.
+func produce(t *testing.T, opts *Options, topic string, value string, keys ...string) {
+	t.Helper()
+	p, err := NewKafkaProducer(opts.Kafka)
+	require.NoError(t, err)
+	defer p.Close()
+
+	var msgs []*Message
+	for _, key := range keys {
+		msgs = append(msgs, &Message{Topic: topic, Key: key, Value: []byte(value + "-" + key)})
+	}
+	for _, err := range p.Publish(context.Background(), msgs...) {
+		require.NoError(t, err)
+	}
+}
+
+// startKafkaConsumer starts a consumer configured by register, and stops it at the end of the
+// test.
+func startKafkaConsumer(t *testing.T, opts *Options, register func(c *KafkaConsumer)) *KafkaConsumer {
+	t.Helper()
+	c, err := NewKafkaConsumer(opts)
+	require.NoError(t, err)
+	register(c)
+	go c.Start(context.Background())
+	t.Cleanup(c.Stop)
+	return c
+}
+
+func TestKafkaConsumer_CommitsHandledOffsets(t *testing.T) {
+	opts := newKafkaCluster(t, 1, "posts")
+	var want []string
+	for i := 0; i < 50; i++ {
+		value := fmt.Sprintf("event-%02d", i)
+		key := fmt.Sprintf("post-%d", i%7)
+		produce(t, opts, "posts", value, key)
+		want = append(want, value+"-"+key)
+	}
+
+	var rec recorder
+	c := startKafkaConsumer(t, opts, func(c *KafkaConsumer) {
+		c.Register("posts", rec.handle, WithInOrder())
+	})
+	require.Eventually(t, func() bool { return len(rec.handled()) == len(want) }, 20*time.Second, 10*time.Millisecond)
+	assert.Equal(t, want, rec.handled())
+	c.Stop()
+
+	// The offsets of the handled messages were committed: the next member of the group only
+	// handles the new messages.
+	produce(t, opts, "posts", "event-50", "post-1")
+	var next recorder
+	startKafkaConsumer(t, opts, func(c *KafkaConsumer) {
+		c.Register("posts", next.handle, WithInOrder())
+	})
+	require.Eventually(t, func() bool { return len(next.handled()) == 1 }, 20*time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, []string{"event-50-post-1"}, next.handled())
+}
+
+func TestKafkaConsumer_KeepsTheOrderOfKeys(t *testing.T) {
+	opts := newKafkaCluster(t, 2, "posts")
+	for i := 0; i < 10; i++ {
+		produce(t, opts, "posts", fmt.Sprintf("%02d", i), "a", "b", "c", "d", "e", "f")
+	}
+
+	var rec recorder
+	startKafkaConsumer(t, opts, func(c *KafkaConsumer) {
+		c.Register("posts", rec.handle)
+	})
+	require.Eventually(t, func() bool { return len(rec.handled()) == 60 }, 20*time.Second, 10*time.Millisecond)
+
+	last := map[string]string{}
+	for _, value := range rec.handled() {
+		key := value[len("00-"):]
+		assert.Less(t, last[key], value, "messages of %s handled out of order", key)
+		last[key] = value
+	}
+}
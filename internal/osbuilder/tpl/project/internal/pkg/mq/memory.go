@@ -0,0 +1,153 @@
+package mq
+
+import (
+	"context"
+	"strconv"
+	"sync"
+)
+
+// MemoryBroker is an in-memory broker that keeps the published messages by topic. It is both a
+// Producer and a Consumer, used to test the code that publishes and handles messages without a
+// broker. Its consumer consumes the messages published after it registered a topic.
+type MemoryBroker struct {
+	mu       sync.Mutex
+	messages map[string][]*Message
+	notify   chan struct{} // Closed and replaced when a message is published
+	fail     func(msg *Message) error
+	topics   map[string]*memoryTopic
+
+	d *dispatcher
+}
+
+// memoryTopic is a topic registered to the consumer of a MemoryBroker.
+type memoryTopic struct {
+	fn     HandlerFunc
+	opts   *HandlerOptions
+	offset int // Index of the next message to consume
+}
+
+// Ensure MemoryBroker implements Producer and Consumer at compile time.
+var (
+	_ Producer = (*MemoryBroker)(nil)
+	_ Consumer = (*MemoryBroker)(nil)
+)
+
+// NewMemoryBroker returns an empty in-memory broker. Its consumer uses the workers and in-flight
+// bound of opts, which defaults to NewOptions() if nil.
+func NewMemoryBroker(opts *Options) *MemoryBroker {
+	if opts == nil {
+		opts = NewOptions()
+	}
+	b := &MemoryBroker{
+		messages: map[string][]*Message{},
+		notify:   make(chan struct{}),
+		topics:   map[string]*memoryTopic{},
+	}
+	b.d = newDispatcher("memory", b, opts)
+	return b
+}
+
+// Publish keeps msgs in order, except those that the function set by FailWith fails and the
+// later messages of their keys.
+func (b *MemoryBroker) Publish(ctx context.Context, msgs ...*Message) []error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	errs := publishInOrder(ctx, msgs, func(_ context.Context, msg *Message) error {
+		if b.fail != nil {
+			if err := b.fail(msg); err != nil {
+				return err
+			}
+		}
+		log := b.messages[msg.Topic]
+		stored := *msg
+		stored.ID = strconv.Itoa(len(log))
+		b.messages[msg.Topic] = append(log, &stored)
+		return nil
+	})
+	close(b.notify)
+	b.notify = make(chan struct{})
+	return errs
+}
+
+// FailWith makes the broker fail the messages for which fail returns an error. A nil fail
+// publishes every message.
+func (b *MemoryBroker) FailWith(fail func(msg *Message) error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.fail = fail
+}
+
+// Messages returns the messages published to topic, in the order they were published. Their ID
+// is their index in the topic.
+func (b *MemoryBroker) Messages(topic string) []*Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]*Message(nil), b.messages[topic]...)
+}
+
+// Register registers the handler of a topic, whose messages are consumed from the next one.
+func (b *MemoryBroker) Register(topic string, fn HandlerFunc, opts ...HandlerOption) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.topics[topic] = &memoryTopic{fn: fn, opts: NewHandlerOptions(opts...), offset: len(b.messages[topic])}
+}
+
+// Start dispatches the messages published to the registered topics until ctx is canceled or Stop
+// is called.
+func (b *MemoryBroker) Start(ctx context.Context) error {
+	b.mu.Lock()
+	var wg sync.WaitGroup
+	for topic, t := range b.topics {
+		s := b.d.subscribe(context.WithoutCancel(ctx), topic, t.fn, t.opts)
+		wg.Add(1)
+		go func(topic string, t *memoryTopic) {
+			defer wg.Done()
+			b.consume(ctx, topic, t, s)
+		}(topic, t)
+	}
+	b.mu.Unlock()
+
+	wg.Wait()
+	return nil
+}
+
+// consume dispatches the messages of a topic from the offset of t.
+func (b *MemoryBroker) consume(ctx context.Context, topic string, t *memoryTopic, s *subscription) {
+	for {
+		b.mu.Lock()
+		var next *Message
+		if t.offset < len(b.messages[topic]) {
+			next = b.messages[topic][t.offset]
+			t.offset++
+		}
+		notify := b.notify
+		b.mu.Unlock()
+
+		if next != nil {
+			if !s.dispatch(&delivery{msg: next, ack: func(context.Context) error { return nil }}) {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-notify:
+		case <-ctx.Done():
+			return
+		case <-b.d.stopped():
+			return
+		}
+	}
+}
+
+// Stop stops dispatching messages and waits for the messages being handled. The messages are
+// kept after the broker is stopped.
+func (b *MemoryBroker) Stop() {
+	b.d.stop()
+}
+
+// Close does nothing: the messages are kept after the broker is closed.
+func (b *MemoryBroker) Close() error {
+	return nil
+}
@@ -0,0 +1,194 @@
+// Package mq defines a producer and a consumer of messages that do not depend on the broker they
+// are connected to, and implements them for the {{.Web.Broker}} broker of the server and in memory
+// for the unit tests.
+//
+// Messages are delivered at least once. The messages of a key are published in order, and handled
+// in order by the consumers: a consumer handles the messages of different keys concurrently, and
+// the messages of a topic registered WithInOrder one at a time.
+package mq
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Headers added to the messages published to a dead-letter topic, next to the headers of the
+// original message.
+const (
+	// HeaderDeadLetterTopic is the topic of the original message.
+	HeaderDeadLetterTopic = "dlq-original-topic"
+	// HeaderDeadLetterID is the ID of the original message in the broker (e.g., "<partition>/<offset>"
+	// for Kafka).
+	HeaderDeadLetterID = "dlq-original-id"
+	// HeaderDeadLetterError is the error returned by the last attempt to handle the message.
+	HeaderDeadLetterError = "dlq-error"
+	// HeaderDeadLetterAttempts is the number of attempts to handle the message.
+	HeaderDeadLetterAttempts = "dlq-attempts"
+	// HeaderDeadLetterFailedAt is the time the message was dead-lettered, in RFC 3339.
+	HeaderDeadLetterFailedAt = "dlq-failed-at"
+)
+
+// HeaderKey is the header carrying the key of the messages on the brokers whose messages have no
+// key (NATS and RabbitMQ).
+const HeaderKey = "mq-key"
+
+// Message is a message published to or consumed from a topic.
+type Message struct {
+	// Topic is the topic of the message: a Kafka topic, a NATS subject, a RabbitMQ routing key or
+	// a Redis stream.
+	Topic string
+	// Key is the key of the message. The messages of a key are handled in order.
+	Key string
+	// Value is the payload of the message.
+	Value []byte
+	// Headers are the headers of the message, such as the trace context of its producer.
+	Headers map[string]string
+	// ID identifies the message. When publishing, it is an optional unique ID that the brokers
+	// supporting it (NATS and RabbitMQ) use to deduplicate the message. When consuming, it is the
+	// ID of the message in the broker (e.g., "<partition>/<offset>" for Kafka).
+	ID string
+}
+
+// Producer publishes messages to the broker.
+type Producer interface {
+	// Publish publishes msgs in order for each key, and returns the error of each message:
+	// errs[i] is nil if msgs[i] was acknowledged by the broker. Once a message fails, the later
+	// messages of its key fail as well rather than be published out of order.
+	Publish(ctx context.Context, msgs ...*Message) (errs []error)
+	// Close releases the connections to the broker.
+	Close() error
+}
+
+// Consumer consumes the messages of the topics registered to it as a member of the consumer group
+// of its options: the messages of a topic are shared between the members of a group.
+type Consumer interface {
+	// Register registers the handler of a topic. It must be called before Start.
+	Register(topic string, fn HandlerFunc, opts ...HandlerOption)
+	// Start consumes the messages of the registered topics until ctx is canceled or Stop is
+	// called.
+	Start(ctx context.Context) error
+	// Stop stops consuming, waits for the messages being handled and closes the connections to
+	// the broker. The messages received but not handled are delivered again.
+	Stop()
+}
+
+// HandlerFunc defines the function signature for processing messages of a specific topic.
+type HandlerFunc func(ctx context.Context, msg *Message) error
+
+// RetryPolicy defines how many times a message is handled before it is dead-lettered or skipped,
+// and how long to wait between the attempts. The wait doubles after each attempt, up to
+// MaxBackoff.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts to handle a message, the first one included.
+	MaxAttempts int
+	// Backoff is the wait before the first retry.
+	Backoff time.Duration
+	// MaxBackoff bounds the wait between two attempts. Zero means no bound.
+	MaxBackoff time.Duration
+}
+
+// backoff returns the wait before the attempt following attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < attempt && (p.MaxBackoff == 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return d
+}
+
+// HandlerOptions configures how the messages of a topic are handled.
+type HandlerOptions struct {
+	// Retry is the retry policy of the messages whose handling failed.
+	Retry RetryPolicy
+	// DeadLetterTopic is the topic of the messages still failing after the last attempt. Empty
+	// means that they are logged and skipped.
+	DeadLetterTopic string
+	// InOrder handles the messages of the topic one at a time.
+	InOrder bool
+}
+
+// HandlerOption sets a handler option.
+type HandlerOption func(*HandlerOptions)
+
+// NewHandlerOptions returns the handler options set by opts. By default a message is handled
+// once, and skipped if it fails.
+func NewHandlerOptions(opts ...HandlerOption) *HandlerOptions {
+	o := &HandlerOptions{Retry: RetryPolicy{MaxAttempts: 1}}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithRetry retries the messages whose handling failed according to policy.
+func WithRetry(policy RetryPolicy) HandlerOption {
+	return func(o *HandlerOptions) {
+		if policy.MaxAttempts > 0 {
+			o.Retry = policy
+		}
+	}
+}
+
+// WithDeadLetterTopic publishes the messages that are still failing after the last attempt to
+// topic, with headers describing the failure (see HeaderDeadLetterError).
+func WithDeadLetterTopic(topic string) HandlerOption {
+	return func(o *HandlerOptions) {
+		o.DeadLetterTopic = topic
+	}
+}
+
+// WithInOrder handles the messages of the topic one at a time, in the order they are delivered
+// (for Kafka, the messages of each partition in the order of their offsets). By default only the
+// messages of the same key are handled in order.
+func WithInOrder() HandlerOption {
+	return func(o *HandlerOptions) {
+		o.InOrder = true
+	}
+}
+
+// NewProducer creates the producer of the broker of the server.
+func NewProducer(opts *Options) (Producer, error) {
+	{{- if eq .Web.Broker "kafka"}}
+	return NewKafkaProducer(opts.Kafka)
+	{{- else if eq .Web.Broker "nats"}}
+	return NewNATSProducer(opts.NATS)
+	{{- else if eq .Web.Broker "rabbitmq"}}
+	return NewRabbitMQProducer(opts.RabbitMQ)
+	{{- else if eq .Web.Broker "redis"}}
+	return NewRedisProducer(opts.Redis)
+	{{- end}}
+}
+
+// NewConsumer creates a consumer of the broker of the server.
+func NewConsumer(opts *Options) (Consumer, error) {
+	{{- if eq .Web.Broker "kafka"}}
+	return NewKafkaConsumer(opts)
+	{{- else if eq .Web.Broker "nats"}}
+	return NewNATSConsumer(opts)
+	{{- else if eq .Web.Broker "rabbitmq"}}
+	return NewRabbitMQConsumer(opts)
+	{{- else if eq .Web.Broker "redis"}}
+	return NewRedisConsumer(opts)
+	{{- end}}
+}
+
+// publishInOrder publishes msgs one at a time with publish. Once a message fails, the later
+// messages of its key are not published.
+func publishInOrder(ctx context.Context, msgs []*Message, publish func(context.Context, *Message) error) []error {
+	errs := make([]error, len(msgs))
+	failed := map[string]error{}
+	for i, msg := range msgs {
+		if err, ok := failed[msg.Key]; ok {
+			errs[i] = fmt.Errorf("an earlier message of key %q failed: %w", msg.Key, err)
+			continue
+		}
+		if errs[i] = publish(ctx, msg); errs[i] != nil {
+			failed[msg.Key] = errs[i]
+		}
+	}
+	return errs
+}
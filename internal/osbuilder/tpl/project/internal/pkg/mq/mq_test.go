@@ -0,0 +1,194 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startBroker starts the consumer of an in-memory broker configured by register, and stops it at
+// the end of the test.
+func startBroker(t *testing.T, register func(b *MemoryBroker)) *MemoryBroker {
+	t.Helper()
+	opts := NewOptions()
+	opts.MaxInFlight = 2
+	b := NewMemoryBroker(opts)
+	register(b)
+	go b.Start(context.Background())
+	t.Cleanup(b.Stop)
+	return b
+}
+
+// publish publishes a message of value for each key to topic.
+func publish(t *testing.T, b *MemoryBroker, topic string, value string, keys ...string) {
+	t.Helper()
+	var msgs []*Message
+	for _, key := range keys {
+		msgs = append(msgs, &Message{Topic: topic, Key: key, Value: []byte(value + "-" + key)})
+	}
+	for _, err := range b.Publish(context.Background(), msgs...) {
+		require.NoError(t, err)
+	}
+}
+
+// recorder records the messages handled by a handler.
+type recorder struct {
+	mu     sync.Mutex
+	values []string
+}
+
+func (r *recorder) handle(_ context.Context, msg *Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.values = append(r.values, string(msg.Value))
+	return nil
+}
+
+func (r *recorder) handled() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.values...)
+}
+
+func TestMemoryBroker_InOrder(t *testing.T) {
+	var rec recorder
+	var running atomic.Int32
+	b := startBroker(t, func(b *MemoryBroker) {
+		b.Register("posts", func(ctx context.Context, msg *Message) error {
+			if running.Add(1) > 1 {
+				t.Error("messages handled concurrently")
+			}
+			defer running.Add(-1)
+			return rec.handle(ctx, msg)
+		}, WithInOrder())
+	})
+
+	var want []string
+	for i := 0; i < 50; i++ {
+		value := fmt.Sprintf("event-%02d", i)
+		publish(t, b, "posts", value, fmt.Sprintf("post-%d", i%7))
+		want = append(want, value+fmt.Sprintf("-post-%d", i%7))
+	}
+	require.Eventually(t, func() bool { return len(rec.handled()) == len(want) }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, want, rec.handled())
+}
+
+func TestMemoryBroker_KeepsTheOrderOfKeys(t *testing.T) {
+	var rec recorder
+	b := startBroker(t, func(b *MemoryBroker) {
+		b.Register("posts", rec.handle)
+	})
+
+	for i := 0; i < 10; i++ {
+		publish(t, b, "posts", fmt.Sprintf("%02d", i), "a", "b", "c", "d", "e", "f")
+	}
+	require.Eventually(t, func() bool { return len(rec.handled()) == 60 }, 5*time.Second, 10*time.Millisecond)
+
+	last := map[string]string{}
+	for _, value := range rec.handled() {
+		key := value[len("00-"):]
+		assert.Less(t, last[key], value, "messages of %s handled out of order", key)
+		last[key] = value
+	}
+}
+
+func TestMemoryBroker_RetryAndDeadLetter(t *testing.T) {
+	var mu sync.Mutex
+	attempts := map[string]int{}
+	handled := map[string]bool{}
+	b := startBroker(t, func(b *MemoryBroker) {
+		b.Register("posts", func(_ context.Context, msg *Message) error {
+			mu.Lock()
+			defer mu.Unlock()
+			attempts[msg.Key]++
+			if msg.Key == "poison" || (msg.Key == "flaky" && attempts[msg.Key] < 3) {
+				return errors.New("boom")
+			}
+			handled[msg.Key] = true
+			return nil
+		}, WithRetry(RetryPolicy{MaxAttempts: 3, Backoff: 10 * time.Millisecond}), WithDeadLetterTopic("posts.dlq"))
+	})
+
+	traceparent := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+	publish(t, b, "posts", "v", "flaky")
+	for _, err := range b.Publish(context.Background(), &Message{
+		Topic:   "posts",
+		Key:     "poison",
+		Value:   []byte("poison"),
+		Headers: map[string]string{"traceparent": traceparent},
+	}) {
+		require.NoError(t, err)
+	}
+	publish(t, b, "posts", "v", "healthy")
+
+	require.Eventually(t, func() bool { return len(b.Messages("posts.dlq")) == 1 }, 5*time.Second, 10*time.Millisecond)
+	dlq := b.Messages("posts.dlq")[0]
+	assert.Equal(t, "poison", dlq.Key)
+	assert.Equal(t, "poison", string(dlq.Value))
+	assert.Equal(t, "posts", dlq.Headers[HeaderDeadLetterTopic])
+	assert.Equal(t, "1", dlq.Headers[HeaderDeadLetterID])
+	assert.Equal(t, "boom", dlq.Headers[HeaderDeadLetterError])
+	assert.Equal(t, "3", dlq.Headers[HeaderDeadLetterAttempts])
+	assert.NotEmpty(t, dlq.Headers[HeaderDeadLetterFailedAt])
+	assert.Equal(t, traceparent, dlq.Headers["traceparent"])
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return handled["flaky"] && handled["healthy"]
+	}, 5*time.Second, 10*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, map[string]int{"flaky": 3, "poison": 3, "healthy": 1}, attempts)
+}
+
+func TestMemoryBroker_Publish(t *testing.T) {
+	b := NewMemoryBroker(nil)
+	b.FailWith(func(msg *Message) error {
+		if string(msg.Value) == "2-a" {
+			return errors.New("unavailable")
+		}
+		return nil
+	})
+
+	var msgs []*Message
+	for i := 1; i <= 3; i++ {
+		for _, key := range []string{"a", "b"} {
+			msgs = append(msgs, &Message{Topic: "posts", Key: key, Value: []byte(fmt.Sprintf("%d-%s", i, key))})
+		}
+	}
+	errs := b.Publish(context.Background(), msgs...)
+
+	// Once a message fails, the later messages of its key fail rather than be published out of
+	// order. The messages of the other keys are published.
+	require.Len(t, errs, 6)
+	for i, err := range errs {
+		switch string(msgs[i].Value) {
+		case "2-a", "3-a":
+			assert.Error(t, err, string(msgs[i].Value))
+		default:
+			assert.NoError(t, err, string(msgs[i].Value))
+		}
+	}
+	var values []string
+	for _, msg := range b.Messages("posts") {
+		values = append(values, string(msg.Value))
+	}
+	assert.Equal(t, []string{"1-a", "1-b", "2-b", "3-b"}, values)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 5, Backoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	var backoffs []time.Duration
+	for attempt := 1; attempt <= 5; attempt++ {
+		backoffs = append(backoffs, p.backoff(attempt))
+	}
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second}, backoffs)
+}
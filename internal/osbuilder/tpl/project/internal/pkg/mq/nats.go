@@ -0,0 +1,203 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// NATSProducer publishes messages to NATS JetStream. A message is published to the subject named
+// after its topic, which is stored by the stream of the options.
+type NATSProducer struct {
+	conn *nats.Conn
+	js   jetstream.JetStream
+	opts *NATSOptions
+}
+
+// NATSConsumer consumes messages from NATS JetStream. Each topic is read by a durable consumer
+// of the stream, shared by the members of the consumer group.
+type NATSConsumer struct {
+	producer *NATSProducer
+	opts     *Options
+	d        *dispatcher
+	topics   map[string]*HandlerOptions
+	handlers map[string]HandlerFunc
+}
+
+// Ensure NATSProducer and NATSConsumer implement Producer and Consumer at compile time.
+var (
+	_ Producer = (*NATSProducer)(nil)
+	_ Consumer = (*NATSConsumer)(nil)
+)
+
+// NewNATSProducer creates a producer connected to the NATS server of opts. It creates the stream
+// of the options if it does not exist.
+func NewNATSProducer(opts *NATSOptions) (*NATSProducer, error) {
+	conn, err := nats.Connect(opts.URL, nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to create JetStream context: %w", err)
+	}
+
+	p := &NATSProducer{conn: conn, js: js, opts: opts}
+	if _, err := p.stream(context.Background()); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+// stream returns the stream of the options, and creates it if it does not exist. An existing
+// stream is not updated, so that its configuration can be managed outside of the server.
+func (p *NATSProducer) stream(ctx context.Context) (jetstream.Stream, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.opts.Timeout)
+	defer cancel()
+
+	stream, err := p.js.Stream(ctx, p.opts.Stream)
+	if errors.Is(err, jetstream.ErrStreamNotFound) {
+		stream, err = p.js.CreateStream(ctx, jetstream.StreamConfig{Name: p.opts.Stream, Subjects: p.opts.Subjects})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NATS stream %q: %w", p.opts.Stream, err)
+	}
+	return stream, nil
+}
+
+// Publish publishes msgs one at a time, waiting for the acknowledgment of the stream. The ID of a
+// message is used as its Nats-Msg-Id, so that the stream drops the duplicates of a message
+// published again within its duplicate window.
+func (p *NATSProducer) Publish(ctx context.Context, msgs ...*Message) []error {
+	return publishInOrder(ctx, msgs, func(ctx context.Context, msg *Message) error {
+		ctx, cancel := context.WithTimeout(ctx, p.opts.Timeout)
+		defer cancel()
+
+		m := nats.NewMsg(msg.Topic)
+		m.Data = msg.Value
+		for k, v := range msg.Headers {
+			m.Header.Set(k, v)
+		}
+		if msg.Key != "" {
+			m.Header.Set(HeaderKey, msg.Key)
+		}
+		if msg.ID != "" {
+			m.Header.Set(nats.MsgIdHdr, msg.ID)
+		}
+		_, err := p.js.PublishMsg(ctx, m)
+		return err
+	})
+}
+
+// Close drains the connection to the server.
+func (p *NATSProducer) Close() error {
+	return p.conn.Drain()
+}
+
+// NewNATSConsumer creates a consumer of the consumer group of opts, connected to its NATS server.
+func NewNATSConsumer(opts *Options) (*NATSConsumer, error) {
+	producer, err := NewNATSProducer(opts.NATS)
+	if err != nil {
+		return nil, err
+	}
+	return &NATSConsumer{
+		producer: producer,
+		opts:     opts,
+		d:        newDispatcher("nats", producer, opts),
+		topics:   map[string]*HandlerOptions{},
+		handlers: map[string]HandlerFunc{},
+	}, nil
+}
+
+// Register registers the handler of a topic.
+func (c *NATSConsumer) Register(topic string, fn HandlerFunc, opts ...HandlerOption) {
+	c.topics[topic] = NewHandlerOptions(opts...)
+	c.handlers[topic] = fn
+}
+
+// Start creates the durable consumers of the registered topics and dispatches their messages
+// until ctx is canceled or Stop is called.
+func (c *NATSConsumer) Start(ctx context.Context) error {
+	stream, err := c.producer.stream(ctx)
+	if err != nil {
+		return err
+	}
+
+	var consumes []jetstream.ConsumeContext
+	defer func() {
+		for _, cc := range consumes {
+			cc.Stop()
+		}
+	}()
+	for topic, opts := range c.topics {
+		// Strict order requires a single unacknowledged message, since the messages that are not
+		// acknowledged in time are delivered again after the later ones.
+		maxAckPending := c.opts.MaxInFlight
+		if opts.InOrder {
+			maxAckPending = 1
+		}
+		consumer, err := stream.CreateOrUpdateConsumer(ctx, jetstream.ConsumerConfig{
+			Durable:       durableName(c.opts.Group, topic),
+			FilterSubject: topic,
+			AckPolicy:     jetstream.AckExplicitPolicy,
+			AckWait:       c.opts.NATS.AckWait,
+			MaxAckPending: maxAckPending,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create NATS consumer of %q: %w", topic, err)
+		}
+
+		s := c.d.subscribe(context.WithoutCancel(ctx), topic, c.handlers[topic], opts)
+		cc, err := consumer.Consume(func(m jetstream.Msg) {
+			s.dispatch(natsDelivery(topic, m))
+		})
+		if err != nil {
+			return fmt.Errorf("failed to consume NATS consumer of %q: %w", topic, err)
+		}
+		consumes = append(consumes, cc)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-c.d.stopped():
+	}
+	return nil
+}
+
+// natsDelivery returns the delivery of a message of topic.
+func natsDelivery(topic string, m jetstream.Msg) *delivery {
+	msg := &Message{Topic: topic, Key: m.Headers().Get(HeaderKey), Value: m.Data(), Headers: map[string]string{}}
+	for k := range m.Headers() {
+		// The dead-lettered copy of a message must not be dropped as a duplicate of the message.
+		if k != HeaderKey && k != nats.MsgIdHdr {
+			msg.Headers[k] = m.Headers().Get(k)
+		}
+	}
+	if meta, err := m.Metadata(); err == nil {
+		msg.ID = strconv.FormatUint(meta.Sequence.Stream, 10)
+	}
+	return &delivery{
+		msg:      msg,
+		ack:      func(ctx context.Context) error { return m.DoubleAck(ctx) },
+		progress: func() { _ = m.InProgress() },
+	}
+}
+
+// durableName returns the name of the durable consumer of topic for group. The names of the
+// consumers cannot contain the separators and wildcards of the subjects.
+func durableName(group string, topic string) string {
+	return strings.NewReplacer(".", "_", "*", "_", ">", "_").Replace(group + "_" + topic)
+}
+
+// Stop stops consuming, waits for the messages being handled and closes the connection.
+func (c *NATSConsumer) Stop() {
+	c.d.stop()
+	_ = c.producer.Close()
+}
@@ -0,0 +1,281 @@
+package mq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// RabbitMQProducer publishes messages to a topic exchange of RabbitMQ, with their topic as routing
+// key. The channel is in confirm mode: a message is published once the server confirmed it.
+type RabbitMQProducer struct {
+	opts *RabbitMQOptions
+
+	mu   sync.Mutex // Serializes the publications, so that the messages of a key stay in order
+	conn *amqp.Connection
+	ch   *amqp.Channel
+}
+
+// RabbitMQConsumer consumes messages from RabbitMQ. Each topic is read from a durable queue of the
+// consumer group, bound to the exchange with the topic as routing key.
+type RabbitMQConsumer struct {
+	producer *RabbitMQProducer
+	opts     *Options
+	d        *dispatcher
+	topics   map[string]*HandlerOptions
+	handlers map[string]HandlerFunc
+}
+
+// Ensure RabbitMQProducer and RabbitMQConsumer implement Producer and Consumer at compile time.
+var (
+	_ Producer = (*RabbitMQProducer)(nil)
+	_ Consumer = (*RabbitMQConsumer)(nil)
+)
+
+// NewRabbitMQProducer creates a producer connected to the RabbitMQ server of opts. It declares the
+// exchange of the options if it does not exist.
+func NewRabbitMQProducer(opts *RabbitMQOptions) (*RabbitMQProducer, error) {
+	p := &RabbitMQProducer{opts: opts}
+	if err := p.connect(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// connect opens the connection and the channel of the producer.
+func (p *RabbitMQProducer) connect() error {
+	conn, ch, err := dialRabbitMQ(p.opts)
+	if err != nil {
+		return err
+	}
+	if err := ch.Confirm(false); err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to put RabbitMQ channel in confirm mode: %w", err)
+	}
+	p.conn, p.ch = conn, ch
+	return nil
+}
+
+// dialRabbitMQ opens a connection and a channel to the server of opts, and declares its exchange.
+func dialRabbitMQ(opts *RabbitMQOptions) (*amqp.Connection, *amqp.Channel, error) {
+	conn, err := amqp.DialConfig(opts.URL, amqp.Config{Dial: amqp.DefaultDial(opts.Timeout)})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		_ = conn.Close()
+		return nil, nil, fmt.Errorf("failed to open RabbitMQ channel: %w", err)
+	}
+	if err := ch.ExchangeDeclare(opts.Exchange, amqp.ExchangeTopic, true, false, false, false, nil); err != nil {
+		_ = conn.Close()
+		return nil, nil, fmt.Errorf("failed to declare RabbitMQ exchange %q: %w", opts.Exchange, err)
+	}
+	return conn, ch, nil
+}
+
+// Publish publishes msgs one at a time, waiting for the confirmation of the server. The producer
+// reconnects if its channel was closed, by the server or by a network failure.
+func (p *RabbitMQProducer) Publish(ctx context.Context, msgs ...*Message) []error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return publishInOrder(ctx, msgs, func(ctx context.Context, msg *Message) error {
+		if p.ch == nil || p.ch.IsClosed() {
+			if p.conn != nil {
+				_ = p.conn.Close()
+			}
+			if err := p.connect(); err != nil {
+				return err
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, p.opts.Timeout)
+		defer cancel()
+
+		headers := make(amqp.Table, len(msg.Headers)+1)
+		for k, v := range msg.Headers {
+			headers[k] = v
+		}
+		if msg.Key != "" {
+			headers[HeaderKey] = msg.Key
+		}
+		confirm, err := p.ch.PublishWithDeferredConfirmWithContext(ctx, p.opts.Exchange, msg.Topic, false, false, amqp.Publishing{
+			Headers:      headers,
+			DeliveryMode: amqp.Persistent,
+			MessageId:    msg.ID,
+			Timestamp:    time.Now(),
+			Body:         msg.Value,
+		})
+		if err != nil {
+			return err
+		}
+		acked, err := confirm.WaitContext(ctx)
+		if err != nil {
+			return err
+		}
+		if !acked {
+			return errors.New("message rejected by RabbitMQ")
+		}
+		return nil
+	})
+}
+
+// Close closes the connection to the server.
+func (p *RabbitMQProducer) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil || p.conn.IsClosed() {
+		return nil
+	}
+	return p.conn.Close()
+}
+
+// NewRabbitMQConsumer creates a consumer of the consumer group of opts, connected to its RabbitMQ
+// server.
+func NewRabbitMQConsumer(opts *Options) (*RabbitMQConsumer, error) {
+	producer, err := NewRabbitMQProducer(opts.RabbitMQ)
+	if err != nil {
+		return nil, err
+	}
+	return &RabbitMQConsumer{
+		producer: producer,
+		opts:     opts,
+		d:        newDispatcher("rabbitmq", producer, opts),
+		topics:   map[string]*HandlerOptions{},
+		handlers: map[string]HandlerFunc{},
+	}, nil
+}
+
+// Register registers the handler of a topic.
+func (c *RabbitMQConsumer) Register(topic string, fn HandlerFunc, opts ...HandlerOption) {
+	c.topics[topic] = NewHandlerOptions(opts...)
+	c.handlers[topic] = fn
+}
+
+// Start consumes the queues of the registered topics until ctx is canceled or Stop is called. It
+// reconnects when the connection is lost, and the messages that were not acknowledged are
+// delivered again.
+func (c *RabbitMQConsumer) Start(ctx context.Context) error {
+	subs := make(map[string]*subscription, len(c.topics))
+	for topic, opts := range c.topics {
+		subs[topic] = c.d.subscribe(context.WithoutCancel(ctx), topic, c.handlers[topic], opts)
+	}
+
+	for attempt := 1; ; attempt++ {
+		closed, err := c.consume(subs)
+		if err == nil {
+			attempt = 0
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-c.d.stopped():
+				return nil
+			case err = <-closed:
+			}
+		}
+
+		backoff := RetryPolicy{Backoff: 100 * time.Millisecond, MaxBackoff: 10 * time.Second}.backoff(max(attempt, 1))
+		slog.ErrorContext(ctx, "lost connection to RabbitMQ, reconnecting", "backoff", backoff, "error", err)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-c.d.stopped():
+			return nil
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// consume opens a connection, declares and binds the queues of the topics, and dispatches their
+// messages to subs until the connection is closed. The returned channel receives the error that
+// closed the connection.
+func (c *RabbitMQConsumer) consume(subs map[string]*subscription) (<-chan *amqp.Error, error) {
+	conn, ch, err := dialRabbitMQ(c.opts.RabbitMQ)
+	if err != nil {
+		return nil, err
+	}
+	closed := conn.NotifyClose(make(chan *amqp.Error, 1))
+	go func() {
+		<-c.d.stopped()
+		_ = conn.Close()
+	}()
+
+	for topic, s := range subs {
+		// Strict order requires a single unacknowledged message, since the messages that are
+		// rejected are delivered again after the later ones.
+		prefetch := c.opts.MaxInFlight
+		if s.opts.InOrder {
+			prefetch = 1
+		}
+
+		// Each topic has its own channel, since the prefetch count applies to a channel.
+		tch := ch
+		if len(subs) > 1 {
+			if tch, err = conn.Channel(); err != nil {
+				_ = conn.Close()
+				return nil, fmt.Errorf("failed to open RabbitMQ channel: %w", err)
+			}
+		}
+		deliveries, err := c.declare(tch, topic, prefetch)
+		if err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+		go func(s *subscription) {
+			for d := range deliveries {
+				if !s.dispatch(rabbitMQDelivery(s.topic, d)) {
+					return
+				}
+			}
+		}(s)
+	}
+	return closed, nil
+}
+
+// declare declares the queue of topic, binds it to the exchange and consumes it from ch.
+func (c *RabbitMQConsumer) declare(ch *amqp.Channel, topic string, prefetch int) (<-chan amqp.Delivery, error) {
+	queue := c.opts.Group + "." + topic
+	if _, err := ch.QueueDeclare(queue, true, false, false, false, nil); err != nil {
+		return nil, fmt.Errorf("failed to declare RabbitMQ queue %q: %w", queue, err)
+	}
+	if err := ch.QueueBind(queue, topic, c.opts.RabbitMQ.Exchange, false, nil); err != nil {
+		return nil, fmt.Errorf("failed to bind RabbitMQ queue %q: %w", queue, err)
+	}
+	if err := ch.Qos(prefetch, 0, false); err != nil {
+		return nil, fmt.Errorf("failed to set the prefetch count of RabbitMQ queue %q: %w", queue, err)
+	}
+	deliveries, err := ch.Consume(queue, "", false, false, false, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume RabbitMQ queue %q: %w", queue, err)
+	}
+	return deliveries, nil
+}
+
+// rabbitMQDelivery returns the delivery of a message of topic.
+func rabbitMQDelivery(topic string, d amqp.Delivery) *delivery {
+	msg := &Message{Topic: topic, Value: d.Body, Headers: make(map[string]string, len(d.Headers)), ID: d.MessageId}
+	for k, v := range d.Headers {
+		if k == HeaderKey {
+			msg.Key = fmt.Sprint(v)
+			continue
+		}
+		msg.Headers[k] = fmt.Sprint(v)
+	}
+	return &delivery{
+		msg: msg,
+		ack: func(context.Context) error { return d.Ack(false) },
+	}
+}
+
+// Stop stops consuming, waits for the messages being handled and closes the connections.
+func (c *RabbitMQConsumer) Stop() {
+	c.d.stop()
+	_ = c.producer.Close()
+}
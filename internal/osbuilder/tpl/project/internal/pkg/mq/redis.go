@@ -0,0 +1,268 @@
+package mq
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Fields of the entries of the Redis streams.
+const (
+	redisFieldKey     = "key"
+	redisFieldValue   = "value"
+	redisFieldHeaders = "headers"
+)
+
+// RedisProducer publishes messages to Redis Streams: a message is added to the stream named after
+// its topic.
+type RedisProducer struct {
+	client *redis.Client
+	opts   *RedisOptions
+}
+
+// RedisConsumer consumes messages from Redis Streams as a member of the consumer group of its
+// options. The messages left pending by a member that stopped are claimed by the other members.
+type RedisConsumer struct {
+	producer *RedisProducer
+	opts     *Options
+	name     string // Name of the consumer in the consumer groups
+	d        *dispatcher
+	topics   map[string]*HandlerOptions
+	handlers map[string]HandlerFunc
+}
+
+// Ensure RedisProducer and RedisConsumer implement Producer and Consumer at compile time.
+var (
+	_ Producer = (*RedisProducer)(nil)
+	_ Consumer = (*RedisConsumer)(nil)
+)
+
+// NewRedisProducer creates a producer connected to the Redis server of opts and checks that it is
+// reachable.
+func NewRedisProducer(opts *RedisOptions) (*RedisProducer, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:         opts.Addr,
+		Username:     opts.Username,
+		Password:     opts.Password,
+		DB:           opts.Database,
+		DialTimeout:  opts.Timeout,
+		ReadTimeout:  opts.Timeout,
+		WriteTimeout: opts.Timeout,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+	return &RedisProducer{client: client, opts: opts}, nil
+}
+
+// Publish adds msgs to their streams one at a time. The streams are trimmed to about MaxLen
+// entries if it is set.
+func (p *RedisProducer) Publish(ctx context.Context, msgs ...*Message) []error {
+	return publishInOrder(ctx, msgs, func(ctx context.Context, msg *Message) error {
+		headers, err := json.Marshal(msg.Headers)
+		if err != nil {
+			return err
+		}
+		return p.client.XAdd(ctx, &redis.XAddArgs{
+			Stream: msg.Topic,
+			MaxLen: p.opts.MaxLen,
+			Approx: true,
+			Values: []any{redisFieldKey, msg.Key, redisFieldValue, msg.Value, redisFieldHeaders, headers},
+		}).Err()
+	})
+}
+
+// Close closes the connections to Redis.
+func (p *RedisProducer) Close() error {
+	return p.client.Close()
+}
+
+// NewRedisConsumer creates a consumer of the consumer group of opts, connected to its Redis
+// server. The consumer is named after the host and the process, so that a restarted process
+// claims the messages it left pending.
+func NewRedisConsumer(opts *Options) (*RedisConsumer, error) {
+	producer, err := NewRedisProducer(opts.Redis)
+	if err != nil {
+		return nil, err
+	}
+	hostname, _ := os.Hostname()
+	return &RedisConsumer{
+		producer: producer,
+		opts:     opts,
+		name:     fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		d:        newDispatcher("redis", producer, opts),
+		topics:   map[string]*HandlerOptions{},
+		handlers: map[string]HandlerFunc{},
+	}, nil
+}
+
+// Register registers the handler of a topic.
+func (c *RedisConsumer) Register(topic string, fn HandlerFunc, opts ...HandlerOption) {
+	c.topics[topic] = NewHandlerOptions(opts...)
+	c.handlers[topic] = fn
+}
+
+// Start creates the consumer groups of the registered topics, which read their streams from the
+// start, and dispatches their messages until ctx is canceled or Stop is called.
+func (c *RedisConsumer) Start(ctx context.Context) error {
+	for topic := range c.topics {
+		err := c.producer.client.XGroupCreateMkStream(ctx, topic, c.opts.Group, "0").Err()
+		if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			return fmt.Errorf("failed to create Redis consumer group of %q: %w", topic, err)
+		}
+	}
+
+	// Cancel the reads blocked on the streams when the consumer stops.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-c.d.stopped():
+			cancel()
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for topic, opts := range c.topics {
+		s := c.d.subscribe(context.WithoutCancel(ctx), topic, c.handlers[topic], opts)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.consume(ctx, s)
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// consume reads the stream of a subscription until ctx is canceled. It reads the messages left
+// pending by the previous run of the consumer first, then the new messages, and claims the
+// messages left pending by the other members of the group for longer than ClaimIdle.
+func (c *RedisConsumer) consume(ctx context.Context, s *subscription) {
+	client := c.producer.client
+	count := int64(c.opts.MaxInFlight)
+	if s.opts.InOrder {
+		count = 1
+	}
+
+	pending := "0" // ID after which the pending messages are read, empty once they are all read
+	var claimed time.Time
+	for attempt := 1; ctx.Err() == nil; {
+		var messages []redis.XMessage
+		var err error
+		switch {
+		case time.Since(claimed) >= c.opts.Redis.ClaimIdle:
+			messages, _, err = client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+				Stream:   s.topic,
+				Group:    c.opts.Group,
+				Consumer: c.name,
+				MinIdle:  c.opts.Redis.ClaimIdle,
+				Start:    "0-0",
+				Count:    count,
+			}).Result()
+			if err == nil && len(messages) < int(count) {
+				claimed = time.Now()
+			}
+		default:
+			id := ">"
+			if pending != "" {
+				id = pending
+			}
+			var streams []redis.XStream
+			streams, err = client.XReadGroup(ctx, &redis.XReadGroupArgs{
+				Group:    c.opts.Group,
+				Consumer: c.name,
+				Streams:  []string{s.topic, id},
+				Count:    count,
+				Block:    time.Second,
+			}).Result()
+			if err == redis.Nil {
+				err = nil
+			}
+			if len(streams) > 0 {
+				messages = streams[0].Messages
+			}
+			if err == nil && pending != "" {
+				pending = ""
+				if len(messages) > 0 {
+					pending = messages[len(messages)-1].ID
+				}
+			}
+		}
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			backoff := RetryPolicy{Backoff: 100 * time.Millisecond, MaxBackoff: 10 * time.Second}.backoff(attempt)
+			slog.ErrorContext(ctx, "failed to read Redis stream, retrying", "topic", s.topic, "backoff", backoff, "error", err)
+			attempt++
+			if !wait(backoff, c.d.stopped()) {
+				return
+			}
+			continue
+		}
+		attempt = 1
+
+		for _, m := range messages {
+			// The pending messages trimmed from the stream have no fields.
+			if len(m.Values) == 0 {
+				_ = client.XAck(ctx, s.topic, c.opts.Group, m.ID).Err()
+				continue
+			}
+			if !s.dispatch(c.delivery(s.topic, m)) {
+				return
+			}
+		}
+	}
+}
+
+// delivery returns the delivery of an entry of the stream of topic.
+func (c *RedisConsumer) delivery(topic string, m redis.XMessage) *delivery {
+	client := c.producer.client
+	msg := &Message{Topic: topic, ID: m.ID, Headers: map[string]string{}}
+	if v, ok := m.Values[redisFieldKey].(string); ok {
+		msg.Key = v
+	}
+	if v, ok := m.Values[redisFieldValue].(string); ok {
+		msg.Value = []byte(v)
+	}
+	if v, ok := m.Values[redisFieldHeaders].(string); ok {
+		if err := json.Unmarshal([]byte(v), &msg.Headers); err != nil {
+			slog.Error("failed to decode headers of Redis message", "topic", topic, "id", m.ID, "error", err)
+		}
+	}
+
+	return &delivery{
+		msg: msg,
+		ack: func(ctx context.Context) error {
+			return client.XAck(ctx, topic, c.opts.Group, m.ID).Err()
+		},
+		// Claiming a message resets its idle time, so that the other members do not claim it.
+		progress: func() {
+			_ = client.XClaimJustID(context.Background(), &redis.XClaimArgs{
+				Stream:   topic,
+				Group:    c.opts.Group,
+				Consumer: c.name,
+				Messages: []string{m.ID},
+			}).Err()
+		},
+	}
+}
+
+// Stop stops consuming, waits for the messages being handled and closes the connections.
+func (c *RedisConsumer) Stop() {
+	c.d.stop()
+	_ = c.producer.Close()
+}
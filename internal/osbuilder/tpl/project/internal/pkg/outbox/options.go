@@ -0,0 +1,42 @@
+package outbox
+
+import (
+	"errors"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Options contains the configuration of the relay of the outbox.
+type Options struct {
+	// PollInterval is how often the relay polls the outbox table once it is drained.
+	PollInterval time.Duration `json:"poll-interval" mapstructure:"poll-interval"`
+	// BatchSize is the maximum number of messages published at once.
+	BatchSize int `json:"batch-size" mapstructure:"batch-size"`
+}
+
+// NewOptions returns Options with default values.
+func NewOptions() *Options {
+	return &Options{
+		PollInterval: time.Second,
+		BatchSize:    100,
+	}
+}
+
+// AddFlags adds the flags of the options to fs, prefixed with prefix (e.g., "outbox").
+func (o *Options) AddFlags(fs *pflag.FlagSet, prefix string) {
+	fs.DurationVar(&o.PollInterval, prefix+".poll-interval", o.PollInterval, "How often the relay polls the outbox table once it is drained.")
+	fs.IntVar(&o.BatchSize, prefix+".batch-size", o.BatchSize, "Maximum number of outbox messages published at once.")
+}
+
+// Validate checks whether the options are valid.
+func (o *Options) Validate() []error {
+	var errs []error
+	if o.PollInterval <= 0 {
+		errs = append(errs, errors.New("outbox.poll-interval must be positive"))
+	}
+	if o.BatchSize <= 0 {
+		errs = append(errs, errors.New("outbox.batch-size must be positive"))
+	}
+	return errs
+}
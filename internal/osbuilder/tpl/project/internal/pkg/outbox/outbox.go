@@ -0,0 +1,67 @@
+// Package outbox implements the transactional outbox of the events of the kinds. An event is
+// written into the outbox table in the transaction of the write that raised it, and published to
+// the broker by the Relay afterwards, so that an event is published if and only if its write is
+// committed.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// TableName is the table of the outbox messages.
+const TableName = "{{.Web.OutboxTable}}"
+
+// Message is an event waiting in the outbox table to be published.
+type Message struct {
+	// ID orders the messages: the messages of a key are published in the order of their ID.
+	ID int64 `gorm:"column:id;primaryKey;autoIncrement:true"`
+	// Topic is the topic the message is published to.
+	Topic string `gorm:"column:topic;not null"`
+	// Key is the key of the message, the ID of the resource of the event.
+	Key string `gorm:"column:message_key;not null;default:''"`
+	// Payload is the event encoded in JSON.
+	Payload []byte `gorm:"column:payload"`
+	// Headers holds the headers of the message encoded in JSON, such as the trace context of the write.
+	Headers string `gorm:"column:headers"`
+	// CreatedAt is the time the message was written into the outbox.
+	CreatedAt time.Time `gorm:"column:created_at;not null;default:current_timestamp"`
+}
+
+// TableName returns the table of the outbox messages.
+func (*Message) TableName() string {
+	return TableName
+}
+
+// NewMessage returns the message of an event, published to topic with the given key. The message
+// carries the trace context of ctx, so that the consumers of the event continue the trace of the write.
+func NewMessage(ctx context.Context, topic string, key string, event proto.Message) (*Message, error) {
+	payload, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := propagation.MapCarrier{"content-type": "application/json"}
+	otel.GetTextMapPropagator().Inject(ctx, headers)
+	encoded, err := json.Marshal(headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{Topic: topic, Key: key, Payload: payload, Headers: string(encoded)}, nil
+}
+
+// HeaderMap returns the headers of the message.
+func (m *Message) HeaderMap() map[string]string {
+	headers := map[string]string{}
+	if m.Headers != "" {
+		_ = json.Unmarshal([]byte(m.Headers), &headers)
+	}
+	return headers
+}
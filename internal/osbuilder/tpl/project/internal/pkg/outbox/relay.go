@@ -0,0 +1,130 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"{{.D.ModuleName}}/internal/pkg/mq"
+)
+
+// Relay publishes the messages of the outbox table to a broker with at-least-once delivery: a
+// message is removed from the table only after the broker acknowledged it, so it is published
+// again if the relay stops in between. The messages of a key are published in order: they are
+// read in the order of their ID, and a message is never removed before the earlier messages of
+// its key.
+type Relay struct {
+	db       *gorm.DB
+	producer mq.Producer
+	opts     *Options
+}
+
+// NewRelay creates a relay that publishes the messages of the outbox table of db with producer.
+func NewRelay(db *gorm.DB, producer mq.Producer, opts *Options) *Relay {
+	return &Relay{db: db, producer: producer, opts: opts}
+}
+
+// Run publishes the messages of the outbox until ctx is canceled. It polls the outbox table
+// every poll interval, and right away as long as full batches are published.
+func (r *Relay) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		n, err := r.Flush(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "failed to publish outbox messages", "published", n, "error", err)
+		}
+
+		wait := r.opts.PollInterval
+		if err == nil && n == r.opts.BatchSize {
+			wait = 0
+		}
+		timer.Reset(wait)
+	}
+}
+
+// Flush publishes a batch of the oldest messages of the outbox, removes the published messages
+// from the table and returns their number. The error joins the errors of the messages that were
+// not published, which stay in the table together with the later messages of their key.
+//
+// The batch is locked until it is removed, so that the relays of the replicas of the server
+// publish the messages in turn instead of concurrently, which would break the order of the keys.
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	var published int
+	var failures []error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var msgs []*Message
+		if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Order("id").Limit(r.opts.BatchSize).Find(&msgs).Error; err != nil {
+			return err
+		}
+		if len(msgs) == 0 {
+			return nil
+		}
+
+		// The ID of a message lets the brokers that support it drop the duplicates published when
+		// the relay stops before the message is removed.
+		batch := make([]*mq.Message, len(msgs))
+		for i, msg := range msgs {
+			batch[i] = &mq.Message{
+				Topic:   msg.Topic,
+				Key:     msg.Key,
+				Value:   msg.Payload,
+				Headers: msg.HeaderMap(),
+				ID:      fmt.Sprintf("outbox-%d", msg.ID),
+			}
+		}
+		errs := r.producer.Publish(ctx, batch...)
+		failed := map[string]bool{}
+		var ids []int64
+		for i, msg := range msgs {
+			if failed[msg.Key] {
+				continue
+			}
+			if errs[i] != nil {
+				failed[msg.Key] = true
+				failures = append(failures, fmt.Errorf("message %d of key %q: %w", msg.ID, msg.Key, errs[i]))
+				continue
+			}
+			ids = append(ids, msg.ID)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		published = len(ids)
+		return tx.Delete(&Message{}, ids).Error
+	}, r.txOptions())
+	if err != nil {
+		return 0, err
+	}
+	return published, errors.Join(failures...)
+}
+
+// Close closes the producer of the relay.
+func (r *Relay) Close() error {
+	return r.producer.Close()
+}
+
+// txOptions returns the options of the transaction of a batch. Read committed keeps MySQL from
+// locking the gap after the last message, which would block the writes of new messages until the
+// batch is published. SQLite has no isolation levels and serializes its write transactions.
+func (r *Relay) txOptions() *sql.TxOptions {
+	if r.db.Dialector.Name() == "sqlite" {
+		return nil
+	}
+	return &sql.TxOptions{Isolation: sql.LevelReadCommitted}
+}
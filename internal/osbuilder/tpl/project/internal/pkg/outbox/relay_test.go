@@ -0,0 +1,151 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"{{.D.ModuleName}}/internal/pkg/mq"
+)
+
+const testTopic = "test.posts"
+
+func newTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "outbox.db")), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&Message{}))
+	return db
+}
+
+// enqueue writes a message of value for each key into the outbox, in one transaction.
+func enqueue(t *testing.T, db *gorm.DB, value string, keys ...string) {
+	ctx := context.Background()
+	require.NoError(t, db.Transaction(func(tx *gorm.DB) error {
+		for _, key := range keys {
+			msg, err := NewMessage(ctx, testTopic, key, wrapperspb.String(value+"-"+key))
+			require.NoError(t, err)
+			if err := tx.Create(msg).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+// published returns the payloads of the messages published to the test topic.
+func published(broker *mq.MemoryBroker) []string {
+	var payloads []string
+	for _, msg := range broker.Messages(testTopic) {
+		payloads = append(payloads, string(msg.Value))
+	}
+	return payloads
+}
+
+func pending(t *testing.T, db *gorm.DB) int64 {
+	var count int64
+	require.NoError(t, db.Model(&Message{}).Count(&count).Error)
+	return count
+}
+
+func TestRelay_Flush(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	broker := mq.NewMemoryBroker(nil)
+	relay := NewRelay(db, broker, NewOptions())
+
+	enqueue(t, db, "created", "post-1", "post-2")
+	// The messages of a rolled back transaction are never published.
+	err := db.Transaction(func(tx *gorm.DB) error {
+		msg, err := NewMessage(ctx, testTopic, "post-3", wrapperspb.String("created-post-3"))
+		require.NoError(t, err)
+		require.NoError(t, tx.Create(msg).Error)
+		return errors.New("rollback")
+	})
+	require.Error(t, err)
+
+	n, err := relay.Flush(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{`"created-post-1"`, `"created-post-2"`}, published(broker))
+	assert.Zero(t, pending(t, db))
+
+	msg := broker.Messages(testTopic)[0]
+	assert.Equal(t, "post-1", msg.Key)
+	assert.Equal(t, "application/json", msg.Headers["content-type"])
+
+	n, err = relay.Flush(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func TestRelay_FlushKeepsTheOrderOfKeys(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+	broker := mq.NewMemoryBroker(nil)
+	relay := NewRelay(db, broker, NewOptions())
+
+	enqueue(t, db, "created", "post-1", "post-2")
+	enqueue(t, db, "updated", "post-1", "post-2")
+
+	// The first message of post-1 fails: the later message of post-1 is not published before it,
+	// while the messages of post-2 are published.
+	broker.FailWith(func(msg *mq.Message) error {
+		if string(msg.Value) == `"created-post-1"` {
+			return errors.New("broker unavailable")
+		}
+		return nil
+	})
+	n, err := relay.Flush(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "broker unavailable")
+	assert.Equal(t, 2, n)
+	assert.Equal(t, int64(2), pending(t, db))
+	assert.Equal(t, []string{`"created-post-2"`, `"updated-post-2"`}, published(broker))
+
+	broker.FailWith(nil)
+	n, err = relay.Flush(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Zero(t, pending(t, db))
+
+	assert.Equal(t, []string{
+		`"created-post-2"`, `"updated-post-2"`,
+		`"created-post-1"`, `"updated-post-1"`,
+	}, published(broker))
+}
+
+func TestRelay_Run(t *testing.T) {
+	db := newTestDB(t)
+	broker := mq.NewMemoryBroker(nil)
+	opts := NewOptions()
+	opts.BatchSize = 2
+	opts.PollInterval = 10 * time.Millisecond
+	relay := NewRelay(db, broker, opts)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		relay.Run(ctx)
+	}()
+
+	enqueue(t, db, "created", "post-1", "post-2", "post-3", "post-4", "post-5")
+	require.Eventually(t, func() bool { return len(broker.Messages(testTopic)) == 5 }, 5*time.Second, 10*time.Millisecond)
+	assert.Zero(t, pending(t, db))
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the relay did not stop after its context was canceled")
+	}
+}
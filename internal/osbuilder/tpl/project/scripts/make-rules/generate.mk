@@ -36,5 +36,9 @@ gen.protoc.%: tools.verify.protoc-gen-go ## 编译 protobuf 文件.
 gen.generate:
 	@GOWORK=off go generate ./...
 
+gen.gorm-model: ## 根据各组件的数据库重新生成 gorm/gen 查询代码.
+	@echo "===========> Generate gorm/gen query objects"
+	@cd $(PROJ_ROOT_DIR) && go run ./cmd/gen-gorm-model
+
 # 伪目标（防止文件与目标名称冲突）
-.PHONY: gen.add-copyright gen.protoc gen.generate
+.PHONY: gen.add-copyright gen.protoc gen.generate gen.gorm-model
@@ -0,0 +1,317 @@
+package types
+
+import (
+	"fmt"
+	"net/mail"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/duke-git/lancet/v2/strutil"
+	"gopkg.in/yaml.v3"
+
+	"github.com/onexstack/osbuilder/internal/osbuilder/known"
+)
+
+// Client declares a typed client of a web server, generated under pkg/clientset/typed/<name> and
+// served by the clientset of the server. A client is declared in PROJECT either by its name, as
+// a REST client, or as a mapping, for example:
+//
+//	clients:
+//	  - billing
+//	  - name: avatars
+//	    type: s3
+//	    params:
+//	      bucket: avatars
+//	  - name: usercenter
+//	    type: grpc
+//	    params:
+//	      server: mb-usercenter
+type Client struct {
+	// Name is the name of the client in snake_case (e.g., "user_center"). It names the package,
+	// the clientset method and the configuration section of the client.
+	Name string `yaml:"name"`
+	// Type is the type of the client (see known.ClientTypeREST). Empty means a REST client.
+	Type string `yaml:"type,omitempty"`
+	// Params overrides the default values of the options of the client (see ClientParams), and
+	// names the web server served by gRPC clients.
+	Params map[string]string `yaml:"params,omitempty"`
+
+	// Server is the web server of the project called by the client: the server of a gRPC
+	// client, or the web server a dependency client was derived from.
+	Server *WebServer `yaml:"-"`
+}
+
+// ClientParam describes a parameter of a type of clients.
+type ClientParam struct {
+	// Kind is the Go type of the option set by the parameter: "string", "int", "bool",
+	// "duration" or "email".
+	Kind string
+	// Default is the value of the option when the parameter is not set. Empty for the server
+	// of gRPC clients, which must be set.
+	Default string
+}
+
+// ClientParams lists the parameters accepted by every type of clients. Apart from the server of
+// gRPC clients, the parameters set the default values of the options of the clients, which can
+// still be changed by the configuration file and the command line flags of the server.
+var ClientParams = map[string]map[string]ClientParam{
+	known.ClientTypeREST: {
+		"endpoint":    {"string", "http://127.0.0.1:9999"},
+		"timeout":     {"duration", "30s"},
+		"retry-count": {"int", "3"},
+	},
+	known.ClientTypeS3: {
+		"endpoint": {"string", "127.0.0.1:9000"},
+		"bucket":   {"string", ""},
+		"region":   {"string", "us-east-1"},
+		"use-ssl":  {"bool", "false"},
+	},
+	known.ClientTypeSMTP: {
+		"host": {"string", "127.0.0.1"},
+		"port": {"int", "25"},
+		"from": {"email", "noreply@example.com"},
+	},
+	known.ClientTypeGRPC: {
+		"server":  {"string", ""},
+		"addr":    {"string", "127.0.0.1:6666"},
+		"timeout": {"duration", "5s"},
+	},
+}
+
+// reservedClientNames are the packages imported next to the client packages by the generated
+// server, which the package of a client must not shadow.
+var reservedClientNames = []string{
+	"authz", "biz", "cache", "clientset", "context", "errors", "gorm", "handler", "known", "migrations",
+	"model", "registry", "server", "store", "time", "token", "validation", "where",
+}
+
+// NewDependencyClient returns the client of the web server binaryName listed in the dependsOn
+// of another web server. It calls the gRPC API of the server if it serves one, and its REST API
+// otherwise. The client is named after the component of the server (e.g., "usercenter").
+func NewDependencyClient(binaryName string, server *WebServer) *Client {
+	c := &Client{
+		Name:   strings.ReplaceAll(GetComponentName(binaryName), "-", "_"),
+		Type:   known.ClientTypeGRPC,
+		Params: map[string]string{"server": binaryName},
+		Server: server,
+	}
+	if server != nil && !server.ServesGRPC() {
+		c.Type = known.ClientTypeREST
+		c.Params = map[string]string{"endpoint": "http://127.0.0.1:5555"}
+	}
+	return c
+}
+
+// ParseClient parses a client declared as "name[:type]" (e.g., "avatars:s3"), as accepted by the
+// --clients flag of `create quickstart`.
+func ParseClient(spec string) (*Client, error) {
+	parts := strings.Split(strings.TrimSpace(spec), ":")
+	if len(parts) > 2 {
+		return nil, fmt.Errorf("invalid client %q: expected name[:type]", spec)
+	}
+	c := &Client{Name: strings.TrimSpace(parts[0])}
+	if len(parts) == 2 {
+		c.Type = strings.ToLower(strings.TrimSpace(parts[1]))
+	}
+	return c, nil
+}
+
+// UnmarshalYAML decodes a client declared by its name or as a mapping.
+func (c *Client) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*c = Client{Name: node.Value}
+		return nil
+	}
+	type plain Client
+	return node.Decode((*plain)(c))
+}
+
+// MarshalYAML encodes the REST clients without parameters by their name only, as they were
+// declared before clients had types.
+func (c *Client) MarshalYAML() (any, error) {
+	if c.TypeOrDefault() == known.ClientTypeREST && len(c.Params) == 0 {
+		return c.Name, nil
+	}
+	type plain Client
+	return (*plain)(c), nil
+}
+
+// Validate checks the name, the type and the parameters of the client.
+func (c *Client) Validate() error {
+	if !isIdentifier(c.Name) || strings.ToLower(c.Name) != c.Name {
+		return fmt.Errorf("invalid client name %q: only lowercase letters, digits and '_' are allowed and it must not start with a digit", c.Name)
+	}
+	if contains(reservedClientNames, c.PackageName()) {
+		return fmt.Errorf("client name %q is reserved: it names a package of the generated server", c.Name)
+	}
+	if !known.AvailableClientTypes.Has(c.TypeOrDefault()) {
+		return fmt.Errorf("client %q: unsupported type %q; supported: %s",
+			c.Name, c.Type, strings.Join(known.AvailableClientTypes.UnsortedList(), ", "))
+	}
+
+	params := ClientParams[c.TypeOrDefault()]
+	for key, value := range c.Params {
+		p, ok := params[key]
+		if !ok {
+			return fmt.Errorf("client %q: unknown param %q; supported: %s",
+				c.Name, key, strings.Join(c.ParamNames(), ", "))
+		}
+		if err := p.validate(value); err != nil {
+			return fmt.Errorf("client %q: invalid param %s=%q: %w", c.Name, key, value, err)
+		}
+	}
+	if c.IsGRPC() && c.Param("server") == "" {
+		return fmt.Errorf("client %q: gRPC clients must set the server param to the binary name of a web server", c.Name)
+	}
+	return nil
+}
+
+// validate checks that value is a valid value of the parameter.
+func (p ClientParam) validate(value string) error {
+	var err error
+	switch p.Kind {
+	case "int":
+		_, err = strconv.Atoi(value)
+	case "bool":
+		_, err = strconv.ParseBool(value)
+	case "duration":
+		_, err = time.ParseDuration(value)
+	case "email":
+		_, err = mail.ParseAddress(value)
+	}
+	return err
+}
+
+// TypeOrDefault returns the type of the client, which defaults to a REST client.
+func (c *Client) TypeOrDefault() string {
+	if c.Type == "" {
+		return known.ClientTypeREST
+	}
+	return c.Type
+}
+
+// IsGRPC reports whether the client calls the gRPC API of another web server.
+func (c *Client) IsGRPC() bool {
+	return c.TypeOrDefault() == known.ClientTypeGRPC
+}
+
+// ParamNames returns the sorted names of the parameters accepted by the type of the client.
+func (c *Client) ParamNames() []string {
+	var names []string
+	for name := range ClientParams[c.TypeOrDefault()] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Param returns the value of a parameter of the client, or its default value.
+func (c *Client) Param(key string) string {
+	if v, ok := c.Params[key]; ok {
+		return v
+	}
+	// Buckets are named after their client by default.
+	if key == "bucket" {
+		return strings.ReplaceAll(c.Name, "_", "-")
+	}
+	return ClientParams[c.TypeOrDefault()][key].Default
+}
+
+// GoValue returns the Go literal of a parameter of the client, used as the default value of its
+// option (e.g., "30 * time.Second").
+func (c *Client) GoValue(key string) string {
+	value := c.Param(key)
+	switch ClientParams[c.TypeOrDefault()][key].Kind {
+	case "int", "bool":
+		return value
+	case "duration":
+		d, _ := time.ParseDuration(value)
+		for _, unit := range []struct {
+			d    time.Duration
+			name string
+		}{{time.Hour, "time.Hour"}, {time.Minute, "time.Minute"}, {time.Second, "time.Second"}, {time.Millisecond, "time.Millisecond"}} {
+			if d != 0 && d%unit.d == 0 {
+				return fmt.Sprintf("%d * %s", d/unit.d, unit.name)
+			}
+		}
+		return fmt.Sprintf("time.Duration(%d)", d)
+	default:
+		return strconv.Quote(value)
+	}
+}
+
+// PackageName returns the Go package of the client (e.g., "usercenter").
+func (c *Client) PackageName() string {
+	return strings.ReplaceAll(c.Name, "_", "")
+}
+
+// GoName returns the name of the client in the clientset and the options (e.g., "UserCenter").
+func (c *Client) GoName() string {
+	return strutil.UpperFirst(strutil.CamelCase(c.Name))
+}
+
+// ConfigKey returns the section of the client in the configuration file and the prefix of its
+// command line flags (e.g., "user-center").
+func (c *Client) ConfigKey() string {
+	return strings.ReplaceAll(c.Name, "_", "-")
+}
+
+// ServerName returns the component name of the web server called by the client
+// (e.g., "usercenter").
+func (c *Client) ServerName() string {
+	if c.Server != nil {
+		return GetComponentName(c.Server.BinaryName)
+	}
+	return GetComponentName(c.Param("server"))
+}
+
+// ServiceName returns the gRPC service of the web server called by a gRPC client.
+func (c *Client) ServiceName() string {
+	if c.Server != nil && c.Server.GRPCServiceName != "" {
+		return c.Server.GRPCServiceName
+	}
+	return strutil.UpperFirst(c.ServerName())
+}
+
+// Discovery reports whether the client resolves the instances of the web server it calls from
+// the service registry that the server registers to.
+func (c *Client) Discovery() bool {
+	return c.Server != nil && c.Server.ServiceRegistry == known.ServiceRegistryPolaris
+}
+
+// AllClients returns the clients of the clientset of the web server: the declared clients
+// followed by the clients of the web servers it depends on.
+func (ws *WebServer) AllClients() []*Client {
+	return append(append([]*Client{}, ws.Clients...), ws.Dependencies...)
+}
+
+// Discovery reports whether a client of the web server resolves the instances of the web server
+// it calls from a service registry.
+func (ws *WebServer) Discovery() bool {
+	for _, c := range ws.AllClients() {
+		if c.Discovery() {
+			return true
+		}
+	}
+	return false
+}
+
+// ServesGRPC reports whether the web server serves a gRPC API.
+func (ws *WebServer) ServesGRPC() bool {
+	return contains([]string{known.WebFrameworkGRPC, known.WebFrameworkGRPCGateway, known.WebFrameworkGoZero,
+		known.WebFrameworkKitex, known.WebFrameworkConnect}, ws.WebFramework)
+}
+
+// ClientPairs returns the files of a typed client: the client, its options and its fake.
+func (ws *WebServer) ClientPairs(c *Client) map[string]string {
+	dir := filepath.Join(ws.Pkg(), "clientset/typed", c.PackageName())
+	tpl := filepath.Join("/project/internal/apiserver/pkg/clientset/typed", c.TypeOrDefault())
+	return map[string]string{
+		filepath.Join(dir, c.PackageName()+".go"): filepath.Join(tpl, "client.go"),
+		filepath.Join(dir, "options.go"):          filepath.Join(tpl, "options.go"),
+		filepath.Join(dir, "fake/fake.go"):        filepath.Join(tpl, "fake/fake.go"),
+	}
+}
@@ -0,0 +1,112 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseClient(t *testing.T) {
+	c, err := ParseClient("user_center")
+	require.NoError(t, err)
+	assert.Equal(t, "rest", c.TypeOrDefault())
+	assert.Equal(t, "usercenter", c.PackageName())
+	assert.Equal(t, "UserCenter", c.GoName())
+	assert.Equal(t, "user-center", c.ConfigKey())
+
+	c, err = ParseClient("avatars:S3")
+	require.NoError(t, err)
+	assert.Equal(t, "s3", c.Type)
+	assert.Equal(t, "avatars", c.Param("bucket"))
+
+	_, err = ParseClient("a:s3:b")
+	assert.Error(t, err)
+}
+
+func TestClientYAML(t *testing.T) {
+	var clients []*Client
+	require.NoError(t, yaml.Unmarshal([]byte(`
+- billing
+- name: usercenter
+  type: grpc
+  params:
+    server: mb-usercenter
+    timeout: 1m30s
+`), &clients))
+	require.Len(t, clients, 2)
+	assert.Equal(t, "billing", clients[0].Name)
+	assert.True(t, clients[1].IsGRPC())
+	assert.Equal(t, "usercenter", clients[1].ServerName())
+	assert.Equal(t, "Usercenter", clients[1].ServiceName())
+	assert.Equal(t, "90 * time.Second", clients[1].GoValue("timeout"))
+	assert.Equal(t, `"127.0.0.1:6666"`, clients[1].GoValue("addr"))
+
+	out, err := yaml.Marshal(clients)
+	require.NoError(t, err)
+	assert.Equal(t, "- billing\n- name: usercenter\n  type: grpc\n  params:\n    server: mb-usercenter\n    timeout: 1m30s\n", string(out))
+}
+
+func TestClientValidate(t *testing.T) {
+	valid := []*Client{
+		{Name: "billing"},
+		{Name: "mailer", Type: "smtp", Params: map[string]string{"port": "587", "from": "Support <support@example.com>"}},
+		{Name: "usercenter", Type: "grpc", Params: map[string]string{"server": "mb-usercenter"}},
+	}
+	for _, c := range valid {
+		assert.NoError(t, c.Validate(), c.Name)
+	}
+
+	tests := []struct {
+		name   string
+		client *Client
+	}{
+		{name: "InvalidName", client: &Client{Name: "user-center"}},
+		{name: "UpperCase", client: &Client{Name: "Billing"}},
+		{name: "Reserved", client: &Client{Name: "store"}},
+		{name: "UnknownType", client: &Client{Name: "billing", Type: "ftp"}},
+		{name: "UnknownParam", client: &Client{Name: "billing", Params: map[string]string{"bucket": "b"}}},
+		{name: "InvalidInt", client: &Client{Name: "mailer", Type: "smtp", Params: map[string]string{"port": "smtp"}}},
+		{name: "InvalidEmail", client: &Client{Name: "mailer", Type: "smtp", Params: map[string]string{"from": "nobody"}}},
+		{name: "MissingServer", client: &Client{Name: "usercenter", Type: "grpc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, tt.client.Validate())
+		})
+	}
+}
+
+func TestDependencyClients(t *testing.T) {
+	proj := &Project{
+		D: &GeneratedData{},
+		WebServers: []*WebServer{
+			{BinaryName: "mb-apiserver", WebFramework: "gin", DependsOn: []string{"mb-usercenter", "mb-billing"}},
+			{BinaryName: "mb-usercenter", WebFramework: "kitex", ServiceRegistry: "polaris"},
+			{BinaryName: "mb-billing", WebFramework: "nethttp"},
+		},
+	}
+	ws := proj.WebServers[0]
+	ws.Clients = []*Client{{Name: "avatars", Type: "s3"}}
+	ws.Complete(proj)
+
+	clients := ws.AllClients()
+	require.Len(t, clients, 3)
+	assert.Equal(t, "avatars", clients[0].Name)
+
+	uc := clients[1]
+	assert.Equal(t, "usercenter", uc.Name)
+	assert.True(t, uc.IsGRPC())
+	assert.Equal(t, "usercenter", uc.ServerName())
+	assert.True(t, uc.Discovery())
+	require.NoError(t, uc.Validate())
+
+	billing := clients[2]
+	assert.Equal(t, "rest", billing.TypeOrDefault())
+	assert.Same(t, proj.WebServers[2], billing.Server)
+	assert.False(t, billing.Discovery())
+	require.NoError(t, billing.Validate())
+
+	assert.True(t, ws.Discovery())
+}
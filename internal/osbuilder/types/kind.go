@@ -0,0 +1,493 @@
+package types
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/duke-git/lancet/v2/strutil"
+	"github.com/gobuffalo/flect"
+
+	"github.com/onexstack/osbuilder/internal/osbuilder/known"
+)
+
+// Kind records a REST resource kind generated for a web server, together with its field spec.
+// Kinds are persisted in PROJECT so that later runs of `create api` can resolve relations
+// between kinds that were created separately.
+type Kind struct {
+	// Name is the kind path as passed to `create api --kinds` (e.g., "post", "ticket/cron_job").
+	Name string `yaml:"name"`
+	// Fields is the field spec of the kind, including relations to other kinds.
+	Fields []*Field `yaml:"fields,omitempty"`
+	// Nested marks the leading segments of Name as parent kinds, so that "ticket/cron_job"
+	// is served as /tickets/:ticketID/cronjobs instead of /ticket/cronjobs.
+	Nested bool `yaml:"nested,omitempty"`
+	// Verbs lists the custom (non-CRUD) methods of the kind (e.g., "publish", "mark_read").
+	Verbs []string `yaml:"verbs,omitempty"`
+	// Pagination is the pagination mode of the List method (see known.PaginationOffset).
+	// Empty means offset pagination.
+	Pagination string `yaml:"pagination,omitempty"`
+	// SoftDelete makes Delete mark records as deleted instead of removing them, and adds the
+	// Restore and Purge methods to undelete and permanently remove records.
+	SoftDelete bool `yaml:"softDelete,omitempty"`
+	// ResourceVersion adds a version that is incremented by every update, so that concurrent
+	// updates of a record are detected instead of overwriting each other.
+	ResourceVersion bool `yaml:"resourceVersion,omitempty"`
+	// Cache puts a read-through cache in front of the store of the kind, using the cache
+	// backend of the web server.
+	Cache bool `yaml:"cache,omitempty"`
+	// Outbox publishes an event for every created, updated and deleted record of the kind,
+	// through the transactional outbox of the web server.
+	Outbox bool `yaml:"outbox,omitempty"`
+	// Datasource is the named datasource of the web server that stores the table of the kind.
+	// Empty means the table is stored by the primary database.
+	Datasource string `yaml:"datasource,omitempty"`
+	// Table is the existing table the kind was generated from by `create api --from-db`. Empty
+	// means the table is named after the kind.
+	Table string `yaml:"table,omitempty"`
+	// Proto is the existing proto file the kind was generated from by `create api --from-proto`,
+	// relative to the API directory. The file is not generated nor modified. Empty means the
+	// proto file is generated and named after the kind.
+	Proto string `yaml:"proto,omitempty"`
+}
+
+// Verb describes a custom method of a kind, served as POST /<kinds>/:id:<verb>.
+type Verb struct {
+	// Name is the verb in snake_case or lowerCamelCase (e.g., "mark_read").
+	Name string
+	// Builtin marks the Restore and Purge methods of soft deleted kinds, which are implemented
+	// by the generated code and whose requests only carry URI parameters.
+	Builtin bool
+}
+
+// Field describes a single field of a kind.
+//
+// A field spec is written as "name:type[:target]", for example:
+//   - "title:string"           a scalar column
+//   - "user:belongs_to"        a foreign key to the "user" kind (post belongs_to user)
+//   - "author:belongs_to:user" a foreign key named "author" to the "user" kind
+//   - "comments:has_many"      the inverse side of "comment belongs_to post"
+type Field struct {
+	// Name is the field name in snake_case or lowerCamelCase (e.g., "published_at").
+	Name string `yaml:"name"`
+	// Type is a scalar type (see known.AllFieldTypes) or a relation type (belongs_to, has_many).
+	Type string `yaml:"type"`
+	// Target is the kind path a relation points to. Defaults to the field name
+	// (singularized for has_many relations).
+	Target string `yaml:"target,omitempty"`
+	// Tag numbers the field in the proto messages of the kind. It is assigned when the field is
+	// recorded and never reused, so that adding fields keeps the numbers of the existing ones.
+	// has_many relations have no proto field and no tag.
+	Tag int `yaml:"tag,omitempty"`
+
+	// Ref holds the naming metadata of the relation target (not serialized).
+	Ref *REST `yaml:"-"`
+	// Inverse is the belongs_to field on the target kind that backs a has_many relation (not serialized).
+	Inverse *Field `yaml:"-"`
+}
+
+// ParseFieldSpec parses a comma separated list of "name:type[:target]" items.
+func ParseFieldSpec(spec string) ([]*Field, error) {
+	var fields []*Field
+	seen := map[string]bool{}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		parts := strings.Split(item, ":")
+		if len(parts) < 2 || len(parts) > 3 {
+			return nil, fmt.Errorf("invalid field %q: expected name:type[:target]", item)
+		}
+
+		f := &Field{
+			Name: strings.TrimSpace(parts[0]),
+			Type: strings.ToLower(strings.TrimSpace(parts[1])),
+		}
+		if len(parts) == 3 {
+			f.Target = strings.TrimSpace(parts[2])
+		}
+		if err := f.Validate(); err != nil {
+			return nil, err
+		}
+
+		if seen[f.GoName()] {
+			return nil, fmt.Errorf("duplicate field %q", f.Name)
+		}
+		seen[f.GoName()] = true
+		fields = append(fields, f)
+	}
+
+	return fields, nil
+}
+
+// ParseVerbs parses a comma separated list of custom verbs.
+func ParseVerbs(spec string) ([]string, error) {
+	var verbs []string
+	seen := map[string]bool{}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		v := &Verb{Name: item}
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+		if seen[v.GoName()] {
+			return nil, fmt.Errorf("duplicate verb %q", item)
+		}
+		seen[v.GoName()] = true
+		verbs = append(verbs, v.Name)
+	}
+
+	return verbs, nil
+}
+
+// Validate checks that the verb is a valid identifier and does not shadow a standard method.
+func (v *Verb) Validate() error {
+	if !isIdentifier(v.Name) {
+		return fmt.Errorf("invalid verb %q: only letters, digits and '_' are allowed and it must not start with a digit", v.Name)
+	}
+	if reserved := []string{"Create", "Update", "Delete", "DeleteCollection", "Get", "List"}; contains(reserved, v.GoName()) {
+		return fmt.Errorf("verb %q is reserved for the standard methods", v.Name)
+	}
+	return nil
+}
+
+// GoName returns the Go method name of the verb (e.g., "MarkRead").
+func (v *Verb) GoName() string {
+	return goName(v.Name)
+}
+
+// PathName returns the suffix of the custom method URL (e.g., "markRead" in "/posts/:postID:markRead").
+func (v *Verb) PathName() string {
+	return strutil.LowerFirst(v.GoName())
+}
+
+// Label returns a human readable form of the verb used in comments (e.g., "mark read").
+func (v *Verb) Label() string {
+	return strings.ReplaceAll(v.FileName(), "_", " ")
+}
+
+// FileName returns the verb in snake_case, used to name per-verb files (e.g., "mark_read").
+func (v *Verb) FileName() string {
+	return strutil.SnakeCase(v.Name)
+}
+
+// String returns the field as a "name:type[:target]" item of a field spec.
+func (f *Field) String() string {
+	if f.Target != "" {
+		return f.Name + ":" + f.Type + ":" + f.Target
+	}
+	return f.Name + ":" + f.Type
+}
+
+// Validate checks the field name, type and relation target.
+func (f *Field) Validate() error {
+	if f.Name == "" {
+		return fmt.Errorf("field name must not be empty")
+	}
+	if !isIdentifier(f.Name) {
+		return fmt.Errorf("invalid field name %q: only letters, digits and '_' are allowed and it must not start with a digit", f.Name)
+	}
+	if reserved := []string{"ID", "CreatedAt", "UpdatedAt"}; contains(reserved, f.GoName()) {
+		return fmt.Errorf("field %q is reserved and generated for every kind", f.Name)
+	}
+
+	if f.IsRelation() {
+		return nil
+	}
+	if f.Target != "" {
+		return fmt.Errorf("field %q: only relations may declare a target", f.Name)
+	}
+	if !known.IsValidFieldType(f.Type) {
+		return fmt.Errorf("field %q: unsupported type %q; supported: %s, %s, %s",
+			f.Name, f.Type, strings.Join(known.AllFieldTypes, ", "), known.RelationBelongsTo, known.RelationHasMany)
+	}
+	return nil
+}
+
+// IsRelation reports whether the field declares a relation to another kind.
+func (f *Field) IsRelation() bool {
+	return f.Type == known.RelationBelongsTo || f.Type == known.RelationHasMany
+}
+
+// IsBelongsTo reports whether the field is a belongs_to relation.
+func (f *Field) IsBelongsTo() bool {
+	return f.Type == known.RelationBelongsTo
+}
+
+// IsHasMany reports whether the field is a has_many relation.
+func (f *Field) IsHasMany() bool {
+	return f.Type == known.RelationHasMany
+}
+
+// TargetKind returns the kind path the relation points to.
+func (f *Field) TargetKind() string {
+	if f.Target != "" {
+		return f.Target
+	}
+	if f.IsHasMany() {
+		return strutil.SnakeCase(singularize(f.Name))
+	}
+	return strutil.SnakeCase(f.Name)
+}
+
+// GoName returns the Go struct field name (e.g., "PublishedAt").
+func (f *Field) GoName() string {
+	return goName(f.Name)
+}
+
+// Label returns a human readable form of the field name used in comments (e.g., "published at").
+func (f *Field) Label() string {
+	return strings.ReplaceAll(f.Column(), "_", " ")
+}
+
+// JSONName returns the lowerCamelCase name used by proto and JSON (e.g., "publishedAt").
+func (f *Field) JSONName() string {
+	return strutil.LowerFirst(f.GoName())
+}
+
+// Column returns the database column name (e.g., "published_at").
+func (f *Field) Column() string {
+	return strutil.SnakeCase(f.Name)
+}
+
+// ForeignKey returns the Go name of the foreign key field of a belongs_to relation (e.g., "UserID").
+func (f *Field) ForeignKey() string {
+	return f.GoName() + "ID"
+}
+
+// ForeignKeyJSON returns the lowerCamelCase name of the foreign key field (e.g., "userID").
+func (f *Field) ForeignKeyJSON() string {
+	return f.JSONName() + "ID"
+}
+
+// ForeignKeyColumn returns the foreign key column of a belongs_to relation (e.g., "user_id").
+func (f *Field) ForeignKeyColumn() string {
+	return f.Column() + "_id"
+}
+
+// GoType returns the Go type used in the GORM model.
+func (f *Field) GoType() string {
+	switch f.Type {
+	case known.FieldTypeText:
+		return "string"
+	case known.FieldTypeInt:
+		return "int64"
+	case known.FieldTypeFloat:
+		return "float32"
+	case known.FieldTypeDouble:
+		return "float64"
+	case known.FieldTypeTime:
+		return "time.Time"
+	case known.FieldTypeBytes:
+		return "[]byte"
+	default:
+		return f.Type
+	}
+}
+
+// ProtoType returns the protobuf type used in the API messages.
+func (f *Field) ProtoType() string {
+	switch f.Type {
+	case known.FieldTypeText:
+		return "string"
+	case known.FieldTypeInt:
+		return "int64"
+	case known.FieldTypeTime:
+		return "google.protobuf.Timestamp"
+	default:
+		return f.Type
+	}
+}
+
+// graphQLScalar describes how a protobuf scalar type is exposed by the GraphQL endpoint.
+type graphQLScalar struct {
+	// Name is the GraphQL type (e.g., "Int64").
+	Name string
+	// GoType is the Go type of the GraphQL values in the resolvers (e.g., "Int64").
+	GoType string
+	// FromProto formats the conversion of a protobuf value to GoType.
+	FromProto string
+	// ToProto formats the conversion of a GoType value to the protobuf type.
+	ToProto string
+	// ToProtoList formats the conversion of a list of GoType values to a repeated protobuf field.
+	ToProtoList string
+}
+
+// graphQLScalars maps the protobuf types of the fields and filters to GraphQL types. Int is 32
+// bits wide in GraphQL, so the wider integers are exposed through the custom Int64 scalar.
+// "ID" stands for the identifiers of resources, which are strings in the API messages.
+var graphQLScalars = map[string]graphQLScalar{
+	"string":                    {"String", "string", "%s", "%s", "%s"},
+	"bool":                      {"Boolean", "bool", "%s", "%s", "%s"},
+	"int32":                     {"Int", "int32", "%s", "%s", "%s"},
+	"int64":                     {"Int64", "Int64", "Int64(%s)", "int64(%s)", "toIntegers[int64](%s)"},
+	"uint32":                    {"Int64", "Int64", "Int64(%s)", "uint32(%s)", "toIntegers[uint32](%s)"},
+	"uint64":                    {"Int64", "Int64", "Int64(%s)", "uint64(%s)", "toIntegers[uint64](%s)"},
+	"float":                     {"Float", "float64", "float64(%s)", "float32(%s)", "%s"},
+	"double":                    {"Float", "float64", "%s", "%s", "%s"},
+	"bytes":                     {"Bytes", "Bytes", "Bytes(%s)", "[]byte(%s)", "%s"},
+	"google.protobuf.Timestamp": {"Time", "graphql.Time", "timeOf(%s)", "timestamppb.New(%s.Time)", "%s"},
+	"ID":                        {"ID", "graphql.ID", "graphql.ID(%s)", "string(%s)", "toStrings(%s)"},
+}
+
+// GraphQLType returns the GraphQL type of the field (e.g., "Int64").
+func (f *Field) GraphQLType() string {
+	return graphQLScalars[f.ProtoType()].Name
+}
+
+// GraphQLGoType returns the Go type of the field in the GraphQL resolvers (e.g., "graphql.Time").
+func (f *Field) GraphQLGoType() string {
+	return graphQLScalars[f.ProtoType()].GoType
+}
+
+// GraphQLValue returns the Go expression that converts expr, a value of the field in the API
+// messages, to its GraphQL value. Timestamps are converted to nil if they are not set.
+func (f *Field) GraphQLValue(expr string) string {
+	return fmt.Sprintf(graphQLScalars[f.ProtoType()].FromProto, expr)
+}
+
+// ProtoValue returns the Go expression that converts expr, a GraphQL value of the field, to its
+// value in the API messages.
+func (f *Field) ProtoValue(expr string) string {
+	return fmt.Sprintf(graphQLScalars[f.ProtoType()].ToProto, expr)
+}
+
+// GORMType returns the explicit GORM column type, or an empty string to let GORM decide.
+func (f *Field) GORMType() string {
+	if f.Type == known.FieldTypeText {
+		return "text"
+	}
+	return ""
+}
+
+// IsTime reports whether the field is a timestamp.
+func (f *Field) IsTime() bool {
+	return f.Type == known.FieldTypeTime
+}
+
+// RefKey returns the Go name of the resource ID field on the relation target (e.g., "UserID").
+func (f *Field) RefKey() string {
+	return f.Ref.Last.SingularName + "ID"
+}
+
+// RefColumn returns the resource ID column on the relation target (e.g., "user_id").
+func (f *Field) RefColumn() string {
+	return f.Ref.Last.SingularLower + "_id"
+}
+
+// FieldSpec returns the fields of the kind as a field spec that ParseFieldSpec accepts
+// (e.g., "title:string,author:belongs_to:user").
+func (k *Kind) FieldSpec() string {
+	items := make([]string, 0, len(k.Fields))
+	for _, f := range k.Fields {
+		items = append(items, f.String())
+	}
+	return strings.Join(items, ",")
+}
+
+// FindField returns the field with the given name, if any.
+func (k *Kind) FindField(name string) (*Field, bool) {
+	for _, f := range k.Fields {
+		if f.GoName() == goName(name) {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+// MergeFields adds new fields to the kind, replacing fields with the same name. A replaced
+// field keeps its tag, and new fields are tagged after the existing ones.
+func (k *Kind) MergeFields(fields []*Field) {
+	for _, f := range fields {
+		if old, ok := k.FindField(f.Name); ok {
+			tag := old.Tag
+			*old = *f
+			old.Tag = tag
+			continue
+		}
+		k.Fields = append(k.Fields, f)
+	}
+	k.AssignTags()
+}
+
+// AssignTags tags the fields that have no tag yet, in order, after the highest tag of the kind.
+// Kinds recorded before fields were tagged get the tags of their declaration order.
+func (k *Kind) AssignTags() {
+	next := 1
+	for _, f := range k.Fields {
+		next = max(next, f.Tag+1)
+	}
+	for _, f := range k.Fields {
+		if f.Tag == 0 && !f.IsHasMany() {
+			f.Tag = next
+			next++
+		}
+	}
+}
+
+// ListTag returns the number of the i-th List filter of the field, relative to the first filter
+// of the List request. Each field reserves listFilterSlots numbers for its filters.
+func (f *Field) ListTag(i int) int {
+	return (f.Tag-1)*listFilterSlots + i + 1
+}
+
+// FindVerb returns the recorded verb that has the same Go name as name, if any.
+func (k *Kind) FindVerb(name string) (string, bool) {
+	for _, v := range k.Verbs {
+		if goName(v) == goName(name) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// MergeVerbs adds the verbs that are not recorded yet, keeping the existing order.
+func (k *Kind) MergeVerbs(verbs []string) {
+	for _, v := range verbs {
+		if _, ok := k.FindVerb(v); !ok {
+			k.Verbs = append(k.Verbs, v)
+		}
+	}
+}
+
+// goName converts a snake_case or lowerCamelCase name into an exported Go identifier,
+// keeping the common "ID" initialism upper case.
+func goName(name string) string {
+	n := strutil.UpperFirst(strutil.CamelCase(name))
+	if strings.HasSuffix(n, "Id") {
+		n = strings.TrimSuffix(n, "Id") + "ID"
+	}
+	return n
+}
+
+func singularize(name string) string {
+	return flect.Singularize(filepath.Base(name))
+}
+
+// isIdentifier reports whether name only contains letters, digits and '_' and does not start with a digit.
+func isIdentifier(name string) bool {
+	if name == "" || name[0] >= '0' && name[0] <= '9' {
+		return false
+	}
+	for _, r := range name {
+		if !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
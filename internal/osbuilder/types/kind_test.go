@@ -0,0 +1,187 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFieldSpec(t *testing.T) {
+	fields, err := ParseFieldSpec("title:string, published_at:time,author:belongs_to:user,comments:has_many")
+	require.NoError(t, err)
+	require.Len(t, fields, 4)
+
+	assert.Equal(t, "Title", fields[0].GoName())
+	assert.Equal(t, "string", fields[0].ProtoType())
+
+	assert.Equal(t, "PublishedAt", fields[1].GoName())
+	assert.Equal(t, "publishedAt", fields[1].JSONName())
+	assert.Equal(t, "published_at", fields[1].Column())
+	assert.Equal(t, "time.Time", fields[1].GoType())
+	assert.Equal(t, "google.protobuf.Timestamp", fields[1].ProtoType())
+
+	assert.True(t, fields[2].IsBelongsTo())
+	assert.Equal(t, "user", fields[2].TargetKind())
+	assert.Equal(t, "AuthorID", fields[2].ForeignKey())
+	assert.Equal(t, "authorID", fields[2].ForeignKeyJSON())
+	assert.Equal(t, "author_id", fields[2].ForeignKeyColumn())
+
+	assert.True(t, fields[3].IsHasMany())
+	assert.Equal(t, "comment", fields[3].TargetKind())
+}
+
+func TestHasManyTargetKind(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "comments", want: "comment"},
+		{name: "categories", want: "category"},
+		{name: "houses", want: "house"},
+		{name: "addresses", want: "address"},
+		{name: "people", want: "person"},
+		{name: "cron_jobs", want: "cron_job"},
+		{name: "cronJobs", want: "cron_job"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &Field{Name: tt.name, Type: "has_many"}
+			assert.Equal(t, tt.want, f.TargetKind())
+		})
+	}
+}
+
+func TestKindFieldSpec(t *testing.T) {
+	spec := "title:string,published_at:time,author:belongs_to:user,comments:has_many"
+	fields, err := ParseFieldSpec(spec)
+	require.NoError(t, err)
+
+	assert.Equal(t, "author:belongs_to:user", fields[2].String())
+	assert.Equal(t, "comments:has_many", fields[3].String())
+	assert.Equal(t, spec, (&Kind{Fields: fields}).FieldSpec())
+	assert.Empty(t, (&Kind{}).FieldSpec())
+}
+
+func TestKindTags(t *testing.T) {
+	k := &Kind{Name: "post"}
+	k.MergeFields([]*Field{{Name: "title", Type: "string"}, {Name: "comments", Type: "has_many"}, {Name: "user", Type: "belongs_to"}})
+	k.MergeFields([]*Field{{Name: "title", Type: "text"}, {Name: "views", Type: "int"}})
+
+	tags := map[string]int{}
+	for _, f := range k.Fields {
+		tags[f.Name] = f.Tag
+	}
+	// Replaced fields keep their tag, new fields are appended and has_many relations are not tagged.
+	assert.Equal(t, map[string]int{"title": 1, "comments": 0, "user": 2, "views": 3}, tags)
+	assert.Equal(t, 9, k.Fields[3].ListTag(0))
+
+	// Kinds recorded before fields were tagged are tagged in declaration order.
+	legacy := &Kind{Fields: []*Field{{Name: "body", Type: "text"}, {Name: "title", Type: "string", Tag: 2}}}
+	legacy.AssignTags()
+	assert.Equal(t, 3, legacy.Fields[0].Tag)
+}
+
+func TestParseFieldSpec_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+	}{
+		{name: "MissingType", spec: "title"},
+		{name: "TooManyParts", spec: "a:belongs_to:user:extra"},
+		{name: "UnknownType", spec: "title:varchar"},
+		{name: "InvalidName", spec: "ti-tle:string"},
+		{name: "LeadingDigit", spec: "1title:string"},
+		{name: "Reserved", spec: "created_at:time"},
+		{name: "ScalarWithTarget", spec: "title:string:user"},
+		{name: "Duplicate", spec: "title:string,title:text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFieldSpec(tt.spec)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseVerbs(t *testing.T) {
+	verbs, err := ParseVerbs("publish, mark_read")
+	require.NoError(t, err)
+	require.Equal(t, []string{"publish", "mark_read"}, verbs)
+
+	v := &Verb{Name: verbs[1]}
+	assert.Equal(t, "MarkRead", v.GoName())
+	assert.Equal(t, "markRead", v.PathName())
+	assert.Equal(t, "mark_read", v.FileName())
+
+	for _, spec := range []string{"get", "delete_collection", "mark-read", "publish,publish"} {
+		_, err := ParseVerbs(spec)
+		assert.Error(t, err, spec)
+	}
+
+	k := &Kind{Name: "post", Verbs: []string{"publish"}}
+	k.MergeVerbs([]string{"archive", "publish"})
+	assert.Equal(t, []string{"publish", "archive"}, k.Verbs)
+}
+
+func TestCustomMethods(t *testing.T) {
+	r := &REST{Spec: &Kind{Name: "post", SoftDelete: true}, Verbs: []*Verb{{Name: "publish"}}}
+	var names []string
+	for _, m := range r.CustomMethods() {
+		names = append(names, m.GoName())
+	}
+	assert.Equal(t, []string{"Restore", "Purge", "Publish"}, names)
+
+	r.Spec.SoftDelete = false
+	assert.Len(t, r.CustomMethods(), 1)
+}
+
+func TestOutboxEvents(t *testing.T) {
+	r := &REST{Spec: &Kind{Name: "cron_job", Outbox: true}, SingularName: "CronJob", SingularLower: "cronjob"}
+	assert.True(t, r.HasOutbox())
+	assert.Equal(t, "CRON_JOB", r.EventTypePrefix())
+	assert.Equal(t, "cronjob.mq.proto", r.EventProtoFile())
+
+	ws := &WebServer{BinaryName: "mb-apiserver", Outbox: "kafka"}
+	assert.Equal(t, "mb_outbox", ws.OutboxTable())
+
+	// Binary names with several dashes are prefixed by their first segment, like the kinds.
+	ws.BinaryName = "mb-order-apiserver"
+	assert.Equal(t, "mb_outbox", ws.OutboxTable())
+	assert.Equal(t, "mb", ProjectPrefix(ws.BinaryName))
+}
+
+func TestUpdatePaths(t *testing.T) {
+	fields, err := ParseFieldSpec("title:string,published_at:time,author:belongs_to:user")
+	require.NoError(t, err)
+	r := &REST{Fields: fields[:2], BelongsTo: fields[2:]}
+
+	var paths []string
+	for _, p := range r.UpdatePaths() {
+		paths = append(paths, p.Name+"="+p.Column)
+	}
+	assert.Equal(t, []string{"title=title", "publishedAt=published_at", "authorID=author_id"}, paths)
+}
+
+func TestGraphQLTypes(t *testing.T) {
+	fields, err := ParseFieldSpec("title:string,views:int,score:float,published_at:time,data:bytes,author:belongs_to:user")
+	require.NoError(t, err)
+	r := &REST{Fields: fields[:5], BelongsTo: fields[5:]}
+
+	var types []string
+	for _, f := range r.Fields {
+		types = append(types, f.GraphQLType())
+	}
+	assert.Equal(t, []string{"String", "Int64", "Float", "Time", "Bytes"}, types)
+	assert.Equal(t, "float32(in.Score)", fields[2].ProtoValue("in.Score"))
+
+	filters := map[string]string{}
+	for _, lf := range r.ListFilters() {
+		filters[lf.Name] = lf.GraphQLType() + " " + lf.ProtoValue("v")
+	}
+	assert.Equal(t, "[String!] v", filters["titleIn"])
+	assert.Equal(t, "[Int64!] toIntegers[int64](v)", filters["viewsIn"])
+	assert.Equal(t, "String v", filters["publishedAtAfter"])
+	assert.Equal(t, "[ID!] toStrings(v)", filters["authorIDIn"])
+}
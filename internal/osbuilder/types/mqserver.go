@@ -17,6 +17,9 @@ type MQServer struct {
 	WithPreloader   bool     `yaml:"withPreloader,omitempty"`
 	ServiceRegistry string   `yaml:"serviceRegistry,omitempty"`
 	Clients         []string `yaml:"clients,omitempty"`
+	// Broker selects the message broker the server consumes from (see known.BrokerKafka). It
+	// defaults to the broker of the web servers of the project.
+	Broker string `yaml:"broker,omitempty"`
 	// Computed/derived fields (not serialized).
 	Proj              *Project `yaml:"-"`
 	Name              string   `yaml:"-"`
@@ -26,3 +29,28 @@ type MQServer struct {
 	R               *REST  `yaml:"-"`
 	TypedClientName string `yaml:"-"`
 }
+
+// Complete populates derived fields and defaults the broker to the broker of the first web server
+// that has one.
+func (ms *MQServer) Complete(proj *Project) *MQServer {
+	ms.Proj = proj
+	ms.Name = GetComponentName(ms.BinaryName)
+	for _, ws := range proj.WebServers {
+		if ms.Broker != "" {
+			break
+		}
+		ms.Broker = ws.Broker
+	}
+	return ms
+}
+
+// BrokerPairs returns the files of the mq package that the server consumes from its broker with.
+func (ms *MQServer) BrokerPairs() map[string]string {
+	return brokerPairs(ms.Proj, ms.Broker)
+}
+
+// WebServer returns the server as a web server with the same binary name and broker, which the
+// templates of the mq package shared with web servers are rendered with.
+func (ms *MQServer) WebServer() *WebServer {
+	return (&WebServer{BinaryName: ms.BinaryName, Broker: ms.Broker}).Complete(ms.Proj)
+}
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMQServerBrokerPairs(t *testing.T) {
+	proj := &Project{
+		D: &GeneratedData{},
+		WebServers: []*WebServer{
+			{BinaryName: "mb-apiserver", WebFramework: "gin"},
+			{BinaryName: "mb-billing", WebFramework: "gin", Outbox: "kafka"},
+		},
+		MQServers: []*MQServer{
+			{BinaryName: "mb-consumer"},
+			{BinaryName: "mb-notifier", Broker: "nats"},
+		},
+	}
+	for _, ws := range proj.WebServers {
+		ws.Complete(proj)
+	}
+	for _, ms := range proj.MQServers {
+		ms.Complete(proj)
+	}
+
+	// The broker defaults to the broker of the web servers, and is kept when it is set.
+	assert.Equal(t, "kafka", proj.MQServers[0].Broker)
+	assert.Equal(t, "nats", proj.MQServers[1].Broker)
+
+	kafka := proj.MQServers[0].BrokerPairs()
+	assert.Equal(t, proj.WebServers[1].BrokerPairs(), kafka)
+	assert.Equal(t, "/project/internal/pkg/mq/kafka_partition.go", kafka["internal/pkg/mq/kafka_partition.go"])
+	assert.Equal(t, "/project/internal/pkg/mq/nats.go", proj.MQServers[1].BrokerPairs()["internal/pkg/mq/nats.go"])
+
+	// The templates of the mq package render the server as a web server.
+	ws := proj.MQServers[1].WebServer()
+	assert.Equal(t, "mb-notifier", ws.BinaryName)
+	assert.Equal(t, "notifier", ws.Name)
+	assert.Equal(t, "nats", ws.Broker)
+}
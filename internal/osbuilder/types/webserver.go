@@ -3,6 +3,7 @@ package types
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/duke-git/lancet/v2/strutil"
@@ -62,6 +63,350 @@ type REST struct {
 	// ResourcePathPrefix is the URL or logical prefix used when exposing this resource via the REST API
 	// (e.g., "cronjobs" for "/api/v1/cronjobs").
 	ResourcePathPrefix string
+
+	// Spec is the recorded field spec of the kind, nil if the kind has no fields.
+	Spec *Kind
+	// Fields holds the scalar fields of the kind.
+	Fields []*Field
+	// BelongsTo holds the belongs_to relations of the kind (foreign keys owned by this kind).
+	BelongsTo []*Field
+	// HasMany holds the has_many relations of the kind (foreign keys owned by the target kind).
+	HasMany []*Field
+	// Parents holds the parent kinds of a nested kind, outermost first
+	// (e.g., "ticket" for "ticket/cron_job"). It is empty for top-level kinds.
+	Parents []*REST
+	// Verbs holds the custom methods of the kind.
+	Verbs []*Verb
+	// Verb is the custom method being rendered by the per-verb templates.
+	Verb *Verb
+}
+
+// IDField returns the Go name of the resource ID field (e.g., "CronJobID").
+func (r *REST) IDField() string {
+	return r.Last.SingularName + "ID"
+}
+
+// IDJSON returns the lowerCamelCase name of the resource ID field (e.g., "cronJobID").
+func (r *REST) IDJSON() string {
+	return r.Last.SingularLowerFirst + "ID"
+}
+
+// IDColumn returns the database column of the resource ID (e.g., "cronjob_id").
+func (r *REST) IDColumn() string {
+	return r.Last.SingularLower + "_id"
+}
+
+// IsNested reports whether the kind is served under its parent kinds.
+func (r *REST) IsNested() bool {
+	return len(r.Parents) > 0
+}
+
+// Parent returns the immediate parent of a nested kind, or nil for top-level kinds.
+func (r *REST) Parent() *REST {
+	if len(r.Parents) == 0 {
+		return nil
+	}
+	return r.Parents[len(r.Parents)-1]
+}
+
+// RoutePath returns the gin route of the resource collection
+// (e.g., "/tickets/:ticketID/cronjobs" for a nested kind, "/ticket/cronjobs" otherwise).
+func (r *REST) RoutePath() string {
+	return r.collectionPath(func(p *REST) string { return ":" + p.IDJSON() })
+}
+
+// HTTPRulePath returns the google.api.http path template of the resource collection
+// (e.g., "/v1/tickets/{ticketID}/cronjobs").
+func (r *REST) HTTPRulePath(apiVersion string) string {
+	return "/" + apiVersion + r.MuxPath()
+}
+
+// MuxPath returns the net/http ServeMux pattern path of the resource collection
+// (e.g., "/tickets/{ticketID}/cronjobs").
+func (r *REST) MuxPath() string {
+	return r.collectionPath(func(p *REST) string { return p.IDWildcard() })
+}
+
+// IDWildcard returns the ServeMux wildcard of the resource ID (e.g., "{cronjobID}").
+func (r *REST) IDWildcard() string {
+	return "{" + r.IDJSON() + "}"
+}
+
+func (r *REST) collectionPath(param func(p *REST) string) string {
+	var b strings.Builder
+	if !r.IsNested() && r.ResourcePathPrefix != "" {
+		b.WriteString("/" + r.ResourcePathPrefix)
+	}
+	for _, p := range r.Parents {
+		b.WriteString("/" + p.Last.PluralLower + "/" + param(p))
+	}
+	b.WriteString("/" + r.Last.PluralLower)
+	return b.String()
+}
+
+// ListFilter describes a typed filter field of a List request, generated from the field spec.
+type ListFilter struct {
+	// Name is the lowerCamelCase name of the request field (e.g., "titlePrefix").
+	Name string
+	// Column is the database column the filter applies to (e.g., "title").
+	Column string
+	// Op is the filter operator (see known.ListFilterEqual and friends).
+	Op string
+	// Type is the protobuf scalar type of the filter value.
+	Type string
+	// ForeignKey reports whether the filter applies to the foreign key of a belongs_to relation.
+	ForeignKey bool
+	// Tag numbers the filter in the List request, relative to the first filter (see Field.ListTag).
+	Tag int
+}
+
+// listFilterSlots is the number of List request fields reserved for the filters of each field, so
+// that the filters of a new field never renumber the filters of the existing ones.
+const listFilterSlots = 4
+
+// GoName returns the Go name of the generated request field (e.g., "TitlePrefix").
+func (lf *ListFilter) GoName() string {
+	return strutil.UpperFirst(lf.Name)
+}
+
+// Label returns a human readable form of the filtered column used in comments (e.g., "published at").
+func (lf *ListFilter) Label() string {
+	return strings.ReplaceAll(lf.Column, "_", " ")
+}
+
+// IsRepeated reports whether the filter takes a list of values.
+func (lf *ListFilter) IsRepeated() bool {
+	return lf.Op == known.ListFilterIn
+}
+
+// IsTimeRange reports whether the filter bounds a timestamp. Bounds are RFC 3339 strings so that
+// they can be bound from query parameters.
+func (lf *ListFilter) IsTimeRange() bool {
+	return lf.Op == known.ListFilterAfter || lf.Op == known.ListFilterBefore
+}
+
+// graphQLScalar returns the GraphQL form of the filter value. Foreign keys are resource IDs.
+func (lf *ListFilter) graphQLScalar() graphQLScalar {
+	if lf.ForeignKey {
+		return graphQLScalars["ID"]
+	}
+	return graphQLScalars[lf.Type]
+}
+
+// GraphQLType returns the GraphQL type of the filter in the filter input of the kind
+// (e.g., "[Int64!]" for an "in" filter).
+func (lf *ListFilter) GraphQLType() string {
+	if lf.IsRepeated() {
+		return "[" + lf.graphQLScalar().Name + "!]"
+	}
+	return lf.graphQLScalar().Name
+}
+
+// GraphQLGoType returns the Go type of the filter in the GraphQL resolvers. Filters are optional,
+// so they are pointers.
+func (lf *ListFilter) GraphQLGoType() string {
+	if lf.IsRepeated() {
+		return "*[]" + lf.graphQLScalar().GoType
+	}
+	return "*" + lf.graphQLScalar().GoType
+}
+
+// ProtoValue returns the Go expression that converts expr, the dereferenced GraphQL value of the
+// filter, to the value of the request field.
+func (lf *ListFilter) ProtoValue(expr string) string {
+	if lf.IsRepeated() {
+		return fmt.Sprintf(lf.graphQLScalar().ToProtoList, expr)
+	}
+	return fmt.Sprintf(lf.graphQLScalar().ToProto, expr)
+}
+
+// SortKey maps a field that List requests may order by to its column. It also describes the
+// fields of update masks (see UpdatePaths).
+type SortKey struct {
+	// Name is the lowerCamelCase field name accepted in orderBy (e.g., "createdAt").
+	Name string
+	// Column is the database column to order by (e.g., "created_at").
+	Column string
+}
+
+// ListFilters returns the typed filters of the List request:
+//   - string: equality, in and prefix match
+//   - text: prefix match
+//   - bool: equality
+//   - integers and belongs_to foreign keys: equality and in
+//   - time: a [after, before) range
+//   - float and double: a [min, max] range
+//
+// The equality filter of a belongs_to foreign key predates the typed filters and is not included.
+func (r *REST) ListFilters() []*ListFilter {
+	var filters []*ListFilter
+	for _, f := range r.Fields {
+		first := len(filters)
+		add := func(name, op, typ string) {
+			filters = append(filters, &ListFilter{Name: name, Column: f.Column(), Op: op, Type: typ, Tag: f.ListTag(len(filters) - first)})
+		}
+		name := f.JSONName()
+		switch f.Type {
+		case known.FieldTypeString:
+			add(name, known.ListFilterEqual, "string")
+			add(name+"In", known.ListFilterIn, "string")
+			add(name+"Prefix", known.ListFilterPrefix, "string")
+		case known.FieldTypeText:
+			add(name+"Prefix", known.ListFilterPrefix, "string")
+		case known.FieldTypeBool:
+			add(name, known.ListFilterEqual, "bool")
+		case known.FieldTypeInt, known.FieldTypeInt32, known.FieldTypeInt64, known.FieldTypeUint32, known.FieldTypeUint64:
+			add(name, known.ListFilterEqual, f.ProtoType())
+			add(name+"In", known.ListFilterIn, f.ProtoType())
+		case known.FieldTypeTime:
+			add(name+"After", known.ListFilterAfter, "string")
+			add(name+"Before", known.ListFilterBefore, "string")
+		case known.FieldTypeFloat, known.FieldTypeDouble:
+			add(name+"Min", known.ListFilterMin, f.ProtoType())
+			add(name+"Max", known.ListFilterMax, f.ProtoType())
+		}
+	}
+	for _, f := range r.BelongsTo {
+		// The first slot of a belongs_to field is taken by its equality filter.
+		filters = append(filters, &ListFilter{
+			Name: f.ForeignKeyJSON() + "In", Column: f.ForeignKeyColumn(), Op: known.ListFilterIn, Type: "string",
+			ForeignKey: true, Tag: f.ListTag(1),
+		})
+	}
+	return filters
+}
+
+// SortKeys returns the allow-list of fields that List requests may order by.
+func (r *REST) SortKeys() []*SortKey {
+	keys := []*SortKey{{Name: "createdAt", Column: "created_at"}, {Name: "updatedAt", Column: "updated_at"}}
+	for _, f := range r.Fields {
+		if f.Type != known.FieldTypeBytes {
+			keys = append(keys, &SortKey{Name: f.JSONName(), Column: f.Column()})
+		}
+	}
+	for _, f := range r.BelongsTo {
+		keys = append(keys, &SortKey{Name: f.ForeignKeyJSON(), Column: f.ForeignKeyColumn()})
+	}
+	return keys
+}
+
+// UpdatePaths returns the fields that the update mask of an Update request may list, with their
+// columns. Unlike SortKeys, they exclude the timestamps maintained by the database.
+func (r *REST) UpdatePaths() []*SortKey {
+	var paths []*SortKey
+	for _, f := range r.Fields {
+		paths = append(paths, &SortKey{Name: f.JSONName(), Column: f.Column()})
+	}
+	for _, f := range r.BelongsTo {
+		paths = append(paths, &SortKey{Name: f.ForeignKeyJSON(), Column: f.ForeignKeyColumn()})
+	}
+	return paths
+}
+
+// IsCursorPaginated reports whether the List method pages with page tokens instead of offsets.
+func (r *REST) IsCursorPaginated() bool {
+	return r.Spec != nil && r.Spec.Pagination == known.PaginationCursor
+}
+
+// Table returns the existing table the kind was generated from, or an empty string if the table
+// is named after the kind.
+func (r *REST) Table() string {
+	if r.Spec == nil {
+		return ""
+	}
+	return r.Spec.Table
+}
+
+// ProtoFile returns the proto file that defines the messages of the kind, relative to the API
+// directory (e.g., "post.proto").
+func (r *REST) ProtoFile() string {
+	if r.Spec != nil && r.Spec.Proto != "" {
+		return r.Spec.Proto
+	}
+	return r.SingularLower + ".proto"
+}
+
+// IsProtoFirst reports whether the kind was generated from an existing proto file, which must
+// not be modified.
+func (r *REST) IsProtoFirst() bool {
+	return r.Spec != nil && r.Spec.Proto != ""
+}
+
+// IsSoftDelete reports whether deleted records are kept and marked by a deleted_at column.
+func (r *REST) IsSoftDelete() bool {
+	return r.Spec != nil && r.Spec.SoftDelete
+}
+
+// IsCached reports whether Get requests of the kind are served through a read-through cache.
+func (r *REST) IsCached() bool {
+	return r.Spec != nil && r.Spec.Cache
+}
+
+// HasOutbox reports whether the writes of the kind publish events through the transactional outbox.
+func (r *REST) HasOutbox() bool {
+	return r.Spec != nil && r.Spec.Outbox
+}
+
+// EventProtoFile returns the proto file that defines the events of the kind, relative to the API
+// directory (e.g., "post.mq.proto"). It is named after the kind even if the kind was generated
+// from an existing proto file, which may define several kinds.
+func (r *REST) EventProtoFile() string {
+	return r.SingularLower + ".mq.proto"
+}
+
+// EventTypePrefix returns the prefix of the values of the event type enum of the kind
+// (e.g., "CRON_JOB" in "CRON_JOB_EVENT_TYPE_CREATED"), which are scoped by the proto package.
+func (r *REST) EventTypePrefix() string {
+	return strutil.UpperSnakeCase(r.SingularName)
+}
+
+// Datasource returns the named datasource that stores the table of the kind, or an empty
+// string for the primary database.
+func (r *REST) Datasource() string {
+	if r.Spec == nil {
+		return ""
+	}
+	return r.Spec.Datasource
+}
+
+// HasResourceVersion reports whether updates of the kind are conditional on a resource version.
+func (r *REST) HasResourceVersion() bool {
+	return r.Spec != nil && r.Spec.ResourceVersion
+}
+
+// CustomMethods returns the non-CRUD methods of the kind: the Restore and Purge methods of a
+// soft deleted kind, followed by its verbs.
+func (r *REST) CustomMethods() []*Verb {
+	var methods []*Verb
+	if r.IsSoftDelete() {
+		methods = append(methods, &Verb{Name: "restore", Builtin: true}, &Verb{Name: "purge", Builtin: true})
+	}
+	return append(methods, r.Verbs...)
+}
+
+// HasTimeRangeFilter reports whether the List request has a timestamp range filter.
+func (r *REST) HasTimeRangeFilter() bool {
+	for _, lf := range r.ListFilters() {
+		if lf.IsTimeRange() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTimeField reports whether any scalar field is a timestamp.
+func (r *REST) HasTimeField() bool {
+	for _, f := range r.Fields {
+		if f.IsTime() {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRelations reports whether the kind declares any relation.
+func (r *REST) HasRelations() bool {
+	return len(r.BelongsTo) > 0 || len(r.HasMany) > 0
 }
 
 // WebServer describes a web server component to generate (HTTP/gRPC/etc).
@@ -75,21 +420,49 @@ type WebServer struct {
 	// StorageType selects backing storage (e.g., memory, mysql).
 	StorageType string `yaml:"storageType"`
 	// Feature flags
-	WithHealthz     bool     `yaml:"withHealthz,omitempty"`
-	WithUser        bool     `yaml:"withUser,omitempty"`
-	WithOTel        bool     `yaml:"withOTel,omitempty"`
-	WithWS          bool     `yaml:"withWS,omitempty"`
-	WithPreloader   bool     `yaml:"withPreloader,omitempty"`
-	ServiceRegistry string   `yaml:"serviceRegistry,omitempty"`
-	Clients         []string `yaml:"clients,omitempty"`
+	WithHealthz     bool   `yaml:"withHealthz,omitempty"`
+	WithUser        bool   `yaml:"withUser,omitempty"`
+	WithOTel        bool   `yaml:"withOTel,omitempty"`
+	WithWS          bool   `yaml:"withWS,omitempty"`
+	WithPreloader   bool   `yaml:"withPreloader,omitempty"`
+	WithGraphQL     bool   `yaml:"withGraphQL,omitempty"`
+	ServiceRegistry string `yaml:"serviceRegistry,omitempty"`
+	// Clients declares the typed clients of the clientset of the server (see Client).
+	Clients []*Client `yaml:"clients,omitempty"`
+	// DependsOn lists the binary names of the web servers of the project that the server calls.
+	// A typed client of each of them is added to the clientset of the server (see Dependencies).
+	DependsOn []string `yaml:"dependsOn,omitempty"`
+	// Cache selects the backend of the read-through cache of the kinds created with
+	// `create api --cache` (see known.CacheLRU). Empty means the stores are not cached.
+	Cache string `yaml:"cache,omitempty"`
+	// Broker selects the message broker of the MQ layer of the server (see known.BrokerKafka):
+	// the internal/pkg/mq producer and consumer, and the mq block of the configuration. Empty
+	// means the server has no MQ layer, unless Outbox is set.
+	Broker string `yaml:"broker,omitempty"`
+	// Outbox selects the broker that the transactional outbox publishes the events of the kinds
+	// created with `create api --outbox` to. It must be the broker of the server, which defaults
+	// to it. Empty means no events are published.
+	Outbox string `yaml:"outbox,omitempty"`
+	// ReadReplicas sends the queries of the stores to the read replicas of the database, which
+	// are listed in the configuration file of the server.
+	ReadReplicas bool `yaml:"readReplicas,omitempty"`
+	// Datasources names the databases besides the primary one that the kinds created with
+	// `create api --datasource` are stored in (e.g., "analytics"). Each datasource has the
+	// storage type of the web server and may have read replicas.
+	Datasources []string `yaml:"datasources,omitempty"`
+	// Kinds records the REST resources generated by `create api` together with their field specs.
+	Kinds []*Kind `yaml:"kinds,omitempty"`
 	// Computed/derived fields (not serialized).
 	Proj              *Project `yaml:"-"`
 	Name              string   `yaml:"-"`
 	EnvironmentPrefix string   `yaml:"-"`
 	// APIImportPath is like: v1 "module/pkg/api/apiserver/v1"
-	APIImportPath   string `yaml:"-"`
-	R               *REST  `yaml:"-"`
-	TypedClientName string `yaml:"-"`
+	APIImportPath string `yaml:"-"`
+	R             *REST  `yaml:"-"`
+	// Dependencies are the clients of the web servers listed in DependsOn.
+	Dependencies []*Client `yaml:"-"`
+	// TypedClient is the client whose files are being generated.
+	TypedClient *Client `yaml:"-"`
 }
 
 // Complete populates derived fields and sensible defaults.
@@ -102,6 +475,11 @@ func (ws *WebServer) Complete(proj *Project) *WebServer {
 		ws.GRPCServiceName = strutil.UpperFirst(ws.Name)
 	}
 
+	// The outbox publishes to the broker of the server, which defaults to the outbox broker.
+	if ws.Broker == "" {
+		ws.Broker = ws.Outbox
+	}
+
 	// Environment variable prefix: PROJECT_COMPONENT (uppercased).
 	ws.EnvironmentPrefix = strings.ReplaceAll(fmt.Sprintf("%s_%s",
 		strings.ToUpper(proj.D.ProjectName),
@@ -116,6 +494,19 @@ func (ws *WebServer) Complete(proj *Project) *WebServer {
 		ws.Proj.D.APIVersion,
 	)
 
+	// Resolve the web servers called by the gRPC clients.
+	for _, c := range ws.Clients {
+		if c.IsGRPC() {
+			c.Server = proj.FindWebServer(c.Param("server"))
+		}
+	}
+
+	// Derive the clients of the web servers the server depends on.
+	ws.Dependencies = nil
+	for _, binaryName := range ws.DependsOn {
+		ws.Dependencies = append(ws.Dependencies, NewDependencyClient(binaryName, proj.FindWebServer(binaryName)))
+	}
+
 	return ws
 }
 
@@ -139,6 +530,16 @@ func (ws *WebServer) Handler() string {
 	return filepath.Join(ws.Base(), "handler")
 }
 
+// Logic returns the directory of the go-zero logics, shared by the REST and zrpc servers.
+func (ws *WebServer) Logic() string {
+	return filepath.Join(ws.Base(), "logic")
+}
+
+// RPCServer returns the directory of the zrpc server of a go-zero component.
+func (ws *WebServer) RPCServer() string {
+	return filepath.Join(ws.Base(), "server")
+}
+
 // Biz returns the business logic directory for the component.
 func (ws *WebServer) Biz() string {
 	return filepath.Join(ws.Base(), "biz")
@@ -149,6 +550,20 @@ func (ws *WebServer) Store() string {
 	return filepath.Join(ws.Base(), "store")
 }
 
+// Migrations returns the directory of the versioned schema migrations for the component.
+func (ws *WebServer) Migrations() string {
+	return filepath.Join(ws.Base(), "migrations")
+}
+
+// KindMigrations returns the directory of the migrations of the table of a kind: the tables of
+// the kinds stored in a named datasource are migrated in that datasource only.
+func (ws *WebServer) KindMigrations(k *Kind) string {
+	if k.Datasource == "" {
+		return ws.Migrations()
+	}
+	return filepath.Join(ws.Migrations(), "datasources", k.Datasource)
+}
+
 // RESTBiz returns the business logic directory for the specified rest resource.
 func (ws *WebServer) RESTBiz() string {
 	return filepath.Join(
@@ -170,11 +585,11 @@ func (ws *WebServer) API() string {
 	return filepath.Join("pkg/api", ws.Name, ws.Proj.D.APIVersion)
 }
 
-// PrepareRESTMetadata constructs REST metadata for a given kind.
-func (ws *WebServer) PrepareRESTMetadata(kindPath string) {
+// NewREST constructs REST naming metadata for a given kind path and API version.
+func NewREST(kindPath string, apiVersion string) *REST {
 	lastKind := filepath.Base(kindPath)
 	kind := strings.ReplaceAll(kindPath, "/", "_")
-	upperVer := strings.ToUpper(ws.Proj.D.APIVersion)
+	upperVer := strings.ToUpper(apiVersion)
 
 	r := REST{
 		SingularName:       strutil.UpperFirst(strutil.CamelCase(kind)),
@@ -206,7 +621,135 @@ func (ws *WebServer) PrepareRESTMetadata(kindPath string) {
 		r.FileName = strings.ReplaceAll(r.ResourcePathPrefix, "/", "_") + "_" + r.FileName
 	}
 
-	ws.R = &r
+	return &r
+}
+
+// PrepareRESTMetadata constructs REST metadata for a given kind.
+// If the kind is recorded in ws.Kinds, its field spec is attached as well.
+func (ws *WebServer) PrepareRESTMetadata(kindPath string) {
+	r := NewREST(kindPath, ws.Proj.D.APIVersion)
+
+	if spec, ok := ws.FindKind(kindPath); ok {
+		r.Spec = spec
+		spec.AssignTags()
+		if spec.Nested {
+			segments := strings.Split(kindPath, "/")
+			for i := 1; i < len(segments); i++ {
+				parentPath := strings.Join(segments[:i], "/")
+				parent := NewREST(parentPath, ws.Proj.D.APIVersion)
+				// A nested parent is scoped to its own parents, which are the outer parents of the kind.
+				if ps, ok := ws.FindKind(parentPath); ok && ps.Nested {
+					parent.Parents = slices.Clone(r.Parents)
+				}
+				r.Parents = append(r.Parents, parent)
+			}
+		}
+		for _, v := range spec.Verbs {
+			r.Verbs = append(r.Verbs, &Verb{Name: v})
+		}
+		for _, f := range spec.Fields {
+			if f.IsRelation() {
+				f.Ref = NewREST(f.TargetKind(), ws.Proj.D.APIVersion)
+			}
+			switch {
+			case f.IsBelongsTo():
+				r.BelongsTo = append(r.BelongsTo, f)
+			case f.IsHasMany():
+				f.Inverse = ws.inverseOf(kindPath, f)
+				r.HasMany = append(r.HasMany, f)
+			default:
+				r.Fields = append(r.Fields, f)
+			}
+		}
+	}
+
+	ws.R = r
+}
+
+// inverseOf returns the belongs_to field on the target of a has_many relation that points back
+// to kindPath. If the target has not been recorded, the conventional "<kind>_id" foreign key is assumed.
+func (ws *WebServer) inverseOf(kindPath string, f *Field) *Field {
+	if target, ok := ws.FindKind(f.TargetKind()); ok {
+		for _, tf := range target.Fields {
+			if tf.IsBelongsTo() && tf.TargetKind() == kindPath {
+				return tf
+			}
+		}
+	}
+	return &Field{Name: filepath.Base(kindPath), Type: known.RelationBelongsTo}
+}
+
+// RESTs returns the REST metadata of every recorded kind, in the order of Kinds.
+func (ws *WebServer) RESTs() []*REST {
+	current := ws.R
+	defer func() { ws.R = current }()
+
+	rs := make([]*REST, 0, len(ws.Kinds))
+	for _, k := range ws.Kinds {
+		ws.PrepareRESTMetadata(k.Name)
+		rs = append(rs, ws.R)
+	}
+	return rs
+}
+
+// IsLoadable reports whether the GraphQL endpoint resolves the relation f of the current kind with
+// a DataLoader. The target must be a top-level kind recorded in Kinds, and the target of a has_many
+// relation must belong to the current kind, so that it can be listed by its foreign key.
+func (ws *WebServer) IsLoadable(f *Field) bool {
+	target, ok := ws.FindKind(f.TargetKind())
+	if !ok || target.Nested {
+		return false
+	}
+	if f.IsHasMany() {
+		return slices.Contains(target.Fields, f.Inverse)
+	}
+	return true
+}
+
+// FindKind returns the recorded spec of the given kind, if any.
+func (ws *WebServer) FindKind(kindPath string) (*Kind, bool) {
+	for _, k := range ws.Kinds {
+		if k.Name == kindPath {
+			return k, true
+		}
+	}
+	return nil, false
+}
+
+// UpsertKind records the kind of spec and merges spec into it. Fields replace the recorded
+// fields of the same name and verbs are appended. A kind that has been recorded as nested, soft
+// deleted, versioned, cached or published through the outbox stays so, and the pagination mode,
+// datasource, table and proto file are only changed when they are set in spec.
+func (ws *WebServer) UpsertKind(spec *Kind) *Kind {
+	k, ok := ws.FindKind(spec.Name)
+	if !ok {
+		k = &Kind{Name: spec.Name}
+		ws.Kinds = append(ws.Kinds, k)
+	}
+	k.MergeFields(spec.Fields)
+	k.MergeVerbs(spec.Verbs)
+	k.Nested = k.Nested || spec.Nested
+	k.SoftDelete = k.SoftDelete || spec.SoftDelete
+	k.ResourceVersion = k.ResourceVersion || spec.ResourceVersion
+	k.Cache = k.Cache || spec.Cache
+	k.Outbox = k.Outbox || spec.Outbox
+	switch spec.Pagination {
+	case "":
+	case known.PaginationOffset:
+		k.Pagination = ""
+	default:
+		k.Pagination = spec.Pagination
+	}
+	if spec.Datasource != "" {
+		k.Datasource = spec.Datasource
+	}
+	if spec.Table != "" {
+		k.Table = spec.Table
+	}
+	if spec.Proto != "" {
+		k.Proto = spec.Proto
+	}
+	return k
 }
 
 // SetREST attaches REST metadata for later template rendering.
@@ -215,6 +758,181 @@ func (ws *WebServer) SetREST(meta *REST) *WebServer {
 	return ws
 }
 
+// Models returns the names of the GORM models of the kinds of the web server, including the
+// built-in user kind (e.g., "UserM", "PostM").
+func (ws *WebServer) Models() []string {
+	var models []string
+	if ws.WithUser {
+		models = append(models, NewREST("user", "").GORMModel)
+	}
+	for _, k := range ws.Kinds {
+		if k.Name == "user" && ws.WithUser {
+			continue
+		}
+		models = append(models, NewREST(k.Name, "").GORMModel)
+	}
+	return models
+}
+
+// MigrationPairs returns the files that apply the versioned schema migrations of the component:
+// the migrate command, the embedded migrations with one directory per SQL dialect, and the runner.
+// Each named datasource has its own migrations, with one directory per SQL dialect.
+// go-zero services have no migrate command: they apply the pending migrations when they start.
+func (ws *WebServer) MigrationPairs() map[string]string {
+	pairs := map[string]string{
+		filepath.Join(ws.Migrations(), "migrations.go"):                 "/project/internal/apiserver/migrations/migrations.go",
+		filepath.Join(ws.Proj.InternalPkg(), "migrate/migrate.go"):      "/project/internal/pkg/migrate/migrate.go",
+		filepath.Join(ws.Proj.InternalPkg(), "migrate/migrate_test.go"): "/project/internal/pkg/migrate/migrate_test.go",
+	}
+	if ws.WebFramework != known.WebFrameworkGoZero {
+		pairs[filepath.Join("cmd", ws.BinaryName, "app/migrate.go")] = "/project/cmd/mb-apiserver/app/migrate.go"
+		pairs[filepath.Join(ws.Base(), "db.go")] = "/project/internal/apiserver/db.go"
+	}
+	for _, dialect := range []string{"mysql", "postgres", "sqlite"} {
+		pairs[filepath.Join(ws.Migrations(), dialect, ".keep")] = "/keep.tpl"
+		for _, name := range ws.Datasources {
+			pairs[filepath.Join(ws.KindMigrations(&Kind{Datasource: name}), dialect, ".keep")] = "/keep.tpl"
+		}
+	}
+	return pairs
+}
+
+// CachePairs returns the files of the read-through cache of the stores: the cache package with
+// the backend of the web server, the helpers shared by the cached kinds with their tests and,
+// with OpenTelemetry, the metrics of the cache lookups.
+func (ws *WebServer) CachePairs() map[string]string {
+	pairs := map[string]string{
+		filepath.Join(ws.Proj.InternalPkg(), "cache/cache.go"):      "/project/internal/pkg/cache/cache.go",
+		filepath.Join(ws.Proj.InternalPkg(), "cache/cache_test.go"): "/project/internal/pkg/cache/cache_test.go",
+		filepath.Join(ws.Proj.InternalPkg(), "cache/lru.go"):        "/project/internal/pkg/cache/lru.go",
+		filepath.Join(ws.Proj.InternalPkg(), "cache/options.go"):    "/project/internal/pkg/cache/options.go",
+		filepath.Join(ws.Store(), "cache.go"):                       "/project/internal/apiserver/store/cache.go",
+		filepath.Join(ws.Store(), "cache_test.go"):                  "/project/internal/apiserver/store/cache_test.go",
+	}
+	if ws.Cache == known.CacheRedis {
+		pairs[filepath.Join(ws.Proj.InternalPkg(), "cache/redis.go")] = "/project/internal/pkg/cache/redis.go"
+	}
+	if ws.WithOTel {
+		pairs[filepath.Join(ws.Pkg(), "metrics/cache.go")] = "/project/internal/apiserver/pkg/metrics/cache.go"
+	}
+	return pairs
+}
+
+// OutboxPairs returns the files of the transactional outbox: the outbox package with the relay,
+// which publishes the messages with the producer of the mq package, and the store of the outbox
+// messages.
+func (ws *WebServer) OutboxPairs() map[string]string {
+	return map[string]string{
+		filepath.Join(ws.Proj.InternalPkg(), "outbox/outbox.go"):     "/project/internal/pkg/outbox/outbox.go",
+		filepath.Join(ws.Proj.InternalPkg(), "outbox/relay.go"):      "/project/internal/pkg/outbox/relay.go",
+		filepath.Join(ws.Proj.InternalPkg(), "outbox/relay_test.go"): "/project/internal/pkg/outbox/relay_test.go",
+		filepath.Join(ws.Proj.InternalPkg(), "outbox/options.go"):    "/project/internal/pkg/outbox/options.go",
+		filepath.Join(ws.Store(), "outbox.go"):                       "/project/internal/apiserver/store/outbox.go",
+	}
+}
+
+// BrokerPairs returns the files of the mq package: the broker-agnostic producer and consumer,
+// their implementation for the broker of the server and the in-memory broker of the unit tests.
+// The Kafka consumer also has its partition consumers and the tests of its offsets.
+func (ws *WebServer) BrokerPairs() map[string]string {
+	return brokerPairs(ws.Proj, ws.Broker)
+}
+
+// brokerPairs returns the files of the mq package of the project for the given broker, which web
+// servers and MQ servers share.
+func brokerPairs(proj *Project, broker string) map[string]string {
+	dir := filepath.Join(proj.InternalPkg(), "mq")
+	names := []string{broker + ".go", "mq.go", "dispatcher.go", "options.go", "memory.go", "mq_test.go"}
+	if broker == known.BrokerKafka {
+		names = append(names, "kafka_partition.go", "kafka_test.go")
+	}
+	pairs := make(map[string]string, len(names))
+	for _, name := range names {
+		pairs[filepath.Join(dir, name)] = "/project/internal/pkg/mq/" + name
+	}
+	return pairs
+}
+
+// OutboxTable returns the table of the outbox messages, named like the tables of the kinds
+// (e.g., "mb_outbox").
+func (ws *WebServer) OutboxTable() string {
+	return ProjectPrefix(ws.BinaryName) + "_outbox"
+}
+
+// IsResolved reports whether the queries of the stores are routed by the database resolver, to
+// read replicas or to the named datasources.
+func (ws *WebServer) IsResolved() bool {
+	return ws.ReadReplicas || len(ws.Datasources) > 0
+}
+
+// DBOptions returns the name of the genericoptions type that configures the SQL database of the
+// web server (e.g., "MySQLOptions"), or an empty string for storage types without options.
+func (ws *WebServer) DBOptions() string {
+	switch ws.StorageType {
+	case known.StorageTypeMariaDB:
+		return "MySQLOptions"
+	case known.StorageTypePostgreSQL:
+		return "PostgreSQLOptions"
+	case known.StorageTypeSQLite:
+		return "SQLiteOptions"
+	default:
+		return ""
+	}
+}
+
+// DBConfigKey returns the key of the options of the SQL database of the web server in its
+// configuration file (e.g., "mysql").
+func (ws *WebServer) DBConfigKey() string {
+	if ws.StorageType == known.StorageTypeMariaDB {
+		return "mysql"
+	}
+	return ws.StorageType
+}
+
+// GoZeroPairs returns the scaffolding of a go-zero service: the main package that runs the REST
+// and zrpc servers, their configuration and service context, the REST route registry and the
+// zrpc implementation of the gRPC service, whose methods are added by `create api`.
+func (ws *WebServer) GoZeroPairs() map[string]string {
+	apiDir := filepath.Join("pkg/api", ws.Name, ws.Proj.D.APIVersion)
+	return map[string]string{
+		filepath.Join("cmd", ws.BinaryName, "main.go"):    "/project/cmd/mb-apiserver/gozero/main.go",
+		filepath.Join(ws.Base(), "config/config.go"):      "/project/internal/apiserver/config/config.go",
+		filepath.Join(ws.Base(), "svc/servicecontext.go"): "/project/internal/apiserver/svc/servicecontext.go",
+		filepath.Join(ws.Handler(), "handler.go"):         "/project/internal/apiserver/handler/gozero/handler.go",
+		filepath.Join(ws.Logic(), "logic.go"):             "/project/internal/apiserver/logic/logic.go",
+		filepath.Join(ws.RPCServer(), "server.go"):        "/project/internal/apiserver/server/server.go",
+		filepath.Join(apiDir, ws.Name+".proto"):           "/project/pkg/api/apiserver/v1/apiserver.proto",
+	}
+}
+
+// Graph returns the directory of the GraphQL endpoint for the component.
+func (ws *WebServer) Graph() string {
+	return filepath.Join(ws.Base(), "graph")
+}
+
+// GraphQLPairs returns the files of the GraphQL endpoint: the HTTP handler, the root resolver,
+// the DataLoaders and the connections shared by the kinds, and the schema of their queries and
+// mutations, which `create api` regenerates.
+func (ws *WebServer) GraphQLPairs() map[string]string {
+	return map[string]string{
+		filepath.Join(ws.Graph(), "handler.go"):            "/project/internal/apiserver/graph/handler.go",
+		filepath.Join(ws.Graph(), "resolver.go"):           "/project/internal/apiserver/graph/resolver.go",
+		filepath.Join(ws.Graph(), "loader.go"):             "/project/internal/apiserver/graph/loader.go",
+		filepath.Join(ws.Graph(), "connection.go"):         "/project/internal/apiserver/graph/connection.go",
+		filepath.Join(ws.Graph(), "scalars.go"):            "/project/internal/apiserver/graph/scalars.go",
+		filepath.Join(ws.Graph(), "schema/schema.graphql"): "/project/internal/apiserver/graph/schema/schema.graphql",
+	}
+}
+
+// DatasourcePairs returns the files that route the kinds created with `create api --datasource`
+// to the named datasources of the web server.
+func (ws *WebServer) DatasourcePairs() map[string]string {
+	return map[string]string{
+		filepath.Join(ws.Store(), "datasource.go"):      "/project/internal/apiserver/store/datasource.go",
+		filepath.Join(ws.Store(), "datasource_test.go"): "/project/internal/apiserver/store/datasource_test.go",
+	}
+}
+
 // Pairs returns a map of destination relative paths to template paths.
 // It drives file generation for this component.
 func (ws *WebServer) Pairs() map[string]string {
@@ -232,10 +950,16 @@ func (ws *WebServer) Pairs() map[string]string {
 		pairs[dst] = tpl
 	}
 
-	// Common command and component scaffolding.
-	add(filepath.Join("cmd", ws.BinaryName, "app/options/options.go"), "/project/cmd/mb-apiserver/app/options/options.go")
-	add(filepath.Join("cmd", ws.BinaryName, "app/server.go"), "/project/cmd/mb-apiserver/app/server.go")
-	add(filepath.Join("cmd", ws.BinaryName, "main.go"), "/project/cmd/mb-apiserver/main.go")
+	// Common command and component scaffolding. go-zero services are bootstrapped by their own
+	// main package and service context instead (see GoZeroPairs).
+	if ws.WebFramework != known.WebFrameworkGoZero {
+		add(filepath.Join("cmd", ws.BinaryName, "app/options/options.go"), "/project/cmd/mb-apiserver/app/options/options.go")
+		add(filepath.Join("cmd", ws.BinaryName, "app/server.go"), "/project/cmd/mb-apiserver/app/server.go")
+		add(filepath.Join("cmd", ws.BinaryName, "main.go"), "/project/cmd/mb-apiserver/main.go")
+		add(filepath.Join(baseDir, "server.go"), "/project/internal/apiserver/server.go")
+		add(filepath.Join(baseDir, "wire.go"), "/project/internal/apiserver/wire.go")
+		add(filepath.Join(baseDir, "wire_gen.go"), "/project/internal/apiserver/wire_gen.go")
+	}
 	add(filepath.Join(ws.Proj.Configs(), ws.BinaryName+".yaml"), "/project/configs/mb-apiserver.yaml")
 
 	// Core internal packages reused across frameworks.
@@ -250,6 +974,7 @@ func (ws *WebServer) Pairs() map[string]string {
 	add(filepath.Join(ws.Pkg(), "validation/validation.go"), "/project/internal/apiserver/pkg/validation/validation.go")
 
 	add(filepath.Join(internalPkg, "contextx/contextx.go"), "/project/internal/pkg/contextx/contextx.go")
+	add(filepath.Join(internalPkg, "contextx/contextx_test.go"), "/project/internal/pkg/contextx/contextx_test.go")
 	add(filepath.Join(internalPkg, "contextx/doc.go"), "/project/internal/pkg/contextx/doc.go")
 	add(filepath.Join(internalPkg, "known/doc.go"), "/project/internal/pkg/known/doc.go")
 	add(filepath.Join(internalPkg, "known/known.go"), "/project/internal/pkg/known/known.go")
@@ -263,9 +988,9 @@ func (ws *WebServer) Pairs() map[string]string {
 	add(filepath.Join(internalPkg, "errno/doc.go"), "/project/internal/pkg/errno/doc.go")
 	add(filepath.Join(internalPkg, "errno/code.go"), "/project/internal/pkg/errno/code.go")
 
-	add(filepath.Join(baseDir, "server.go"), "/project/internal/apiserver/server.go")
-	add(filepath.Join(baseDir, "wire.go"), "/project/internal/apiserver/wire.go")
-	add(filepath.Join(baseDir, "wire_gen.go"), "/project/internal/apiserver/wire_gen.go")
+	for dst, tpl := range ws.MigrationPairs() {
+		add(dst, tpl)
+	}
 
 	// Default proto for examples.
 	add(filepath.Join(apiDir, "example.proto"), "/project/pkg/api/apiserver/v1/example.proto")
@@ -318,6 +1043,28 @@ func (ws *WebServer) Pairs() map[string]string {
 			add(filepath.Join("examples/client/user/main.go"), "/project/examples/client/user/main.go")
 			add(filepath.Join("examples/helper/helper.go"), "/project/examples/helper/helper.go")
 			add(filepath.Join("examples/helper/README.md"), "/project/examples/helper/README.md")
+		case known.WebFrameworkHertz:
+			add(filepath.Join(handlerDir, "user.go"), "/project/internal/apiserver/handler/hertz/user.go")
+			add(filepath.Join(internalPkg, "middleware/hertz/authn.go"), "/project/internal/pkg/middleware/hertz/authn.go")
+			add(filepath.Join(internalPkg, "middleware/hertz/authz.go"), "/project/internal/pkg/middleware/hertz/authz.go")
+		case known.WebFrameworkKitex:
+			add(filepath.Join(handlerDir, "user.go"), "/project/internal/apiserver/handler/kitex/user.go")
+			add(filepath.Join(internalPkg, "middleware/kitex/authn.go"), "/project/internal/pkg/middleware/kitex/authn.go")
+			add(filepath.Join(internalPkg, "middleware/kitex/authz.go"), "/project/internal/pkg/middleware/kitex/authz.go")
+			add(filepath.Join("examples/client/user/main.go"), "/project/examples/client/user/main.go")
+			add(filepath.Join("examples/helper/helper.go"), "/project/examples/helper/helper.go")
+			add(filepath.Join("examples/helper/README.md"), "/project/examples/helper/README.md")
+		case known.WebFrameworkNetHTTP:
+			add(filepath.Join(handlerDir, "user.go"), "/project/internal/apiserver/handler/nethttp/user.go")
+			add(filepath.Join(internalPkg, "middleware/nethttp/authn.go"), "/project/internal/pkg/middleware/nethttp/authn.go")
+			add(filepath.Join(internalPkg, "middleware/nethttp/authz.go"), "/project/internal/pkg/middleware/nethttp/authz.go")
+		case known.WebFrameworkConnect:
+			add(filepath.Join(handlerDir, "user.go"), "/project/internal/apiserver/handler/connect/user.go")
+			add(filepath.Join(internalPkg, "middleware/connect/authn.go"), "/project/internal/pkg/middleware/connect/authn.go")
+			add(filepath.Join(internalPkg, "middleware/connect/authz.go"), "/project/internal/pkg/middleware/connect/authz.go")
+			add(filepath.Join("examples/client/user/main.go"), "/project/examples/client/user/main.go")
+			add(filepath.Join("examples/helper/helper.go"), "/project/examples/helper/helper.go")
+			add(filepath.Join("examples/helper/README.md"), "/project/examples/helper/README.md")
 		}
 
 		// Conversion/validation
@@ -339,6 +1086,21 @@ func (ws *WebServer) Pairs() map[string]string {
 		case known.WebFrameworkGRPC:
 			add(filepath.Join(handlerDir, "healthz.go"), "/project/internal/apiserver/handler/grpc/healthz.go")
 			add(filepath.Join("examples/client/health/main.go"), "/project/examples/client/health/main.go")
+		case known.WebFrameworkHertz:
+			add(filepath.Join(handlerDir, "healthz.go"), "/project/internal/apiserver/handler/hertz/healthz.go")
+		case known.WebFrameworkKitex:
+			add(filepath.Join(handlerDir, "healthz.go"), "/project/internal/apiserver/handler/kitex/healthz.go")
+			add(filepath.Join("examples/client/health/main.go"), "/project/examples/client/health/main.go")
+		case known.WebFrameworkNetHTTP:
+			add(filepath.Join(handlerDir, "healthz.go"), "/project/internal/apiserver/handler/nethttp/healthz.go")
+		case known.WebFrameworkConnect:
+			add(filepath.Join(handlerDir, "healthz.go"), "/project/internal/apiserver/handler/connect/healthz.go")
+			add(filepath.Join("examples/client/health/main.go"), "/project/examples/client/health/main.go")
+		case known.WebFrameworkGoZero:
+			add(filepath.Join(handlerDir, "healthz.go"), "/project/internal/apiserver/handler/gozero/healthz.go")
+			add(filepath.Join(ws.Logic(), "healthz.go"), "/project/internal/apiserver/logic/healthz.go")
+			add(filepath.Join(ws.RPCServer(), "healthz.go"), "/project/internal/apiserver/server/healthz.go")
+			add(filepath.Join("examples/client/health/main.go"), "/project/examples/client/health/main.go")
 		}
 	}
 
@@ -396,6 +1158,68 @@ func (ws *WebServer) Pairs() map[string]string {
 		if ws.WithOTel {
 			add(filepath.Join(internalPkg, "middleware/grpc/context.go"), "/project/internal/pkg/middleware/grpc/context.go")
 		}
+	case known.WebFrameworkHertz:
+		add(filepath.Join(internalPkg, "middleware/hertz/doc.go"), "/project/internal/pkg/middleware/hertz/doc.go")
+		add(filepath.Join(internalPkg, "middleware/hertz/header.go"), "/project/internal/pkg/middleware/hertz/header.go")
+		add(filepath.Join(internalPkg, "middleware/hertz/requestid.go"), "/project/internal/pkg/middleware/hertz/requestid.go")
+		add(filepath.Join(baseDir, "httpserver.go"), "/project/internal/apiserver/hertzserver.go")
+		add(filepath.Join(handlerDir, "handler.go"), "/project/internal/apiserver/handler/hertz/handler.go")
+		add(filepath.Join(ws.Proj.Scripts(), "startup-test.sh"), "/project/scripts/startup-test.sh")
+		if ws.WithOTel {
+			add(filepath.Join(internalPkg, "middleware/hertz/context.go"), "/project/internal/pkg/middleware/hertz/context.go")
+		}
+	case known.WebFrameworkNetHTTP:
+		add(filepath.Join(internalPkg, "middleware/nethttp/doc.go"), "/project/internal/pkg/middleware/nethttp/doc.go")
+		add(filepath.Join(internalPkg, "middleware/nethttp/header.go"), "/project/internal/pkg/middleware/nethttp/header.go")
+		add(filepath.Join(internalPkg, "middleware/nethttp/requestid.go"), "/project/internal/pkg/middleware/nethttp/requestid.go")
+		add(filepath.Join(internalPkg, "middleware/nethttp/recovery.go"), "/project/internal/pkg/middleware/nethttp/recovery.go")
+		add(filepath.Join(baseDir, "httpserver.go"), "/project/internal/apiserver/nethttpserver.go")
+		add(filepath.Join(handlerDir, "handler.go"), "/project/internal/apiserver/handler/nethttp/handler.go")
+		add(filepath.Join(handlerDir, "router.go"), "/project/internal/apiserver/handler/nethttp/router.go")
+		add(filepath.Join(ws.Proj.Scripts(), "startup-test.sh"), "/project/scripts/startup-test.sh")
+		if ws.WithOTel {
+			add(filepath.Join(internalPkg, "middleware/nethttp/context.go"), "/project/internal/pkg/middleware/nethttp/context.go")
+		}
+	case known.WebFrameworkKitex:
+		// kitex middlewares
+		add(filepath.Join(internalPkg, "middleware/kitex/doc.go"), "/project/internal/pkg/middleware/kitex/doc.go")
+		add(filepath.Join(internalPkg, "middleware/kitex/requestid.go"), "/project/internal/pkg/middleware/kitex/requestid.go")
+		add(filepath.Join(internalPkg, "middleware/kitex/defaulter.go"), "/project/internal/pkg/middleware/kitex/defaulter.go")
+		add(filepath.Join(internalPkg, "middleware/kitex/validator.go"), "/project/internal/pkg/middleware/kitex/validator.go")
+		add(filepath.Join(internalPkg, "middleware/kitex/status.go"), "/project/internal/pkg/middleware/kitex/status.go")
+		add(filepath.Join(internalPkg, "middleware/kitex/selector.go"), "/project/internal/pkg/middleware/kitex/selector.go")
+		add(filepath.Join(internalPkg, "kitexsvc/kitexsvc.go"), "/project/internal/pkg/kitexsvc/kitexsvc.go")
+
+		// apiserver proto and server: the service is served from its protobuf descriptor, so the
+		// proto is compiled by protoc-gen-go and protoc-gen-go-grpc like for the gRPC servers.
+		add(filepath.Join(apiDir, ws.Name+".proto"), "/project/pkg/api/apiserver/v1/apiserver.proto")
+		add(filepath.Join(baseDir, "kitexserver.go"), "/project/internal/apiserver/kitexserver.go")
+		add(filepath.Join(handlerDir, "handler.go"), "/project/internal/apiserver/handler/kitex/handler.go")
+		if ws.WithOTel {
+			add(filepath.Join(internalPkg, "middleware/kitex/context.go"), "/project/internal/pkg/middleware/kitex/context.go")
+		}
+	case known.WebFrameworkConnect:
+		// connect interceptors
+		add(filepath.Join(internalPkg, "middleware/connect/doc.go"), "/project/internal/pkg/middleware/connect/doc.go")
+		add(filepath.Join(internalPkg, "middleware/connect/cors.go"), "/project/internal/pkg/middleware/connect/cors.go")
+		add(filepath.Join(internalPkg, "middleware/connect/requestid.go"), "/project/internal/pkg/middleware/connect/requestid.go")
+		add(filepath.Join(internalPkg, "middleware/connect/defaulter.go"), "/project/internal/pkg/middleware/connect/defaulter.go")
+		add(filepath.Join(internalPkg, "middleware/connect/validator.go"), "/project/internal/pkg/middleware/connect/validator.go")
+		add(filepath.Join(internalPkg, "middleware/connect/status.go"), "/project/internal/pkg/middleware/connect/status.go")
+		add(filepath.Join(internalPkg, "middleware/connect/selector.go"), "/project/internal/pkg/middleware/connect/selector.go")
+
+		// apiserver proto and server: the procedures are the methods of the gRPC service, so the
+		// proto is compiled by protoc-gen-go and protoc-gen-go-grpc like for the gRPC servers.
+		add(filepath.Join(apiDir, ws.Name+".proto"), "/project/pkg/api/apiserver/v1/apiserver.proto")
+		add(filepath.Join(baseDir, "connectserver.go"), "/project/internal/apiserver/connectserver.go")
+		add(filepath.Join(handlerDir, "handler.go"), "/project/internal/apiserver/handler/connect/handler.go")
+		if ws.WithOTel {
+			add(filepath.Join(internalPkg, "middleware/connect/context.go"), "/project/internal/pkg/middleware/connect/context.go")
+		}
+	case known.WebFrameworkGoZero:
+		for dst, tpl := range ws.GoZeroPairs() {
+			add(dst, tpl)
+		}
 	case known.WebFrameworkGRPCGateway:
 		// TODO: add grpc-gateway templates if needed
 
@@ -409,9 +1233,48 @@ func (ws *WebServer) Pairs() map[string]string {
 		add(filepath.Join(handlerDir, "handler.go"), "/project/internal/apiserver/handler/grpc/handler.go")
 	}
 
-	if len(ws.Clients) > 0 {
+	if len(ws.AllClients()) > 0 {
 		add(filepath.Join(ws.Pkg(), "clientset/clientset.go"), "/project/internal/apiserver/pkg/clientset/clientset.go")
 	}
+	if ws.Discovery() {
+		add(filepath.Join(internalPkg, "discovery/doc.go"), "/project/internal/pkg/discovery/doc.go")
+		add(filepath.Join(internalPkg, "discovery/polaris.go"), "/project/internal/pkg/discovery/polaris.go")
+	}
+
+	// Optional read-through cache of the stores.
+	if ws.Cache != "" {
+		for dst, tpl := range ws.CachePairs() {
+			add(dst, tpl)
+		}
+	}
+
+	// Optional MQ layer, over the message broker of the server.
+	if ws.Broker != "" {
+		for dst, tpl := range ws.BrokerPairs() {
+			add(dst, tpl)
+		}
+	}
+
+	// Optional transactional outbox of the events of the kinds.
+	if ws.Outbox != "" {
+		for dst, tpl := range ws.OutboxPairs() {
+			add(dst, tpl)
+		}
+	}
+
+	// Optional named datasources of the kinds.
+	if len(ws.Datasources) > 0 {
+		for dst, tpl := range ws.DatasourcePairs() {
+			add(dst, tpl)
+		}
+	}
+
+	// Optional GraphQL endpoint.
+	if ws.WithGraphQL {
+		for dst, tpl := range ws.GraphQLPairs() {
+			add(dst, tpl)
+		}
+	}
 
 	// Ensure api dir exists in VCS.
 	add(filepath.Join("api/.keep"), "/keep.tpl")
@@ -429,6 +1292,13 @@ func (td *TemplateData) AbsPath(relPath string) string {
 	return td.Project.Join(relPath)
 }
 
+// ProjectPrefix extracts the project prefix from a binary name, which prefixes the tables of the
+// component (e.g., "mb" from "mb-apiserver" and "mb-order-apiserver").
+func ProjectPrefix(binaryName string) string {
+	prefix, _, _ := strings.Cut(binaryName, "-")
+	return prefix
+}
+
 // GetComponentName extracts the component name from a binary name.
 func GetComponentName(binaryName string) string {
 	parts := strings.Split(binaryName, "-")
@@ -0,0 +1,209 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBrokerPairs(t *testing.T) {
+	proj := &Project{
+		D: &GeneratedData{},
+		WebServers: []*WebServer{
+			{BinaryName: "mb-apiserver", WebFramework: "gin", Outbox: "kafka"},
+			{BinaryName: "mb-billing", WebFramework: "gin", Broker: "nats"},
+		},
+	}
+	for _, ws := range proj.WebServers {
+		ws.Complete(proj)
+	}
+
+	// The broker defaults to the outbox broker.
+	assert.Equal(t, "kafka", proj.WebServers[0].Broker)
+
+	var files []string
+	for dst := range proj.WebServers[1].BrokerPairs() {
+		files = append(files, dst)
+	}
+	sort.Strings(files)
+	assert.Equal(t, []string{
+		"internal/pkg/mq/dispatcher.go",
+		"internal/pkg/mq/memory.go",
+		"internal/pkg/mq/mq.go",
+		"internal/pkg/mq/mq_test.go",
+		"internal/pkg/mq/nats.go",
+		"internal/pkg/mq/options.go",
+	}, files)
+	assert.Equal(t, "/project/internal/pkg/mq/nats.go", proj.WebServers[1].BrokerPairs()["internal/pkg/mq/nats.go"])
+
+	// The Kafka consumer handles its partitions in files of its own.
+	kafka := proj.WebServers[0].BrokerPairs()
+	assert.Len(t, kafka, 8)
+	assert.Contains(t, kafka, "internal/pkg/mq/kafka_partition.go")
+	assert.Contains(t, kafka, "internal/pkg/mq/kafka_test.go")
+}
+
+func TestUpsertKind(t *testing.T) {
+	ws := &WebServer{}
+	k := ws.UpsertKind(&Kind{
+		Name:       "post",
+		Fields:     []*Field{{Name: "title", Type: "string"}},
+		Verbs:      []string{"publish"},
+		Pagination: "cursor",
+		SoftDelete: true,
+		Cache:      true,
+		Datasource: "archive",
+		Table:      "legacy_posts",
+	})
+
+	// Merging into the recorded kind keeps the attributes that spec does not set.
+	got := ws.UpsertKind(&Kind{
+		Name:       "post",
+		Fields:     []*Field{{Name: "title", Type: "text"}, {Name: "views", Type: "int"}},
+		Verbs:      []string{"archive", "publish"},
+		Outbox:     true,
+		Pagination: "offset",
+	})
+	assert.Same(t, k, got)
+	assert.Len(t, ws.Kinds, 1)
+	assert.Equal(t, []*Field{{Name: "title", Type: "text", Tag: 1}, {Name: "views", Type: "int", Tag: 2}}, got.Fields)
+	assert.Equal(t, []string{"publish", "archive"}, got.Verbs)
+	assert.True(t, got.SoftDelete)
+	assert.True(t, got.Cache)
+	assert.True(t, got.Outbox)
+	assert.False(t, got.ResourceVersion)
+	assert.Empty(t, got.Pagination)
+	assert.Equal(t, "archive", got.Datasource)
+	assert.Equal(t, "legacy_posts", got.Table)
+}
+
+func TestListFilters(t *testing.T) {
+	k := &Kind{Name: "post"}
+	k.MergeFields([]*Field{{Name: "title", Type: "text"}, {Name: "user", Type: "belongs_to"}, {Name: "rating", Type: "double"}})
+	r := NewREST("post", "v1")
+	for _, f := range k.Fields {
+		if f.IsBelongsTo() {
+			r.BelongsTo = append(r.BelongsTo, f)
+		} else {
+			r.Fields = append(r.Fields, f)
+		}
+	}
+
+	var got []string
+	for _, lf := range r.ListFilters() {
+		got = append(got, fmt.Sprintf("%s:%s:%s:%d", lf.Name, lf.Op, lf.Type, lf.Tag))
+	}
+	// Each field numbers its filters in its own block, in the order of its tag.
+	assert.Equal(t, []string{
+		"titlePrefix:prefix:string:1",
+		"ratingMin:min:double:9",
+		"ratingMax:max:double:10",
+		"userIDIn:in:string:6",
+	}, got)
+}
+
+func TestFrameworkPairs(t *testing.T) {
+	tests := []struct {
+		framework string
+		// want maps the files the server must generate to their templates.
+		want map[string]string
+		// absent lists the files the server must not generate.
+		absent []string
+	}{
+		{
+			framework: "go-zero",
+			want: map[string]string{
+				"cmd/mb-apiserver/main.go":                        "/project/cmd/mb-apiserver/gozero/main.go",
+				"internal/apiserver/config/config.go":             "/project/internal/apiserver/config/config.go",
+				"internal/apiserver/svc/servicecontext.go":        "/project/internal/apiserver/svc/servicecontext.go",
+				"internal/apiserver/handler/handler.go":           "/project/internal/apiserver/handler/gozero/handler.go",
+				"internal/apiserver/logic/logic.go":               "/project/internal/apiserver/logic/logic.go",
+				"internal/apiserver/server/server.go":             "/project/internal/apiserver/server/server.go",
+				"internal/apiserver/server/healthz.go":            "/project/internal/apiserver/server/healthz.go",
+				"pkg/api/apiserver/v1/apiserver.proto":            "/project/pkg/api/apiserver/v1/apiserver.proto",
+				"internal/apiserver/handler/healthz.go":           "/project/internal/apiserver/handler/gozero/healthz.go",
+				"internal/apiserver/store/store.go":               "/project/internal/apiserver/store/store.go",
+				"internal/apiserver/pkg/validation/validation.go": "/project/internal/apiserver/pkg/validation/validation.go",
+			},
+			// go-zero services are bootstrapped by their main package and service context.
+			absent: []string{
+				"cmd/mb-apiserver/app/server.go",
+				"cmd/mb-apiserver/app/options/options.go",
+				"internal/apiserver/server.go",
+				"internal/apiserver/wire.go",
+				"internal/apiserver/httpserver.go",
+			},
+		},
+		{
+			framework: "hertz",
+			want: map[string]string{
+				"internal/apiserver/httpserver.go":           "/project/internal/apiserver/hertzserver.go",
+				"internal/apiserver/handler/handler.go":      "/project/internal/apiserver/handler/hertz/handler.go",
+				"internal/apiserver/handler/healthz.go":      "/project/internal/apiserver/handler/hertz/healthz.go",
+				"internal/pkg/middleware/hertz/header.go":    "/project/internal/pkg/middleware/hertz/header.go",
+				"internal/pkg/middleware/hertz/requestid.go": "/project/internal/pkg/middleware/hertz/requestid.go",
+				"internal/apiserver/server.go":               "/project/internal/apiserver/server.go",
+				"pkg/api/apiserver/v1/healthz.proto":         "/project/pkg/api/apiserver/v1/healthz.proto",
+			},
+			// The hertz handlers register their routes themselves: there is no service proto.
+			absent: []string{"pkg/api/apiserver/v1/apiserver.proto", "internal/apiserver/grpcserver.go"},
+		},
+		{
+			framework: "nethttp",
+			want: map[string]string{
+				"internal/apiserver/httpserver.go":             "/project/internal/apiserver/nethttpserver.go",
+				"internal/apiserver/handler/handler.go":        "/project/internal/apiserver/handler/nethttp/handler.go",
+				"internal/apiserver/handler/router.go":         "/project/internal/apiserver/handler/nethttp/router.go",
+				"internal/apiserver/handler/healthz.go":        "/project/internal/apiserver/handler/nethttp/healthz.go",
+				"internal/pkg/middleware/nethttp/header.go":    "/project/internal/pkg/middleware/nethttp/header.go",
+				"internal/pkg/middleware/nethttp/requestid.go": "/project/internal/pkg/middleware/nethttp/requestid.go",
+				"internal/pkg/middleware/nethttp/recovery.go":  "/project/internal/pkg/middleware/nethttp/recovery.go",
+			},
+			absent: []string{"pkg/api/apiserver/v1/apiserver.proto", "internal/apiserver/grpcserver.go"},
+		},
+		{
+			framework: "kitex",
+			want: map[string]string{
+				"internal/apiserver/kitexserver.go":          "/project/internal/apiserver/kitexserver.go",
+				"internal/apiserver/handler/handler.go":      "/project/internal/apiserver/handler/kitex/handler.go",
+				"internal/apiserver/handler/healthz.go":      "/project/internal/apiserver/handler/kitex/healthz.go",
+				"internal/pkg/kitexsvc/kitexsvc.go":          "/project/internal/pkg/kitexsvc/kitexsvc.go",
+				"internal/pkg/middleware/kitex/validator.go": "/project/internal/pkg/middleware/kitex/validator.go",
+				"pkg/api/apiserver/v1/apiserver.proto":       "/project/pkg/api/apiserver/v1/apiserver.proto",
+				"examples/client/health/main.go":             "/project/examples/client/health/main.go",
+			},
+			absent: []string{"internal/apiserver/httpserver.go", "internal/apiserver/grpcserver.go"},
+		},
+		{
+			framework: "connect",
+			want: map[string]string{
+				"internal/apiserver/connectserver.go":          "/project/internal/apiserver/connectserver.go",
+				"internal/apiserver/handler/handler.go":        "/project/internal/apiserver/handler/connect/handler.go",
+				"internal/apiserver/handler/healthz.go":        "/project/internal/apiserver/handler/connect/healthz.go",
+				"internal/pkg/middleware/connect/cors.go":      "/project/internal/pkg/middleware/connect/cors.go",
+				"internal/pkg/middleware/connect/validator.go": "/project/internal/pkg/middleware/connect/validator.go",
+				"pkg/api/apiserver/v1/apiserver.proto":         "/project/pkg/api/apiserver/v1/apiserver.proto",
+				"examples/client/health/main.go":               "/project/examples/client/health/main.go",
+			},
+			absent: []string{"internal/apiserver/httpserver.go", "internal/apiserver/grpcserver.go"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.framework, func(t *testing.T) {
+			proj := &Project{
+				Metadata:   &Metadata{},
+				D:          &GeneratedData{APIVersion: "v1"},
+				WebServers: []*WebServer{{BinaryName: "mb-apiserver", WebFramework: tt.framework, WithHealthz: true}},
+			}
+			pairs := proj.WebServers[0].Complete(proj).Pairs()
+			for dst, tpl := range tt.want {
+				assert.Equal(t, tpl, pairs[dst], dst)
+			}
+			for _, dst := range tt.absent {
+				assert.NotContains(t, pairs, dst)
+			}
+		})
+	}
+}